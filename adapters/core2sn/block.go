@@ -0,0 +1,298 @@
+package core2sn
+
+import (
+	"fmt"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/starknet"
+	"github.com/NethermindEth/juno/utils"
+)
+
+func AdaptBlock(block *core.Block, commitments *core.BlockCommitments, status string) (*starknet.Block, error) {
+	txns := make([]*starknet.Transaction, len(block.Transactions))
+	for i, txn := range block.Transactions {
+		var err error
+		if txns[i], err = AdaptTransaction(txn); err != nil {
+			return nil, err
+		}
+	}
+
+	receipts := make([]*starknet.TransactionReceipt, len(block.Receipts))
+	for i, receipt := range block.Receipts {
+		receipts[i] = AdaptTransactionReceipt(receipt, uint64(i))
+	}
+
+	snBlock := &starknet.Block{
+		Hash:             block.Hash,
+		ParentHash:       block.ParentHash,
+		Number:           block.Number,
+		StateRoot:        block.GlobalStateRoot,
+		Status:           status,
+		Transactions:     txns,
+		Timestamp:        block.Timestamp,
+		Version:          block.ProtocolVersion,
+		Receipts:         receipts,
+		SequencerAddress: block.SequencerAddress,
+		L1GasPrice: &starknet.GasPrice{
+			PriceInWei: block.GasPrice,
+			PriceInFri: block.GasPriceSTRK,
+		},
+		L1DAMode:       starknet.L1DAMode(block.L1DAMode),
+		L1DataGasPrice: (*starknet.GasPrice)(block.L1DataGasPrice),
+	}
+	if commitments != nil {
+		snBlock.TransactionCommitment = commitments.TransactionCommitment
+		snBlock.EventCommitment = commitments.EventCommitment
+	}
+	return snBlock, nil
+}
+
+// AdaptTransactionReceipt adapts the receipt of the transaction at the given index of its block
+func AdaptTransactionReceipt(receipt *core.TransactionReceipt, index uint64) *starknet.TransactionReceipt {
+	if receipt == nil {
+		return nil
+	}
+
+	status := starknet.Succeeded
+	if receipt.Reverted {
+		status = starknet.Reverted
+	}
+	return &starknet.TransactionReceipt{
+		ActualFee:          receipt.Fee,
+		Events:             utils.Map(utils.NonNilSlice(receipt.Events), AdaptEvent),
+		ExecutionStatus:    status,
+		ExecutionResources: AdaptExecutionResources(receipt.ExecutionResources),
+		L1ToL2Message:      AdaptL1ToL2Message(receipt.L1ToL2Message),
+		L2ToL1Message:      utils.Map(utils.NonNilSlice(receipt.L2ToL1Message), AdaptL2ToL1Message),
+		TransactionHash:    receipt.TransactionHash,
+		TransactionIndex:   index,
+		RevertError:        receipt.RevertReason,
+	}
+}
+
+func AdaptEvent(event *core.Event) *starknet.Event {
+	if event == nil {
+		return nil
+	}
+
+	return &starknet.Event{
+		From: event.From,
+		Data: event.Data,
+		Keys: event.Keys,
+	}
+}
+
+func AdaptExecutionResources(resources *core.ExecutionResources) *starknet.ExecutionResources {
+	if resources == nil {
+		return nil
+	}
+
+	return &starknet.ExecutionResources{
+		Steps:                  resources.Steps,
+		BuiltinInstanceCounter: starknet.BuiltinInstanceCounter(resources.BuiltinInstanceCounter),
+		MemoryHoles:            resources.MemoryHoles,
+		DataAvailability:       (*starknet.DataAvailability)(resources.DataAvailability),
+	}
+}
+
+func AdaptL1ToL2Message(msg *core.L1ToL2Message) *starknet.L1ToL2Message {
+	if msg == nil {
+		return nil
+	}
+
+	return &starknet.L1ToL2Message{
+		From:     msg.From.String(),
+		Payload:  msg.Payload,
+		Selector: msg.Selector,
+		To:       msg.To,
+		Nonce:    msg.Nonce,
+	}
+}
+
+func AdaptL2ToL1Message(msg *core.L2ToL1Message) *starknet.L2ToL1Message {
+	if msg == nil {
+		return nil
+	}
+
+	return &starknet.L2ToL1Message{
+		From:    msg.From,
+		Payload: msg.Payload,
+		To:      msg.To.String(),
+	}
+}
+
+func AdaptTransaction(transaction core.Transaction) (*starknet.Transaction, error) {
+	switch t := transaction.(type) {
+	case *core.DeclareTransaction:
+		return AdaptDeclareTransaction(t), nil
+	case *core.DeployTransaction:
+		return AdaptDeployTransaction(t), nil
+	case *core.InvokeTransaction:
+		return AdaptInvokeTransaction(t), nil
+	case *core.DeployAccountTransaction:
+		return AdaptDeployAccountTransaction(t), nil
+	case *core.L1HandlerTransaction:
+		return AdaptL1HandlerTransaction(t), nil
+	default:
+		return nil, fmt.Errorf("unknown transaction type %T", transaction)
+	}
+}
+
+func AdaptDeclareTransaction(t *core.DeclareTransaction) *starknet.Transaction {
+	txn := &starknet.Transaction{
+		Hash:              t.TransactionHash,
+		Version:           t.Version.AsFelt(),
+		ClassHash:         t.ClassHash,
+		Type:              starknet.TxnDeclare,
+		SenderAddress:     t.SenderAddress,
+		MaxFee:            t.MaxFee,
+		Signature:         utils.Ptr(utils.NonNilSlice(t.TransactionSignature)),
+		Nonce:             t.Nonce,
+		CompiledClassHash: t.CompiledClassHash,
+	}
+	if t.Version != nil && t.Version.Is(3) {
+		adaptV3Fields(txn, t.ResourceBounds, t.Tip, t.PaymasterData, t.NonceDAMode, t.FeeDAMode)
+		txn.AccountDeploymentData = utils.Ptr(utils.NonNilSlice(t.AccountDeploymentData))
+	}
+	return txn
+}
+
+func AdaptDeployTransaction(t *core.DeployTransaction) *starknet.Transaction {
+	return &starknet.Transaction{
+		Hash:                t.TransactionHash,
+		Version:             t.Version.AsFelt(),
+		ContractAddress:     t.ContractAddress,
+		ContractAddressSalt: t.ContractAddressSalt,
+		ClassHash:           t.ClassHash,
+		ConstructorCallData: utils.Ptr(utils.NonNilSlice(t.ConstructorCallData)),
+		Type:                starknet.TxnDeploy,
+	}
+}
+
+func AdaptInvokeTransaction(t *core.InvokeTransaction) *starknet.Transaction {
+	txn := &starknet.Transaction{
+		Hash:               t.TransactionHash,
+		Version:            t.Version.AsFelt(),
+		ContractAddress:    t.ContractAddress,
+		Type:               starknet.TxnInvoke,
+		SenderAddress:      t.SenderAddress,
+		MaxFee:             t.MaxFee,
+		Signature:          utils.Ptr(utils.NonNilSlice(t.TransactionSignature)),
+		CallData:           utils.Ptr(utils.NonNilSlice(t.CallData)),
+		EntryPointSelector: t.EntryPointSelector,
+		Nonce:              t.Nonce,
+	}
+	if t.Version != nil && t.Version.Is(3) {
+		adaptV3Fields(txn, t.ResourceBounds, t.Tip, t.PaymasterData, t.NonceDAMode, t.FeeDAMode)
+		txn.AccountDeploymentData = utils.Ptr(utils.NonNilSlice(t.AccountDeploymentData))
+	}
+	return txn
+}
+
+func AdaptL1HandlerTransaction(t *core.L1HandlerTransaction) *starknet.Transaction {
+	return &starknet.Transaction{
+		Hash:               t.TransactionHash,
+		Version:            t.Version.AsFelt(),
+		ContractAddress:    t.ContractAddress,
+		Type:               starknet.TxnL1Handler,
+		CallData:           utils.Ptr(utils.NonNilSlice(t.CallData)),
+		EntryPointSelector: t.EntryPointSelector,
+		Nonce:              t.Nonce,
+	}
+}
+
+func AdaptDeployAccountTransaction(t *core.DeployAccountTransaction) *starknet.Transaction {
+	txn := AdaptDeployTransaction(&t.DeployTransaction)
+	txn.Type = starknet.TxnDeployAccount
+	txn.MaxFee = t.MaxFee
+	txn.Signature = utils.Ptr(utils.NonNilSlice(t.TransactionSignature))
+	txn.Nonce = t.Nonce
+	if t.Version != nil && t.Version.Is(3) {
+		adaptV3Fields(txn, t.ResourceBounds, t.Tip, t.PaymasterData, t.NonceDAMode, t.FeeDAMode)
+	}
+	return txn
+}
+
+func adaptV3Fields(txn *starknet.Transaction, bounds map[core.Resource]core.ResourceBounds, tip uint64, paymasterData []*felt.Felt,
+	nonceDAMode, feeDAMode core.DataAvailabilityMode,
+) {
+	snBounds := make(map[starknet.Resource]starknet.ResourceBounds, len(bounds))
+	for resource, b := range bounds {
+		snBounds[starknet.Resource(resource)] = starknet.ResourceBounds{
+			MaxAmount:       new(felt.Felt).SetUint64(b.MaxAmount),
+			MaxPricePerUnit: b.MaxPricePerUnit,
+		}
+	}
+	txn.ResourceBounds = &snBounds
+	txn.Tip = new(felt.Felt).SetUint64(tip)
+	txn.PaymasterData = utils.Ptr(utils.NonNilSlice(paymasterData))
+	txn.NonceDAMode = utils.Ptr(starknet.DataAvailabilityMode(nonceDAMode))
+	txn.FeeDAMode = utils.Ptr(starknet.DataAvailabilityMode(feeDAMode))
+}
+
+func AdaptStateUpdate(update *core.StateUpdate) *starknet.StateUpdate {
+	var snUpdate starknet.StateUpdate
+	snUpdate.BlockHash = update.BlockHash
+	snUpdate.NewRoot = update.NewRoot
+	snUpdate.OldRoot = update.OldRoot
+
+	diff := update.StateDiff
+	snDiff := &snUpdate.StateDiff
+	snDiff.StorageDiffs = make(map[string][]struct {
+		Key   *felt.Felt `json:"key"`
+		Value *felt.Felt `json:"value"`
+	}, len(diff.StorageDiffs))
+	for addr, storage := range diff.StorageDiffs {
+		entries := snDiff.StorageDiffs[addr.String()]
+		for key, value := range storage {
+			entries = append(entries, struct {
+				Key   *felt.Felt `json:"key"`
+				Value *felt.Felt `json:"value"`
+			}{Key: key.Clone(), Value: value})
+		}
+		snDiff.StorageDiffs[addr.String()] = entries
+	}
+
+	snDiff.Nonces = make(map[string]*felt.Felt, len(diff.Nonces))
+	for addr, nonce := range diff.Nonces {
+		snDiff.Nonces[addr.String()] = nonce
+	}
+
+	for addr, classHash := range diff.DeployedContracts {
+		snDiff.DeployedContracts = append(snDiff.DeployedContracts, struct {
+			Address   *felt.Felt `json:"address"`
+			ClassHash *felt.Felt `json:"class_hash"`
+		}{Address: addr.Clone(), ClassHash: classHash})
+	}
+
+	snDiff.OldDeclaredContracts = utils.NonNilSlice(diff.DeclaredV0Classes)
+	for classHash, compiledClassHash := range diff.DeclaredV1Classes {
+		snDiff.DeclaredClasses = append(snDiff.DeclaredClasses, struct {
+			ClassHash         *felt.Felt `json:"class_hash"`
+			CompiledClassHash *felt.Felt `json:"compiled_class_hash"`
+		}{ClassHash: classHash.Clone(), CompiledClassHash: compiledClassHash})
+	}
+
+	for addr, classHash := range diff.ReplacedClasses {
+		snDiff.ReplacedClasses = append(snDiff.ReplacedClasses, struct {
+			Address   *felt.Felt `json:"address"`
+			ClassHash *felt.Felt `json:"class_hash"`
+		}{Address: addr.Clone(), ClassHash: classHash})
+	}
+	return &snUpdate
+}
+
+// AdaptSignature returns the first sequencer signature of the block, stateDiffCommitment is the commitment of the state
+// diff of the block
+func AdaptSignature(header *core.Header, stateDiffCommitment *felt.Felt) *starknet.Signature {
+	var sig starknet.Signature
+	sig.BlockNumber = header.Number
+	sig.Signature = []*felt.Felt{}
+	if len(header.Signatures) > 0 {
+		sig.Signature = header.Signatures[0]
+	}
+	sig.SignatureInput.BlockHash = header.Hash
+	sig.SignatureInput.StateDiffCommitment = stateDiffCommitment
+	return &sig
+}
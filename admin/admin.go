@@ -0,0 +1,232 @@
+// Package admin serves the juno_admin namespace, which manages a running node. It is meant to be served on a listener
+// of its own that requires authentication, never alongside the public RPC.
+package admin
+
+import (
+	"context"
+	"slices"
+
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/p2p"
+	"github.com/NethermindEth/juno/utils"
+)
+
+// LogLevel is the logger whose level can be changed at runtime
+type LogLevel interface {
+	Level() utils.LogLevel
+	SetLevel(level utils.LogLevel) error
+}
+
+// Synchroniser is the sync process that can be paused
+type Synchroniser interface {
+	Pause()
+	Resume()
+	Paused() bool
+}
+
+// P2P manages the peers of the node
+type P2P interface {
+	Peers() []p2p.Peer
+	BannedPeers() []string
+	AddPeer(ctx context.Context, addr string) error
+	BanPeer(id string) error
+}
+
+type SyncStatus struct {
+	Paused bool `json:"paused"`
+}
+
+type Peers struct {
+	Connected []p2p.Peer `json:"connected"`
+	Banned    []string   `json:"banned"`
+}
+
+type Handler struct {
+	logLevel     LogLevel
+	database     db.DB
+	config       any
+	synchroniser Synchroniser
+	p2p          P2P
+	caches       map[string]func()
+	log          utils.SimpleLogger
+}
+
+// New returns the handler of the admin methods, config is the configuration the node was started with, with the
+// secrets already removed
+func New(logLevel LogLevel, database db.DB, config any, log utils.SimpleLogger) *Handler {
+	return &Handler{
+		logLevel: logLevel,
+		database: database,
+		config:   config,
+		caches:   make(map[string]func()),
+		log:      log,
+	}
+}
+
+// WithSynchroniser enables the methods that pause and resume the sync
+func (h *Handler) WithSynchroniser(synchroniser Synchroniser) *Handler {
+	h.synchroniser = synchroniser
+	return h
+}
+
+// WithP2P enables the methods that manage the peers
+func (h *Handler) WithP2P(p2pService P2P) *Handler {
+	h.p2p = p2pService
+	return h
+}
+
+// WithCache registers a cache that is emptied by juno_admin_flushCaches
+func (h *Handler) WithCache(name string, purge func()) *Handler {
+	h.caches[name] = purge
+	return h
+}
+
+// Methods returns the admin methods, the ones of the components the node doesn't run are left out
+func (h *Handler) Methods() []jsonrpc.Method {
+	methods := []jsonrpc.Method{
+		{
+			Name:    "juno_admin_logLevel",
+			Handler: h.LogLevel,
+		},
+		{
+			Name:    "juno_admin_setLogLevel",
+			Params:  []jsonrpc.Parameter{{Name: "level"}},
+			Handler: h.SetLogLevel,
+		},
+		{
+			Name:    "juno_admin_compactDatabase",
+			Handler: h.CompactDatabase,
+		},
+		{
+			Name:    "juno_admin_flushCaches",
+			Handler: h.FlushCaches,
+		},
+		{
+			Name:    "juno_admin_config",
+			Handler: h.Config,
+		},
+	}
+	if h.synchroniser != nil {
+		methods = append(methods, []jsonrpc.Method{
+			{
+				Name:    "juno_admin_syncStatus",
+				Handler: h.SyncStatus,
+			},
+			{
+				Name:    "juno_admin_pauseSync",
+				Handler: h.PauseSync,
+			},
+			{
+				Name:    "juno_admin_resumeSync",
+				Handler: h.ResumeSync,
+			},
+		}...)
+	}
+	if h.p2p != nil {
+		methods = append(methods, []jsonrpc.Method{
+			{
+				Name:    "juno_admin_peers",
+				Handler: h.Peers,
+			},
+			{
+				Name:    "juno_admin_addPeer",
+				Params:  []jsonrpc.Parameter{{Name: "addr"}},
+				Handler: h.AddPeer,
+			},
+			{
+				Name:    "juno_admin_banPeer",
+				Params:  []jsonrpc.Parameter{{Name: "id"}},
+				Handler: h.BanPeer,
+			},
+		}...)
+	}
+	return methods
+}
+
+// LogLevel returns the current log level
+func (h *Handler) LogLevel() (string, *jsonrpc.Error) {
+	return h.logLevel.Level().String(), nil
+}
+
+// SetLogLevel changes the log level until the node is restarted
+func (h *Handler) SetLogLevel(level string) (string, *jsonrpc.Error) {
+	var logLevel utils.LogLevel
+	if err := logLevel.Set(level); err != nil {
+		return "", jsonrpc.Err(jsonrpc.InvalidParams, err.Error())
+	}
+	if err := h.logLevel.SetLevel(logLevel); err != nil {
+		return "", jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	h.log.Infow("Changed log level", "level", logLevel)
+	return logLevel.String(), nil
+}
+
+// CompactDatabase compacts the whole database and returns once the compaction is done
+func (h *Handler) CompactDatabase() (bool, *jsonrpc.Error) {
+	h.log.Infow("Compacting the database")
+	if err := pebble.Compact(h.database); err != nil {
+		return false, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	h.log.Infow("Compacted the database")
+	return true, nil
+}
+
+// FlushCaches empties the in-memory caches and returns their names
+func (h *Handler) FlushCaches() ([]string, *jsonrpc.Error) {
+	names := make([]string, 0, len(h.caches))
+	for name, purge := range h.caches {
+		purge()
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	h.log.Infow("Flushed caches", "caches", names)
+	return names, nil
+}
+
+// Config returns the configuration the node was started with
+func (h *Handler) Config() (any, *jsonrpc.Error) {
+	return h.config, nil
+}
+
+// SyncStatus returns whether the sync is paused
+func (h *Handler) SyncStatus() (*SyncStatus, *jsonrpc.Error) {
+	return &SyncStatus{Paused: h.synchroniser.Paused()}, nil
+}
+
+// PauseSync stops storing new blocks until the sync is resumed or the node is restarted
+func (h *Handler) PauseSync() (*SyncStatus, *jsonrpc.Error) {
+	h.synchroniser.Pause()
+	return h.SyncStatus()
+}
+
+// ResumeSync restarts the sync after PauseSync
+func (h *Handler) ResumeSync() (*SyncStatus, *jsonrpc.Error) {
+	h.synchroniser.Resume()
+	return h.SyncStatus()
+}
+
+// Peers returns the connected and the banned peers
+func (h *Handler) Peers() (*Peers, *jsonrpc.Error) {
+	return &Peers{
+		Connected: h.p2p.Peers(),
+		Banned:    h.p2p.BannedPeers(),
+	}, nil
+}
+
+// AddPeer connects to the peer at addr, a multiaddr that ends with the ID of the peer
+func (h *Handler) AddPeer(ctx context.Context, addr string) (bool, *jsonrpc.Error) {
+	if err := h.p2p.AddPeer(ctx, addr); err != nil {
+		return false, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	return true, nil
+}
+
+// BanPeer disconnects from the peer and refuses its connections until the node is restarted
+func (h *Handler) BanPeer(id string) (bool, *jsonrpc.Error) {
+	if err := h.p2p.BanPeer(id); err != nil {
+		return false, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	return true, nil
+}
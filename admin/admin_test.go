@@ -0,0 +1,123 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NethermindEth/juno/admin"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/p2p"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSynchroniser struct {
+	paused bool
+}
+
+func (s *fakeSynchroniser) Pause()       { s.paused = true }
+func (s *fakeSynchroniser) Resume()      { s.paused = false }
+func (s *fakeSynchroniser) Paused() bool { return s.paused }
+
+type fakeP2P struct {
+	connected []p2p.Peer
+	banned    []string
+}
+
+func (p *fakeP2P) Peers() []p2p.Peer     { return p.connected }
+func (p *fakeP2P) BannedPeers() []string { return p.banned }
+
+func (p *fakeP2P) AddPeer(_ context.Context, addr string) error {
+	if addr == "" {
+		return errors.New("empty address")
+	}
+	p.connected = append(p.connected, p2p.Peer{ID: addr})
+	return nil
+}
+
+func (p *fakeP2P) BanPeer(id string) error {
+	p.connected = nil
+	p.banned = append(p.banned, id)
+	return nil
+}
+
+func newServer(t *testing.T, handler *admin.Handler) func(req string) string {
+	t.Helper()
+
+	server := jsonrpc.NewServer(1, utils.NewNopZapLogger())
+	require.NoError(t, server.RegisterMethods(handler.Methods()...))
+	return func(req string) string {
+		t.Helper()
+		res, err := server.HandleReader(context.Background(), strings.NewReader(req))
+		require.NoError(t, err)
+		return string(res)
+	}
+}
+
+func TestAdmin(t *testing.T) {
+	log, err := utils.NewZapLogger(utils.INFO, false)
+	require.NoError(t, err)
+
+	purged := 0
+	config := map[string]any{"http-port": 6060}
+	handler := admin.New(log, pebble.NewMemTest(t), config, utils.NewNopZapLogger()).
+		WithSynchroniser(&fakeSynchroniser{}).
+		WithP2P(&fakeP2P{}).
+		WithCache("traces", func() { purged++ }).
+		WithCache("responses", func() { purged++ })
+	call := newServer(t, handler)
+
+	t.Run("log level", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"info","id":1}`, call(`{"jsonrpc":"2.0","method":"juno_admin_logLevel","id":1}`))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"debug","id":1}`,
+			call(`{"jsonrpc":"2.0","method":"juno_admin_setLogLevel","params":["DEBUG"],"id":1}`))
+		assert.Equal(t, utils.DEBUG, log.Level())
+		assert.Contains(t, call(`{"jsonrpc":"2.0","method":"juno_admin_setLogLevel","params":["verbose"],"id":1}`),
+			`"code":-32602`)
+		assert.Equal(t, utils.DEBUG, log.Level())
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":{"paused":true},"id":1}`, call(`{"jsonrpc":"2.0","method":"juno_admin_pauseSync","id":1}`))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":{"paused":true},"id":1}`, call(`{"jsonrpc":"2.0","method":"juno_admin_syncStatus","id":1}`))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":{"paused":false},"id":1}`, call(`{"jsonrpc":"2.0","method":"juno_admin_resumeSync","id":1}`))
+	})
+
+	t.Run("compaction", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":true,"id":1}`, call(`{"jsonrpc":"2.0","method":"juno_admin_compactDatabase","id":1}`))
+	})
+
+	t.Run("peers", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":true,"id":1}`,
+			call(`{"jsonrpc":"2.0","method":"juno_admin_addPeer","params":{"addr":"peerA"},"id":1}`))
+		assert.Contains(t, call(`{"jsonrpc":"2.0","method":"juno_admin_addPeer","params":[""],"id":1}`), "empty address")
+		assert.Equal(t, `{"jsonrpc":"2.0","result":{"connected":[{"id":"peerA","addrs":null}],"banned":null},"id":1}`,
+			call(`{"jsonrpc":"2.0","method":"juno_admin_peers","id":1}`))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":true,"id":1}`,
+			call(`{"jsonrpc":"2.0","method":"juno_admin_banPeer","params":["peerA"],"id":1}`))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":{"connected":null,"banned":["peerA"]},"id":1}`,
+			call(`{"jsonrpc":"2.0","method":"juno_admin_peers","id":1}`))
+	})
+
+	t.Run("caches", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":["responses","traces"],"id":1}`,
+			call(`{"jsonrpc":"2.0","method":"juno_admin_flushCaches","id":1}`))
+		assert.Equal(t, 2, purged)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":{"http-port":6060},"id":1}`, call(`{"jsonrpc":"2.0","method":"juno_admin_config","id":1}`))
+	})
+}
+
+func TestDisabledComponents(t *testing.T) {
+	call := newServer(t, admin.New(utils.NewNopZapLogger(), pebble.NewMemTest(t), nil, utils.NewNopZapLogger()))
+
+	for _, method := range []string{"juno_admin_pauseSync", "juno_admin_peers", "juno_admin_banPeer"} {
+		assert.Contains(t, call(`{"jsonrpc":"2.0","method":"`+method+`","id":1}`), `"code":-32601`, method)
+	}
+}
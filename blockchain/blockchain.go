@@ -32,6 +32,7 @@ type Reader interface {
 	TransactionByHash(hash *felt.Felt) (transaction core.Transaction, err error)
 	TransactionByBlockNumberAndIndex(blockNumber, index uint64) (transaction core.Transaction, err error)
 	Receipt(hash *felt.Felt) (receipt *core.TransactionReceipt, blockHash *felt.Felt, blockNumber uint64, err error)
+	ReceiptsByBlockNumber(number uint64) (receipts []*core.TransactionReceipt, err error)
 	StateUpdateByNumber(number uint64) (update *core.StateUpdate, err error)
 	StateUpdateByHash(hash *felt.Felt) (update *core.StateUpdate, err error)
 
@@ -43,6 +44,14 @@ type Reader interface {
 	BlockCommitmentsByNumber(blockNumber uint64) (*core.BlockCommitments, error)
 
 	EventFilter(from *felt.Felt, keys [][]felt.Felt) (*EventFilter, error)
+	Transfers(address, token *felt.Felt, start *TransferID, limit uint64) ([]*Transfer, *TransferID, error)
+	IndexedTransferBlocks() ([]BlockRange, error)
+	ContractsByClassHash(classHash, start *felt.Felt, limit uint64) ([]*core.ClassInstance, *felt.Felt, error)
+	TransactionsBySender(sender *felt.Felt, filter *SenderTransactionsFilter, start *SenderTransactionID,
+		limit uint64) ([]*SenderTransaction, *SenderTransactionID, error)
+	ContractStorageChanges(addr, key *felt.Felt, from, to, limit uint64) ([]*core.ValueChange, *uint64, error)
+	ContractNonceChanges(addr *felt.Felt, from, to, limit uint64) ([]*core.ValueChange, *uint64, error)
+	ContractClassHashChanges(addr *felt.Felt, from, to, limit uint64) ([]*core.ValueChange, *uint64, error)
 
 	Pending() (Pending, error)
 
@@ -74,7 +83,9 @@ type Blockchain struct {
 	network  *utils.Network
 	database db.DB
 
-	listener EventListener
+	listener      EventListener
+	revertHooks   []func(blockNumber uint64)
+	transferIndex bool
 
 	cachedPending atomic.Pointer[Pending]
 }
@@ -93,6 +104,12 @@ func (b *Blockchain) WithListener(listener EventListener) *Blockchain {
 	return b
 }
 
+// WithRevertHook registers a function that is called with the number of every block removed by RevertHead
+func (b *Blockchain) WithRevertHook(hook func(blockNumber uint64)) *Blockchain {
+	b.revertHooks = append(b.revertHooks, hook)
+	return b
+}
+
 func (b *Blockchain) Network() *utils.Network {
 	return b.network
 }
@@ -115,12 +132,13 @@ func (b *Blockchain) Height() (uint64, error) {
 	var height uint64
 	return height, b.database.View(func(txn db.Transaction) error {
 		var err error
-		height, err = chainHeight(txn)
+		height, err = ChainHeight(txn)
 		return err
 	})
 }
 
-func chainHeight(txn db.Transaction) (uint64, error) {
+// ChainHeight retrieves the number of the head block from database
+func ChainHeight(txn db.Transaction) (uint64, error) {
 	var height uint64
 	return height, txn.Get(db.ChainHeight.Key(), func(val []byte) error {
 		height = binary.BigEndian.Uint64(val)
@@ -150,7 +168,7 @@ func (b *Blockchain) HeadsHeader() (*core.Header, error) {
 }
 
 func head(txn db.Transaction) (*core.Block, error) {
-	height, err := chainHeight(txn)
+	height, err := ChainHeight(txn)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +176,7 @@ func head(txn db.Transaction) (*core.Block, error) {
 }
 
 func headsHeader(txn db.Transaction) (*core.Header, error) {
-	height, err := chainHeight(txn)
+	height, err := ChainHeight(txn)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +229,7 @@ func (b *Blockchain) StateUpdateByNumber(number uint64) (*core.StateUpdate, erro
 	var update *core.StateUpdate
 	return update, b.database.View(func(txn db.Transaction) error {
 		var err error
-		update, err = stateUpdateByNumber(txn, number)
+		update, err = StateUpdateByNumber(txn, number)
 		return err
 	})
 }
@@ -226,6 +244,66 @@ func (b *Blockchain) StateUpdateByHash(hash *felt.Felt) (*core.StateUpdate, erro
 	})
 }
 
+// ContractsByClassHash returns up to limit contracts that instantiate a class or did so before being upgraded, ordered
+// by address and starting at the start address. The address of the next contract is returned as well.
+func (b *Blockchain) ContractsByClassHash(classHash, start *felt.Felt, limit uint64) ([]*core.ClassInstance, *felt.Felt,
+	error,
+) {
+	b.listener.OnRead("ContractsByClassHash")
+	var (
+		instances []*core.ClassInstance
+		next      *felt.Felt
+	)
+	return instances, next, b.database.View(func(txn db.Transaction) error {
+		var err error
+		instances, next, err = core.ContractsByClassHash(txn, classHash, start, limit)
+		return err
+	})
+}
+
+// ContractStorageChanges returns up to limit changes to a storage slot between the blocks from and to, both inclusive,
+// along with the block of the next change if there are more
+func (b *Blockchain) ContractStorageChanges(addr, key *felt.Felt, from, to, limit uint64) ([]*core.ValueChange, *uint64,
+	error,
+) {
+	b.listener.OnRead("ContractStorageChanges")
+	return b.stateChanges(func(state *core.State) ([]*core.ValueChange, *uint64, error) {
+		return state.ContractStorageChanges(addr, key, from, to, limit)
+	})
+}
+
+// ContractNonceChanges returns up to limit changes to the nonce of a contract between the blocks from and to
+func (b *Blockchain) ContractNonceChanges(addr *felt.Felt, from, to, limit uint64) ([]*core.ValueChange, *uint64, error) {
+	b.listener.OnRead("ContractNonceChanges")
+	return b.stateChanges(func(state *core.State) ([]*core.ValueChange, *uint64, error) {
+		return state.ContractNonceChanges(addr, from, to, limit)
+	})
+}
+
+// ContractClassHashChanges returns up to limit class hashes that a contract instantiated between the blocks from and to
+func (b *Blockchain) ContractClassHashChanges(addr *felt.Felt, from, to, limit uint64) ([]*core.ValueChange, *uint64,
+	error,
+) {
+	b.listener.OnRead("ContractClassHashChanges")
+	return b.stateChanges(func(state *core.State) ([]*core.ValueChange, *uint64, error) {
+		return state.ContractClassHashChanges(addr, from, to, limit)
+	})
+}
+
+func (b *Blockchain) stateChanges(changesFn func(*core.State) ([]*core.ValueChange, *uint64, error)) ([]*core.ValueChange,
+	*uint64, error,
+) {
+	var (
+		changes []*core.ValueChange
+		next    *uint64
+	)
+	return changes, next, b.database.View(func(txn db.Transaction) error {
+		var err error
+		changes, next, err = changesFn(core.NewState(txn))
+		return err
+	})
+}
+
 // TransactionByBlockNumberAndIndex gets the transaction for a given block number and index.
 func (b *Blockchain) TransactionByBlockNumberAndIndex(blockNumber, index uint64) (core.Transaction, error) {
 	b.listener.OnRead("TransactionByBlockNumberAndIndex")
@@ -301,6 +379,17 @@ func (b *Blockchain) Receipt(hash *felt.Felt) (*core.TransactionReceipt, *felt.F
 	})
 }
 
+// ReceiptsByBlockNumber gets the transaction receipts of a canonical block without loading its transactions.
+func (b *Blockchain) ReceiptsByBlockNumber(number uint64) ([]*core.TransactionReceipt, error) {
+	b.listener.OnRead("ReceiptsByBlockNumber")
+	var receipts []*core.TransactionReceipt
+	return receipts, b.database.View(func(txn db.Transaction) error {
+		var err error
+		receipts, err = receiptsByBlockNumber(txn, number)
+		return err
+	})
+}
+
 func (b *Blockchain) L1Head() (*core.L1Head, error) {
 	b.listener.OnRead("L1Head")
 	var update *core.L1Head
@@ -353,6 +442,11 @@ func (b *Blockchain) Store(block *core.Block, blockCommitments *core.BlockCommit
 				return err
 			}
 		}
+		if b.transferIndex {
+			if err := storeTransfers(txn, block); err != nil {
+				return err
+			}
+		}
 
 		if err := storeStateUpdate(txn, block.Number, stateUpdate); err != nil {
 			return err
@@ -599,7 +693,8 @@ func storeStateUpdate(txn db.Transaction, blockNumber uint64, update *core.State
 	return txn.Set(db.StateUpdatesByBlockNumber.Key(numBytes), updateBytes)
 }
 
-func stateUpdateByNumber(txn db.Transaction, blockNumber uint64) (*core.StateUpdate, error) {
+// StateUpdateByNumber retrieves the state update of a block from database by the block number
+func StateUpdateByNumber(txn db.Transaction, blockNumber uint64) (*core.StateUpdate, error) {
 	numBytes := core.MarshalBlockNumber(blockNumber)
 
 	var update *core.StateUpdate
@@ -616,7 +711,7 @@ func stateUpdateByHash(txn db.Transaction, hash *felt.Felt) (*core.StateUpdate,
 	var update *core.StateUpdate
 	return update, txn.Get(db.BlockHeaderNumbersByHash.Key(hash.Marshal()), func(val []byte) error {
 		var err error
-		update, err = stateUpdateByNumber(txn, binary.BigEndian.Uint64(val))
+		update, err = StateUpdateByNumber(txn, binary.BigEndian.Uint64(val))
 		return err
 	})
 }
@@ -657,11 +752,12 @@ func (t *txAndReceiptDBKey) UnmarshalBinary(data []byte) error {
 }
 
 // storeTransactionAndReceipt stores the given transaction receipt in the database.
-// The db storage for transaction and receipts is maintained by three buckets as follows:
+// The db storage for transaction and receipts is maintained by four buckets as follows:
 //
 // [db.TransactionBlockNumbersAndIndicesByHash](TransactionHash) -> (BlockNumber, Index)
 // [db.TransactionsByBlockNumberAndIndex](BlockNumber, Index) -> Transaction
 // [db.ReceiptsByBlockNumberAndIndex](BlockNumber, Index) -> Receipt
+// [db.TransactionsBySender](SenderAddress, Nonce, BlockNumber, Index) -> ()
 //
 // Note: we are using the same transaction hash bucket which keeps track of block number and
 // index for both transactions and receipts since transaction and its receipt share the same hash.
@@ -683,6 +779,9 @@ func storeTransactionAndReceipt(txn db.Transaction, number, i uint64, t core.Tra
 	if err = txn.Set(db.TransactionsByBlockNumberAndIndex.Key(bnIndexBytes), txnBytes); err != nil {
 		return err
 	}
+	if err = IndexTransactionSender(txn, t, number, i); err != nil {
+		return err
+	}
 
 	rBytes, err := encoder.Marshal(r)
 	if err != nil {
@@ -760,7 +859,7 @@ func (b *Blockchain) HeadState() (core.StateReader, StateCloser, error) {
 		return nil, nil, err
 	}
 
-	_, err = chainHeight(txn)
+	_, err = ChainHeight(txn)
 	if err != nil {
 		return nil, nil, utils.RunAndWrapOnError(txn.Discard, err)
 	}
@@ -814,7 +913,7 @@ func (b *Blockchain) EventFilter(from *felt.Felt, keys [][]felt.Felt) (*EventFil
 		return nil, err
 	}
 
-	latest, err := chainHeight(txn)
+	latest, err := ChainHeight(txn)
 	if err != nil {
 		return nil, err
 	}
@@ -824,17 +923,47 @@ func (b *Blockchain) EventFilter(from *felt.Felt, keys [][]felt.Felt) (*EventFil
 
 // RevertHead reverts the head block
 func (b *Blockchain) RevertHead() error {
-	return b.database.Update(b.revertHead)
+	return b.RevertHeads(1)
+}
+
+// RevertHeads reverts the count newest blocks in a single transaction, either all of them are reverted or none is
+func (b *Blockchain) RevertHeads(count uint64) error {
+	var height uint64
+	err := b.database.Update(func(txn db.Transaction) error {
+		var err error
+		if height, err = ChainHeight(txn); err != nil {
+			return err
+		}
+		if count > height+1 {
+			return fmt.Errorf("can't revert %d blocks, the chain has %d", count, height+1)
+		}
+		for range count {
+			if err = b.revertHead(txn); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range count {
+		for _, hook := range b.revertHooks {
+			hook(height - i)
+		}
+	}
+	return nil
 }
 
 func (b *Blockchain) revertHead(txn db.Transaction) error {
-	blockNumber, err := chainHeight(txn)
+	blockNumber, err := ChainHeight(txn)
 	if err != nil {
 		return err
 	}
 	numBytes := core.MarshalBlockNumber(blockNumber)
 
-	stateUpdate, err := stateUpdateByNumber(txn, blockNumber)
+	stateUpdate, err := StateUpdateByNumber(txn, blockNumber)
 	if err != nil {
 		return err
 	}
@@ -867,6 +996,14 @@ func (b *Blockchain) revertHead(txn db.Transaction) error {
 		return err
 	}
 
+	if err = deleteBlockTraces(txn, blockNumber); err != nil {
+		return err
+	}
+
+	if err = removeTransfers(txn, blockNumber); err != nil {
+		return err
+	}
+
 	// remove state update
 	if err = txn.Delete(db.StateUpdatesByBlockNumber.Key(numBytes)); err != nil {
 		return err
@@ -916,6 +1053,11 @@ func removeTxsAndReceipts(txn db.Transaction, blockNumber, numTxs uint64) error
 		if err = txn.Delete(db.TransactionBlockNumbersAndIndicesByHash.Key(reorgedTxn.Hash().Marshal())); err != nil {
 			return err
 		}
+		if senderKey := transactionsBySenderKey(reorgedTxn, &blockIDAndIndex); senderKey != nil {
+			if err = txn.Delete(senderKey); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -539,7 +539,10 @@ func TestEvents(t *testing.T) {
 
 func TestRevert(t *testing.T) {
 	testdb := pebble.NewMemTest(t)
-	chain := blockchain.New(testdb, &utils.Mainnet)
+	var reverted []uint64
+	chain := blockchain.New(testdb, &utils.Mainnet).WithRevertHook(func(blockNumber uint64) {
+		reverted = append(reverted, blockNumber)
+	})
 
 	client := feeder.NewTestClient(t, &utils.Mainnet)
 	gw := adaptfeeder.New(client)
@@ -607,6 +610,56 @@ func TestRevert(t *testing.T) {
 	t.Run("cannot revert on empty chain", func(t *testing.T) {
 		require.Error(t, chain.RevertHead())
 	})
+
+	t.Run("revert hooks are called with the reverted blocks", func(t *testing.T) {
+		assert.Equal(t, []uint64{2, 1, 0}, reverted)
+	})
+}
+
+func TestRevertHeads(t *testing.T) {
+	testdb := pebble.NewMemTest(t)
+	var reverted []uint64
+	chain := blockchain.New(testdb, &utils.Mainnet).WithRevertHook(func(blockNumber uint64) {
+		reverted = append(reverted, blockNumber)
+	})
+
+	gw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Mainnet))
+	for i := uint64(0); i < 3; i++ {
+		su, b, err := gw.StateUpdateWithBlock(context.Background(), i)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(b, &emptyCommitments, su, nil))
+	}
+	block0, err := chain.BlockByNumber(0)
+	require.NoError(t, err)
+
+	t.Run("reverting more blocks than the chain has reverts nothing", func(t *testing.T) {
+		require.Error(t, chain.RevertHeads(4))
+		height, err := chain.Height()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), height)
+		assert.Empty(t, reverted)
+	})
+
+	t.Run("revert to the first block", func(t *testing.T) {
+		require.NoError(t, chain.RevertHeads(2))
+		head, err := chain.Head()
+		require.NoError(t, err)
+		assert.Equal(t, block0, head)
+		assert.Equal(t, []uint64{2, 1}, reverted)
+
+		_, err = chain.BlockByNumber(1)
+		require.ErrorIs(t, err, db.ErrKeyNotFound)
+		pending, err := chain.Pending()
+		require.NoError(t, err)
+		assert.Equal(t, block0.Hash, pending.Block.ParentHash)
+	})
+
+	t.Run("revert the whole chain", func(t *testing.T) {
+		require.NoError(t, chain.RevertHeads(1))
+		_, err := chain.Height()
+		require.ErrorIs(t, err, db.ErrKeyNotFound)
+		assert.Equal(t, []uint64{2, 1, 0}, reverted)
+	})
 }
 
 func TestL1Update(t *testing.T) {
@@ -0,0 +1,194 @@
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/utils"
+)
+
+// SenderTransaction is a transaction sent by an account, or the deployment of the account itself
+type SenderTransaction struct {
+	Nonce           *felt.Felt
+	BlockNumber     uint64
+	Index           uint64
+	TransactionHash *felt.Felt
+	Reverted        bool
+}
+
+// SenderTransactionsFilter bounds the nonces and block numbers of the transactions returned by TransactionsBySender.
+// Bounds are inclusive and nil bounds are unbounded.
+//
+// The index is ordered by nonce, so the block bounds are checked against every transaction from the start of the scan.
+// ScanLimit bounds the number of transactions skipped that way, once it is reached the scan stops and the id of the
+// next transaction is returned. Zero doesn't bound the scan.
+type SenderTransactionsFilter struct {
+	FromNonce *felt.Felt
+	ToNonce   *felt.Felt
+	FromBlock *uint64
+	ToBlock   *uint64
+	ScanLimit uint64
+}
+
+// SenderTransactionID identifies a transaction of a sender by its position in the index
+type SenderTransactionID struct {
+	Nonce       felt.Felt
+	BlockNumber uint64
+	Index       uint64
+}
+
+func (id *SenderTransactionID) MarshalBinary() []byte {
+	return append(id.Nonce.Marshal(), (&txAndReceiptDBKey{id.BlockNumber, id.Index}).MarshalBinary()...)
+}
+
+func (id *SenderTransactionID) UnmarshalBinary(data []byte) error {
+	if len(data) != felt.Bytes+16 {
+		return fmt.Errorf("invalid sender transaction id length %d", len(data))
+	}
+	id.Nonce.SetBytes(data[:felt.Bytes])
+	var key txAndReceiptDBKey
+	if err := key.UnmarshalBinary(data[felt.Bytes:]); err != nil {
+		return err
+	}
+	id.BlockNumber, id.Index = key.Number, key.Index
+	return nil
+}
+
+func (id *SenderTransactionID) String() string {
+	return fmt.Sprintf("%s-%d-%d", id.Nonce.String(), id.BlockNumber, id.Index)
+}
+
+func (id *SenderTransactionID) FromString(str string) error {
+	nonce, position, found := strings.Cut(str, "-")
+	if !found {
+		return fmt.Errorf("invalid sender transaction id %q", str)
+	}
+	if _, err := id.Nonce.SetString(nonce); err != nil {
+		return err
+	}
+	_, err := fmt.Sscanf(position, "%d-%d", &id.BlockNumber, &id.Index)
+	return err
+}
+
+// transactionSender returns the account that sent a transaction and its nonce. Deploy account transactions are
+// attributed to the deployed account. Transactions that predate nonces have a zero nonce.
+func transactionSender(t core.Transaction) (*felt.Felt, *felt.Felt) {
+	var sender, nonce *felt.Felt
+	switch tx := t.(type) {
+	case *core.InvokeTransaction:
+		sender, nonce = tx.SenderAddress, tx.Nonce
+		if sender == nil {
+			// version 0 transactions don't have a sender, the invoked contract is the account
+			sender = tx.ContractAddress
+		}
+	case *core.DeclareTransaction:
+		sender, nonce = tx.SenderAddress, tx.Nonce
+	case *core.DeployAccountTransaction:
+		sender, nonce = tx.ContractAddress, tx.Nonce
+	default:
+		return nil, nil
+	}
+
+	if nonce == nil {
+		nonce = &felt.Zero
+	}
+	return sender, nonce
+}
+
+// IndexTransactionSender adds a stored transaction to the sender index if it has a sender
+func IndexTransactionSender(txn db.Transaction, t core.Transaction, blockNumber, index uint64) error {
+	if senderKey := transactionsBySenderKey(t, &txAndReceiptDBKey{blockNumber, index}); senderKey != nil {
+		return txn.Set(senderKey, nil)
+	}
+	return nil
+}
+
+// transactionsBySenderKey returns the key of a transaction in the sender index, it is nil for transactions without a
+// sender
+func transactionsBySenderKey(t core.Transaction, bnIndex *txAndReceiptDBKey) []byte {
+	sender, nonce := transactionSender(t)
+	if sender == nil {
+		return nil
+	}
+	id := SenderTransactionID{Nonce: *nonce, BlockNumber: bnIndex.Number, Index: bnIndex.Index}
+	return db.TransactionsBySender.Key(sender.Marshal(), id.MarshalBinary())
+}
+
+// TransactionsBySender returns up to limit transactions sent by sender ordered by nonce, starting at the transaction with
+// the given id. The id of the next transaction is returned as well, it is nil if there are no more transactions.
+func (b *Blockchain) TransactionsBySender(sender *felt.Felt, filter *SenderTransactionsFilter, start *SenderTransactionID,
+	limit uint64,
+) ([]*SenderTransaction, *SenderTransactionID, error) {
+	b.listener.OnRead("TransactionsBySender")
+	if filter == nil {
+		filter = new(SenderTransactionsFilter)
+	}
+
+	var (
+		transactions []*SenderTransaction
+		next         *SenderTransactionID
+		skipped      uint64
+	)
+	return transactions, next, b.database.View(func(txn db.Transaction) error {
+		it, err := txn.NewIterator()
+		if err != nil {
+			return err
+		}
+
+		prefix := db.TransactionsBySender.Key(sender.Marshal())
+		seek := prefix
+		if start != nil {
+			seek = db.TransactionsBySender.Key(sender.Marshal(), start.MarshalBinary())
+		} else if filter.FromNonce != nil {
+			seek = db.TransactionsBySender.Key(sender.Marshal(), filter.FromNonce.Marshal())
+		}
+
+		for it.Seek(seek); it.Valid(); it.Next() {
+			key := it.Key()
+			if !bytes.HasPrefix(key, prefix) {
+				break
+			}
+
+			var id SenderTransactionID
+			if err = id.UnmarshalBinary(key[len(prefix):]); err != nil {
+				return utils.RunAndWrapOnError(it.Close, err)
+			}
+			if filter.ToNonce != nil && id.Nonce.Cmp(filter.ToNonce) > 0 {
+				break
+			}
+			if filter.FromNonce != nil && id.Nonce.Cmp(filter.FromNonce) < 0 {
+				continue
+			}
+			if (filter.FromBlock != nil && id.BlockNumber < *filter.FromBlock) ||
+				(filter.ToBlock != nil && id.BlockNumber > *filter.ToBlock) {
+				if filter.ScanLimit != 0 && skipped == filter.ScanLimit {
+					next = &id
+					break
+				}
+				skipped++
+				continue
+			}
+			if uint64(len(transactions)) == limit {
+				next = &id
+				break
+			}
+
+			var receipt *core.TransactionReceipt
+			if receipt, err = receiptByBlockNumberAndIndex(txn, &txAndReceiptDBKey{id.BlockNumber, id.Index}); err != nil {
+				return utils.RunAndWrapOnError(it.Close, err)
+			}
+			transactions = append(transactions, &SenderTransaction{
+				Nonce:           new(felt.Felt).Set(&id.Nonce),
+				BlockNumber:     id.BlockNumber,
+				Index:           id.Index,
+				TransactionHash: receipt.TransactionHash,
+				Reverted:        receipt.Reverted,
+			})
+		}
+		return it.Close()
+	})
+}
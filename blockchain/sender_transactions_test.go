@@ -0,0 +1,128 @@
+package blockchain_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionsBySender(t *testing.T) {
+	f := func(v uint64) *felt.Felt { return new(felt.Felt).SetUint64(v) }
+	alice, bob := f(0xa), f(0xb)
+
+	makeBlock := func(number uint64, parent *felt.Felt, txs ...core.Transaction) *core.Block {
+		block := &core.Block{Header: &core.Header{
+			Number:           number,
+			Hash:             f(1000 + number),
+			ParentHash:       parent,
+			GlobalStateRoot:  &felt.Zero,
+			TransactionCount: uint64(len(txs)),
+		}, Transactions: txs}
+		for i, tx := range txs {
+			block.Receipts = append(block.Receipts, &core.TransactionReceipt{TransactionHash: tx.Hash(), Reverted: i == 1})
+		}
+		return block
+	}
+	emptyUpdate := &core.StateUpdate{OldRoot: &felt.Zero, NewRoot: &felt.Zero, StateDiff: &core.StateDiff{}}
+
+	block0 := makeBlock(0, &felt.Zero,
+		&core.DeployAccountTransaction{
+			DeployTransaction: core.DeployTransaction{TransactionHash: f(1), ContractAddress: alice},
+			Nonce:             &felt.Zero,
+		},
+		&core.InvokeTransaction{TransactionHash: f(2), SenderAddress: alice, Nonce: f(1)},
+		// version 0 invoke without a nonce
+		&core.InvokeTransaction{TransactionHash: f(3), ContractAddress: bob},
+		&core.L1HandlerTransaction{TransactionHash: f(4), ContractAddress: alice, Nonce: f(2)},
+	)
+	block1 := makeBlock(1, block0.Hash,
+		&core.DeclareTransaction{TransactionHash: f(5), SenderAddress: alice, Nonce: f(2)},
+		&core.InvokeTransaction{TransactionHash: f(6), SenderAddress: alice, Nonce: f(3)},
+	)
+
+	chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+	for _, block := range []*core.Block{block0, block1} {
+		require.NoError(t, chain.Store(block, &emptyCommitments, emptyUpdate, nil))
+	}
+
+	hashes := func(txs []*blockchain.SenderTransaction) []*felt.Felt {
+		var res []*felt.Felt
+		for _, tx := range txs {
+			res = append(res, tx.TransactionHash)
+		}
+		return res
+	}
+
+	t.Run("transactions of an account", func(t *testing.T) {
+		txs, next, err := chain.TransactionsBySender(alice, nil, nil, 10)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, []*felt.Felt{f(1), f(2), f(5), f(6)}, hashes(txs))
+		assert.Equal(t, &blockchain.SenderTransaction{
+			Nonce:           f(1),
+			BlockNumber:     0,
+			Index:           1,
+			TransactionHash: f(2),
+			Reverted:        true,
+		}, txs[1])
+
+		txs, _, err = chain.TransactionsBySender(bob, nil, nil, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []*felt.Felt{f(3)}, hashes(txs))
+		assert.Equal(t, &felt.Zero, txs[0].Nonce)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		txs, _, err := chain.TransactionsBySender(alice, &blockchain.SenderTransactionsFilter{FromNonce: f(1), ToNonce: f(2)}, nil, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []*felt.Felt{f(2), f(5)}, hashes(txs))
+
+		fromBlock, toBlock := uint64(1), uint64(1)
+		txs, _, err = chain.TransactionsBySender(alice, &blockchain.SenderTransactionsFilter{FromBlock: &fromBlock, ToBlock: &toBlock}, nil, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []*felt.Felt{f(5), f(6)}, hashes(txs))
+	})
+
+	t.Run("scan limit", func(t *testing.T) {
+		fromBlock := uint64(1)
+		filter := &blockchain.SenderTransactionsFilter{FromBlock: &fromBlock, ScanLimit: 1}
+		txs, next, err := chain.TransactionsBySender(alice, filter, nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+		require.NotNil(t, next)
+		assert.Equal(t, "0x1-0-1", next.String())
+
+		txs, next, err = chain.TransactionsBySender(alice, filter, next, 10)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, []*felt.Felt{f(5), f(6)}, hashes(txs))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		txs, next, err := chain.TransactionsBySender(alice, nil, nil, 3)
+		require.NoError(t, err)
+		require.Len(t, txs, 3)
+		require.NotNil(t, next)
+		assert.Equal(t, "0x3-1-1", next.String())
+
+		var start blockchain.SenderTransactionID
+		require.NoError(t, start.FromString(next.String()))
+		txs, next, err = chain.TransactionsBySender(alice, nil, &start, 3)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, []*felt.Felt{f(6)}, hashes(txs))
+	})
+
+	t.Run("revert removes the transactions", func(t *testing.T) {
+		require.NoError(t, chain.RevertHead())
+		txs, _, err := chain.TransactionsBySender(alice, nil, nil, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []*felt.Felt{f(1), f(2)}, hashes(txs))
+	})
+}
@@ -0,0 +1,267 @@
+package blockchain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"container/list"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/utils"
+)
+
+var ErrTracesNotFound = errors.New("traces not found")
+
+// TraceStore persists the execution traces of canonical blocks so that they don't have to be re-executed
+// every time they are requested. Traces are opaque to the store and are kept compressed as follows:
+//
+// [db.BlockTraces](BlockNumber, Index) -> (Compressed Trace)
+//
+// The total size of the stored traces is capped, the least recently used blocks are evicted once the cap
+// is exceeded. Traces of reverted blocks are removed by RevertHead, RevertBlock updates the accounting.
+type TraceStore struct {
+	database db.DB
+	maxSize  uint64
+
+	mu     sync.Mutex // protects index, recent and size.
+	index  map[uint64]*list.Element
+	recent *list.List // of *tracedBlock, the most recently used block is at the front
+	size   uint64
+}
+
+type tracedBlock struct {
+	number uint64
+	size   uint64 // size of the stored traces in bytes
+}
+
+// NewTraceStore creates a TraceStore that keeps at most maxSize bytes of compressed traces.
+// The traces that are already in the database are accounted for on creation.
+func NewTraceStore(database db.DB, maxSize uint64) (*TraceStore, error) {
+	s := &TraceStore{
+		database: database,
+		maxSize:  maxSize,
+		index:    make(map[uint64]*list.Element),
+		recent:   list.New(),
+	}
+
+	return s, database.View(func(txn db.Transaction) error {
+		it, err := txn.NewIterator()
+		if err != nil {
+			return err
+		}
+
+		prefix := db.BlockTraces.Key()
+		for it.Seek(prefix); it.Valid(); it.Next() {
+			key := it.Key()
+			if !bytes.HasPrefix(key, prefix) {
+				break
+			}
+
+			var dbKey txAndReceiptDBKey
+			if err = dbKey.UnmarshalBinary(key[len(prefix):]); err != nil {
+				return utils.RunAndWrapOnError(it.Close, err)
+			}
+
+			val, vErr := it.Value()
+			if vErr != nil {
+				return utils.RunAndWrapOnError(it.Close, vErr)
+			}
+
+			s.add(dbKey.Number, uint64(len(val)))
+		}
+		return it.Close()
+	})
+}
+
+// Traces returns the stored traces of the block with the given number and hash.
+// ErrTracesNotFound is returned if the traces of the block were never stored or were evicted.
+func (s *TraceStore) Traces(blockNumber uint64, blockHash *felt.Felt) ([][]byte, error) {
+	var traces [][]byte
+	err := s.database.View(func(txn db.Transaction) error {
+		header, err := blockHeaderByNumber(txn, blockNumber)
+		if err != nil {
+			return err
+		}
+		if !header.Hash.Equal(blockHash) {
+			return ErrTracesNotFound
+		}
+
+		traces = make([][]byte, 0, header.TransactionCount)
+		for i := uint64(0); i < header.TransactionCount; i++ {
+			key := db.BlockTraces.Key((&txAndReceiptDBKey{blockNumber, i}).MarshalBinary())
+			if err = txn.Get(key, func(val []byte) error {
+				trace, dErr := decompressTrace(val)
+				traces = append(traces, trace)
+				return dErr
+			}); err != nil {
+				if errors.Is(err, db.ErrKeyNotFound) {
+					return ErrTracesNotFound
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if elem, ok := s.index[blockNumber]; ok {
+		s.recent.MoveToFront(elem)
+	}
+	s.mu.Unlock()
+	return traces, nil
+}
+
+// StoreTraces stores the traces of all the transactions in the given block. The traces are only stored if
+// the block is still part of the canonical chain.
+func (s *TraceStore) StoreTraces(header *core.Header, traces [][]byte) error {
+	if uint64(len(traces)) != header.TransactionCount {
+		return errors.New("mismatched number of txs and traces")
+	}
+
+	compressed := make([][]byte, 0, len(traces))
+	var blockSize uint64
+	for _, trace := range traces {
+		c, err := compressTrace(trace)
+		if err != nil {
+			return err
+		}
+		compressed = append(compressed, c)
+		blockSize += uint64(len(c))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.database.Update(func(txn db.Transaction) error {
+		canonical, err := blockHeaderByNumber(txn, header.Number)
+		if err != nil {
+			return err
+		}
+		if !canonical.Hash.Equal(header.Hash) {
+			return errors.New("block is not part of the canonical chain")
+		}
+
+		for i, c := range compressed {
+			key := db.BlockTraces.Key((&txAndReceiptDBKey{header.Number, uint64(i)}).MarshalBinary())
+			if err = txn.Set(key, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	s.remove(header.Number)
+	s.add(header.Number, blockSize)
+	return s.evict()
+}
+
+// RevertBlock drops a reverted block from the size accounting, its traces are removed from the database by
+// RevertHead. It is meant to be registered with [Blockchain.WithRevertHook].
+func (s *TraceStore) RevertBlock(blockNumber uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(blockNumber)
+}
+
+// Size returns the total size of the stored traces in bytes
+func (s *TraceStore) Size() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// add accounts for size more bytes of traces of a block and marks it as the most recently used.
+// It assumes that s.mu is held.
+func (s *TraceStore) add(blockNumber, size uint64) {
+	s.size += size
+	if elem, ok := s.index[blockNumber]; ok {
+		elem.Value.(*tracedBlock).size += size
+		s.recent.MoveToFront(elem)
+		return
+	}
+	s.index[blockNumber] = s.recent.PushFront(&tracedBlock{number: blockNumber, size: size})
+}
+
+// remove drops a block from the accounting. It assumes that s.mu is held.
+func (s *TraceStore) remove(blockNumber uint64) {
+	if elem, ok := s.index[blockNumber]; ok {
+		s.size -= s.recent.Remove(elem).(*tracedBlock).size
+		delete(s.index, blockNumber)
+	}
+}
+
+// evict removes the least recently used blocks until the total size is within the cap.
+// It assumes that s.mu is held.
+func (s *TraceStore) evict() error {
+	for s.size > s.maxSize && s.recent.Len() > 0 {
+		oldest := s.recent.Back().Value.(*tracedBlock)
+		s.remove(oldest.number)
+		if err := s.database.Update(func(txn db.Transaction) error {
+			return deleteBlockTraces(txn, oldest.number)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteBlockTraces removes all the stored traces of the given block
+func deleteBlockTraces(txn db.Transaction, blockNumber uint64) error {
+	it, err := txn.NewIterator()
+	if err != nil {
+		return err
+	}
+
+	var keys [][]byte
+	prefix := db.BlockTraces.Key(core.MarshalBlockNumber(blockNumber))
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		keys = append(keys, bytes.Clone(key))
+	}
+	if err = it.Close(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err = txn.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compressTrace(trace []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(trace); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressTrace(compressed []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	trace, err := io.ReadAll(r)
+	if err != nil {
+		return nil, utils.RunAndWrapOnError(r.Close, err)
+	}
+	return trace, r.Close()
+}
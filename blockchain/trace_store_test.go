@@ -0,0 +1,107 @@
+package blockchain_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db/pebble"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceStore(t *testing.T) {
+	client := feeder.NewTestClient(t, &utils.Mainnet)
+	gw := adaptfeeder.New(client)
+
+	testDB := pebble.NewMemTest(t)
+	chain := blockchain.New(testDB, &utils.Mainnet)
+
+	var blocks []*core.Block
+	for i := uint64(0); i < 3; i++ {
+		block, err := gw.BlockByNumber(context.Background(), i)
+		require.NoError(t, err)
+		stateUpdate, err := gw.StateUpdate(context.Background(), i)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(block, &emptyCommitments, stateUpdate, nil))
+		blocks = append(blocks, block)
+	}
+
+	makeTraces := func(block *core.Block) [][]byte {
+		traces := make([][]byte, 0, block.TransactionCount)
+		for i := uint64(0); i < block.TransactionCount; i++ {
+			traces = append(traces, []byte(fmt.Sprintf(`{"block":%d,"index":%d}`, block.Number, i)))
+		}
+		return traces
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		store, err := blockchain.NewTraceStore(testDB, utils.Megabyte)
+		require.NoError(t, err)
+
+		_, err = store.Traces(blocks[1].Number, blocks[1].Hash)
+		assert.ErrorIs(t, err, blockchain.ErrTracesNotFound)
+	})
+
+	t.Run("store and read", func(t *testing.T) {
+		store, err := blockchain.NewTraceStore(testDB, utils.Megabyte)
+		require.NoError(t, err)
+
+		traces := makeTraces(blocks[1])
+		require.NoError(t, store.StoreTraces(blocks[1].Header, traces))
+
+		got, err := store.Traces(blocks[1].Number, blocks[1].Hash)
+		require.NoError(t, err)
+		assert.Equal(t, traces, got)
+
+		_, err = store.Traces(blocks[1].Number, blocks[0].Hash)
+		assert.ErrorIs(t, err, blockchain.ErrTracesNotFound)
+
+		// traces survive restarts
+		store, err = blockchain.NewTraceStore(testDB, utils.Megabyte)
+		require.NoError(t, err)
+		got, err = store.Traces(blocks[1].Number, blocks[1].Hash)
+		require.NoError(t, err)
+		assert.Equal(t, traces, got)
+	})
+
+	t.Run("mismatched number of traces", func(t *testing.T) {
+		store, err := blockchain.NewTraceStore(testDB, utils.Megabyte)
+		require.NoError(t, err)
+
+		assert.Error(t, store.StoreTraces(blocks[1].Header, [][]byte{}))
+	})
+
+	t.Run("least recently used blocks are evicted", func(t *testing.T) {
+		store, err := blockchain.NewTraceStore(testDB, 0)
+		require.NoError(t, err)
+
+		require.NoError(t, store.StoreTraces(blocks[2].Header, makeTraces(blocks[2])))
+		_, err = store.Traces(blocks[2].Number, blocks[2].Hash)
+		assert.ErrorIs(t, err, blockchain.ErrTracesNotFound)
+		_, err = store.Traces(blocks[1].Number, blocks[1].Hash)
+		assert.ErrorIs(t, err, blockchain.ErrTracesNotFound)
+	})
+
+	t.Run("reverted blocks are removed", func(t *testing.T) {
+		store, err := blockchain.NewTraceStore(testDB, utils.Megabyte)
+		require.NoError(t, err)
+
+		chain.WithRevertHook(store.RevertBlock)
+
+		sizeBefore := store.Size()
+		require.NoError(t, store.StoreTraces(blocks[2].Header, makeTraces(blocks[2])))
+		assert.Greater(t, store.Size(), sizeBefore)
+		require.NoError(t, chain.RevertHead())
+		assert.Equal(t, sizeBefore, store.Size())
+
+		_, err = store.Traces(blocks[2].Number, blocks[2].Hash)
+		assert.Error(t, err)
+		assert.Error(t, store.StoreTraces(blocks[2].Header, makeTraces(blocks[2])))
+	})
+}
@@ -0,0 +1,344 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+	"github.com/NethermindEth/juno/utils"
+)
+
+var ErrTransferIndexDisabled = errors.New("the token transfer index is disabled")
+
+// transferEventKey is the selector of the Transfer event of the ERC20 and ERC721 standards
+var transferEventKey = func() *felt.Felt {
+	key, err := crypto.StarknetKeccak([]byte("Transfer"))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// u128Bound bounds both halves of a u256
+var u128Bound = new(felt.Felt).Exp(new(felt.Felt).SetUint64(2), new(big.Int).SetUint64(128))
+
+// TransferID identifies a transfer by the position of its event in the chain
+type TransferID struct {
+	BlockNumber      uint64
+	TransactionIndex uint64
+	EventIndex       uint64
+}
+
+func (id *TransferID) MarshalBinary() []byte {
+	key := binary.BigEndian.AppendUint64(nil, id.BlockNumber)
+	key = binary.BigEndian.AppendUint64(key, id.TransactionIndex)
+	return binary.BigEndian.AppendUint64(key, id.EventIndex)
+}
+
+func (id *TransferID) UnmarshalBinary(data []byte) error {
+	if len(data) != 3*8 {
+		return fmt.Errorf("invalid transfer id length %d", len(data))
+	}
+	id.BlockNumber = binary.BigEndian.Uint64(data)
+	id.TransactionIndex = binary.BigEndian.Uint64(data[8:])
+	id.EventIndex = binary.BigEndian.Uint64(data[16:])
+	return nil
+}
+
+func (id *TransferID) String() string {
+	return fmt.Sprintf("%d-%d-%d", id.BlockNumber, id.TransactionIndex, id.EventIndex)
+}
+
+func (id *TransferID) FromString(str string) error {
+	_, err := fmt.Sscanf(str, "%d-%d-%d", &id.BlockNumber, &id.TransactionIndex, &id.EventIndex)
+	return err
+}
+
+// Transfer is a Transfer event of an ERC20 or ERC721 token. Value is the amount of ERC20 transfers and the token
+// id of ERC721 ones, it is split in the low and high 128 bits of a u256. The Cairo 0 layouts of both standards are
+// the same, so Transfer doesn't tell them apart.
+type Transfer struct {
+	BlockNumber      uint64
+	TransactionIndex uint64
+	EventIndex       uint64
+	TransactionHash  *felt.Felt
+	Token            *felt.Felt
+	From             *felt.Felt
+	To               *felt.Felt
+	ValueLow         *felt.Felt
+	ValueHigh        *felt.Felt
+}
+
+func (t *Transfer) ID() TransferID {
+	return TransferID{BlockNumber: t.BlockNumber, TransactionIndex: t.TransactionIndex, EventIndex: t.EventIndex}
+}
+
+// Value returns the u256 value of the transfer
+func (t *Transfer) Value() *big.Int {
+	value := t.ValueHigh.BigInt(new(big.Int))
+	value.Lsh(value, 128) //nolint:gomnd
+	return value.Or(value, t.ValueLow.BigInt(new(big.Int)))
+}
+
+// adaptTransfer recognises the layouts of the standard Transfer events:
+//   - keys [Transfer], data [from, to, value.low, value.high] for Cairo 0 ERC20 and ERC721 tokens
+//   - keys [Transfer], data [from, to, value] for old Cairo 0 ERC20 tokens with a felt amount
+//   - keys [Transfer, from, to], data [value.low, value.high] for Cairo 1 ERC20 tokens
+//   - keys [Transfer, from, to, value.low, value.high], no data for Cairo 1 ERC721 tokens
+//
+// It returns nil if the event isn't a transfer.
+func adaptTransfer(event *core.Event) *Transfer {
+	if len(event.Keys) == 0 || !event.Keys[0].Equal(transferEventKey) {
+		return nil
+	}
+
+	var fields []*felt.Felt
+	switch {
+	case len(event.Keys) == 1 && (len(event.Data) == 3 || len(event.Data) == 4):
+		fields = event.Data
+	case len(event.Keys) == 3 && len(event.Data) == 2, len(event.Keys) == 5 && len(event.Data) == 0:
+		fields = append(event.Keys[1:3:3], event.Data...)
+		fields = append(fields, event.Keys[3:]...)
+	default:
+		return nil
+	}
+
+	transfer := &Transfer{Token: event.From, From: fields[0], To: fields[1], ValueLow: fields[2], ValueHigh: &felt.Zero}
+	if len(fields) == 4 {
+		if transfer.ValueLow.Cmp(u128Bound) >= 0 || fields[3].Cmp(u128Bound) >= 0 {
+			return nil
+		}
+		transfer.ValueHigh = fields[3]
+	}
+	return transfer
+}
+
+// BlockRange is an inclusive range of block numbers
+type BlockRange struct {
+	From uint64
+	To   uint64
+}
+
+// WithTransferIndex makes Store index the token transfers of new blocks. Blocks stored while the index is disabled are
+// not indexed, IndexedTransferBlocks tells which blocks are.
+func (b *Blockchain) WithTransferIndex() *Blockchain {
+	b.transferIndex = true
+	return b
+}
+
+// storeTransfers indexes the transfers of a block as follows:
+//
+// [db.TokenTransfers](TransferID) -> (Transfer)
+// [db.TokenTransfersByAddress](Address, TransferID) -> ()
+// [db.TokenTransfersByAddressAndToken](Address, Token, TransferID) -> ()
+//
+// Both the sender and the recipient of a transfer are indexed. The block is added to the indexed ranges.
+func storeTransfers(txn db.Transaction, block *core.Block) error {
+	for txIndex, receipt := range block.Receipts {
+		for eventIndex, event := range receipt.Events {
+			transfer := adaptTransfer(event)
+			if transfer == nil {
+				continue
+			}
+			transfer.BlockNumber = block.Number
+			transfer.TransactionIndex = uint64(txIndex)
+			transfer.EventIndex = uint64(eventIndex)
+			transfer.TransactionHash = receipt.TransactionHash
+
+			transferBytes, err := encoder.Marshal(transfer)
+			if err != nil {
+				return err
+			}
+			transferID := transfer.ID()
+			id := transferID.MarshalBinary()
+			if err = txn.Set(db.TokenTransfers.Key(id), transferBytes); err != nil {
+				return err
+			}
+			for _, key := range transferIndexKeys(transfer, id) {
+				if err = txn.Set(key, nil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	ranges, err := transferIndexRanges(txn)
+	if err != nil {
+		return err
+	}
+	if n := len(ranges); n > 0 && ranges[n-1].To+1 == block.Number {
+		ranges[n-1].To = block.Number
+	} else {
+		ranges = append(ranges, BlockRange{From: block.Number, To: block.Number})
+	}
+	return setTransferIndexRanges(txn, ranges)
+}
+
+// transferIndexRanges returns the ranges of indexed blocks in ascending order:
+//
+// [db.TransferIndexRanges]() -> ([]BlockRange)
+func transferIndexRanges(txn db.Transaction) ([]BlockRange, error) {
+	var ranges []BlockRange
+	err := txn.Get(db.TransferIndexRanges.Key(), func(val []byte) error {
+		return encoder.Unmarshal(val, &ranges)
+	})
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return nil, nil
+	}
+	return ranges, err
+}
+
+func setTransferIndexRanges(txn db.Transaction, ranges []BlockRange) error {
+	if len(ranges) == 0 {
+		return txn.Delete(db.TransferIndexRanges.Key())
+	}
+	rangesBytes, err := encoder.Marshal(ranges)
+	if err != nil {
+		return err
+	}
+	return txn.Set(db.TransferIndexRanges.Key(), rangesBytes)
+}
+
+func transferIndexKeys(transfer *Transfer, id []byte) [][]byte {
+	addresses := []*felt.Felt{transfer.From}
+	if !transfer.To.Equal(transfer.From) {
+		addresses = append(addresses, transfer.To)
+	}
+
+	token := transfer.Token.Marshal()
+	keys := make([][]byte, 0, 2*len(addresses))
+	for _, address := range addresses {
+		addressBytes := address.Marshal()
+		keys = append(keys, db.TokenTransfersByAddress.Key(addressBytes, id),
+			db.TokenTransfersByAddressAndToken.Key(addressBytes, token, id))
+	}
+	return keys
+}
+
+// removeTransfers removes the indexed transfers of the head block, if any, and drops it from the indexed ranges
+func removeTransfers(txn db.Transaction, blockNumber uint64) error {
+	it, err := txn.NewIterator()
+	if err != nil {
+		return err
+	}
+
+	prefix := db.TokenTransfers.Key(core.MarshalBlockNumber(blockNumber))
+	var keys [][]byte
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+
+		val, vErr := it.Value()
+		if vErr != nil {
+			return utils.RunAndWrapOnError(it.Close, vErr)
+		}
+		var transfer Transfer
+		if err = encoder.Unmarshal(val, &transfer); err != nil {
+			return utils.RunAndWrapOnError(it.Close, err)
+		}
+		keys = append(keys, bytes.Clone(key))
+		keys = append(keys, transferIndexKeys(&transfer, key[len(db.TokenTransfers.Key()):])...)
+	}
+	if err = it.Close(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err = txn.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	ranges, err := transferIndexRanges(txn)
+	if err != nil {
+		return err
+	}
+	n := len(ranges)
+	if n == 0 || ranges[n-1].To != blockNumber {
+		return nil
+	}
+	if ranges[n-1].From == blockNumber {
+		ranges = ranges[:n-1]
+	} else {
+		ranges[n-1].To--
+	}
+	return setTransferIndexRanges(txn, ranges)
+}
+
+// IndexedTransferBlocks returns the ranges of blocks whose transfers are indexed in ascending order. Transfers of
+// blocks outside of them, stored while the index was disabled, are missing from Transfers.
+func (b *Blockchain) IndexedTransferBlocks() ([]BlockRange, error) {
+	b.listener.OnRead("IndexedTransferBlocks")
+	var ranges []BlockRange
+	return ranges, b.database.View(func(txn db.Transaction) error {
+		var err error
+		ranges, err = transferIndexRanges(txn)
+		return err
+	})
+}
+
+// Transfers returns up to limit transfers from or to address in the order they happened, starting at the transfer
+// with the given id. Only the transfers of token are returned if token isn't nil. The id of the next transfer is
+// returned as well, it is nil if there are no more transfers.
+func (b *Blockchain) Transfers(address, token *felt.Felt, start *TransferID, limit uint64) ([]*Transfer, *TransferID,
+	error,
+) {
+	b.listener.OnRead("Transfers")
+	if !b.transferIndex {
+		return nil, nil, ErrTransferIndexDisabled
+	}
+
+	prefix := db.TokenTransfersByAddress.Key(address.Marshal())
+	if token != nil {
+		prefix = db.TokenTransfersByAddressAndToken.Key(address.Marshal(), token.Marshal())
+	}
+
+	var (
+		transfers []*Transfer
+		next      *TransferID
+	)
+	return transfers, next, b.database.View(func(txn db.Transaction) error {
+		it, err := txn.NewIterator()
+		if err != nil {
+			return err
+		}
+
+		seek := prefix
+		if start != nil {
+			seek = append(bytes.Clone(prefix), start.MarshalBinary()...)
+		}
+		for it.Seek(seek); it.Valid(); it.Next() {
+			key := it.Key()
+			if !bytes.HasPrefix(key, prefix) {
+				break
+			}
+
+			var id TransferID
+			if err = id.UnmarshalBinary(key[len(prefix):]); err != nil {
+				return utils.RunAndWrapOnError(it.Close, err)
+			}
+			if uint64(len(transfers)) == limit {
+				next = &id
+				break
+			}
+
+			transfer := new(Transfer)
+			if err = txn.Get(db.TokenTransfers.Key(id.MarshalBinary()), func(val []byte) error {
+				return encoder.Unmarshal(val, transfer)
+			}); err != nil {
+				return utils.RunAndWrapOnError(it.Close, err)
+			}
+			transfers = append(transfers, transfer)
+		}
+		return it.Close()
+	})
+}
@@ -0,0 +1,171 @@
+package blockchain_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransfers(t *testing.T) {
+	transferKey, err := crypto.StarknetKeccak([]byte("Transfer"))
+	require.NoError(t, err)
+	f := func(v uint64) *felt.Felt { return new(felt.Felt).SetUint64(v) }
+	tokenA, tokenB, nft := f(0x100), f(0x200), f(0x300)
+	alice, bob, carol := f(0xa), f(0xb), f(0xc)
+	u128 := new(felt.Felt).Exp(f(2), big.NewInt(128))
+
+	// makeBlock returns a block with a transaction per list of events
+	makeBlock := func(number uint64, parent *felt.Felt, events ...[]*core.Event) *core.Block {
+		block := &core.Block{Header: &core.Header{
+			Number:           number,
+			Hash:             f(1000 + number),
+			ParentHash:       parent,
+			GlobalStateRoot:  &felt.Zero,
+			TransactionCount: uint64(len(events)),
+		}}
+		for i, txEvents := range events {
+			hash := f(number*100 + uint64(i))
+			block.Transactions = append(block.Transactions, &core.InvokeTransaction{TransactionHash: hash})
+			block.Receipts = append(block.Receipts, &core.TransactionReceipt{TransactionHash: hash, Events: txEvents})
+		}
+		return block
+	}
+	emptyUpdate := &core.StateUpdate{OldRoot: &felt.Zero, NewRoot: &felt.Zero, StateDiff: &core.StateDiff{}}
+
+	block0 := makeBlock(0, &felt.Zero, []*core.Event{
+		// Cairo 0 ERC20
+		{From: tokenA, Keys: []*felt.Felt{transferKey}, Data: []*felt.Felt{alice, bob, f(5), &felt.Zero}},
+		{From: tokenA, Keys: []*felt.Felt{f(1)}, Data: []*felt.Felt{alice, bob, f(5), &felt.Zero}},
+		// Cairo 1 ERC20 with an amount above 2^128
+		{From: tokenB, Keys: []*felt.Felt{transferKey, bob, carol}, Data: []*felt.Felt{f(2), f(1)}},
+	}, []*core.Event{
+		// Cairo 1 ERC721
+		{From: nft, Keys: []*felt.Felt{transferKey, alice, carol, f(7), &felt.Zero}},
+		// not a u256
+		{From: tokenA, Keys: []*felt.Felt{transferKey, alice, carol}, Data: []*felt.Felt{u128, &felt.Zero}},
+	})
+	block1 := makeBlock(1, block0.Hash, []*core.Event{
+		// Cairo 0 ERC20 with a felt amount
+		{From: tokenA, Keys: []*felt.Felt{transferKey}, Data: []*felt.Felt{bob, alice, f(3)}},
+	})
+
+	testDB := pebble.NewMemTest(t)
+	chain := blockchain.New(testDB, &utils.Mainnet).WithTransferIndex()
+	for _, block := range []*core.Block{block0, block1} {
+		require.NoError(t, chain.Store(block, &emptyCommitments, emptyUpdate, nil))
+	}
+
+	t.Run("disabled index", func(t *testing.T) {
+		_, _, err := blockchain.New(testDB, &utils.Mainnet).Transfers(alice, nil, nil, 10)
+		require.ErrorIs(t, err, blockchain.ErrTransferIndexDisabled)
+	})
+
+	t.Run("transfers of an address", func(t *testing.T) {
+		transfers, next, err := chain.Transfers(alice, nil, nil, 10)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		require.Len(t, transfers, 3)
+
+		assert.Equal(t, &blockchain.Transfer{
+			BlockNumber:     0,
+			TransactionHash: f(0),
+			Token:           tokenA,
+			From:            alice,
+			To:              bob,
+			ValueLow:        f(5),
+			ValueHigh:       &felt.Zero,
+		}, transfers[0])
+		assert.Equal(t, blockchain.TransferID{BlockNumber: 0, TransactionIndex: 1, EventIndex: 0}, transfers[1].ID())
+		assert.Equal(t, nft, transfers[1].Token)
+		assert.Equal(t, big.NewInt(7), transfers[1].Value())
+		assert.Equal(t, blockchain.TransferID{BlockNumber: 1, TransactionIndex: 0, EventIndex: 0}, transfers[2].ID())
+		assert.Equal(t, big.NewInt(3), transfers[2].Value())
+	})
+
+	t.Run("u256 value", func(t *testing.T) {
+		transfers, _, err := chain.Transfers(carol, tokenB, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+		want := new(big.Int).Lsh(big.NewInt(1), 128)
+		assert.Equal(t, want.Add(want, big.NewInt(2)), transfers[0].Value())
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		transfers, next, err := chain.Transfers(bob, nil, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, transfers, 2)
+		require.NotNil(t, next)
+		assert.Equal(t, "1-0-0", next.String())
+
+		var start blockchain.TransferID
+		require.NoError(t, start.FromString(next.String()))
+		transfers, next, err = chain.Transfers(bob, nil, &start, 2)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		require.Len(t, transfers, 1)
+		assert.Equal(t, alice, transfers[0].To)
+	})
+
+	t.Run("transfers of a token", func(t *testing.T) {
+		transfers, _, err := chain.Transfers(alice, tokenA, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, transfers, 2)
+		for _, transfer := range transfers {
+			assert.Equal(t, tokenA, transfer.Token)
+		}
+	})
+
+	t.Run("indexed blocks", func(t *testing.T) {
+		ranges, err := chain.IndexedTransferBlocks()
+		require.NoError(t, err)
+		assert.Equal(t, []blockchain.BlockRange{{From: 0, To: 1}}, ranges)
+	})
+
+	t.Run("revert removes the transfers", func(t *testing.T) {
+		require.NoError(t, chain.RevertHead())
+		transfers, _, err := chain.Transfers(alice, tokenA, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+		ranges, err := chain.IndexedTransferBlocks()
+		require.NoError(t, err)
+		assert.Equal(t, []blockchain.BlockRange{{From: 0, To: 0}}, ranges)
+
+		require.NoError(t, chain.RevertHead())
+		require.NoError(t, testDB.View(func(txn db.Transaction) error {
+			it, err := txn.NewIterator()
+			if err != nil {
+				return err
+			}
+			for _, bucket := range []db.Bucket{db.TokenTransfers, db.TokenTransfersByAddress, db.TokenTransfersByAddressAndToken} {
+				it.Seek(bucket.Key())
+				assert.False(t, it.Valid() && it.Key()[0] == byte(bucket), bucket)
+			}
+			return it.Close()
+		}))
+		ranges, err = chain.IndexedTransferBlocks()
+		require.NoError(t, err)
+		assert.Empty(t, ranges)
+	})
+
+	t.Run("blocks stored without the index aren't indexed", func(t *testing.T) {
+		require.NoError(t, blockchain.New(testDB, &utils.Mainnet).Store(block0, &emptyCommitments, emptyUpdate, nil))
+		require.NoError(t, chain.Store(block1, &emptyCommitments, emptyUpdate, nil))
+
+		ranges, err := chain.IndexedTransferBlocks()
+		require.NoError(t, err)
+		assert.Equal(t, []blockchain.BlockRange{{From: 1, To: 1}}, ranges)
+		transfers, _, err := chain.Transfers(alice, nil, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+		assert.Equal(t, uint64(1), transfers[0].BlockNumber)
+	})
+}
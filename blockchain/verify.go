@@ -0,0 +1,224 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/utils"
+)
+
+// Checks performed by Verify
+const (
+	CheckChainHeight      = "chain_height"
+	CheckBlockHeader      = "block_header"
+	CheckParentHash       = "parent_hash"
+	CheckTransactionCount = "transaction_count"
+	CheckReceiptCount     = "receipt_count"
+	CheckTransactionIndex = "transaction_index"
+	CheckStateUpdate      = "state_update"
+	CheckDeclaredClass    = "declared_class"
+	CheckStateRoot        = "state_root"
+)
+
+// maxProblems bounds the size of the report of a badly corrupted database
+const maxProblems = 1000
+
+// Problem is an inconsistency found by Verify. Key is the hash of the transaction or class the problem is about.
+type Problem struct {
+	Check       string  `json:"check"`
+	BlockNumber *uint64 `json:"block_number,omitempty"`
+	Key         string  `json:"key,omitempty"`
+	Message     string  `json:"message"`
+}
+
+// VerifyReport summarises a Verify run. Problems holds the first maxProblems problems, ProblemCount counts all of
+// them.
+type VerifyReport struct {
+	Height                  *uint64   `json:"height"`
+	Blocks                  uint64    `json:"blocks"`
+	TransactionIndexEntries uint64    `json:"transaction_index_entries"`
+	DeclaredClasses         uint64    `json:"declared_classes"`
+	StateRootChecked        bool      `json:"state_root_checked"`
+	ProblemCount            uint64    `json:"problem_count"`
+	Problems                []Problem `json:"problems"`
+}
+
+// OK returns true if no inconsistency was found
+func (r *VerifyReport) OK() bool {
+	return r.ProblemCount == 0
+}
+
+func (r *VerifyReport) add(check string, blockNumber *uint64, key *felt.Felt, format string, args ...any) {
+	r.ProblemCount++
+	if len(r.Problems) >= maxProblems {
+		return
+	}
+
+	problem := Problem{Check: check, Message: fmt.Sprintf(format, args...)}
+	if blockNumber != nil {
+		problem.BlockNumber = utils.Ptr(*blockNumber)
+	}
+	if key != nil {
+		problem.Key = key.String()
+	}
+	r.Problems = append(r.Problems, problem)
+}
+
+// Verify checks the consistency of the stored chain in a single read-only transaction:
+//   - the parent hash of every block is the hash of the previous block
+//   - the transactions and receipts of every block match the counts in its header
+//   - every entry of the transaction hash index resolves to a transaction with that hash
+//   - every block has a state update and the classes it declares are stored
+//   - optionally, the root of the state tries matches the state root of the head
+//
+// Inconsistencies are reported, the returned error is only set if the database can't be read at all.
+func Verify(ctx context.Context, database db.DB, checkStateRoot bool) (*VerifyReport, error) {
+	RegisterCoreTypesToEncoder()
+	report := &VerifyReport{Problems: []Problem{}}
+	err := database.View(func(txn db.Transaction) error {
+		height, err := ChainHeight(txn)
+		if errors.Is(err, db.ErrKeyNotFound) {
+			// nothing to verify, most likely the path doesn't point to the database of a node
+			report.add(CheckChainHeight, nil, nil, "the database holds no blocks")
+			return nil
+		} else if err != nil {
+			return err
+		}
+		report.Height = &height
+
+		if err = verifyBlocks(ctx, txn, height, report); err != nil {
+			return err
+		}
+		if err = verifyTransactionIndex(ctx, txn, height, report); err != nil {
+			return err
+		}
+		if checkStateRoot {
+			verifyStateRoot(txn, height, report)
+		}
+		return nil
+	})
+	return report, err
+}
+
+func verifyBlocks(ctx context.Context, txn db.Transaction, height uint64, report *VerifyReport) error {
+	var parent *core.Header
+	for number := uint64(0); number <= height; number++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		report.Blocks++
+
+		header, err := blockHeaderByNumber(txn, number)
+		if err != nil {
+			report.add(CheckBlockHeader, &number, nil, "read header: %v", err)
+			parent = nil
+			continue
+		}
+		if parent != nil && !header.ParentHash.Equal(parent.Hash) {
+			report.add(CheckParentHash, &number, nil, "parent hash %s does not match the hash %s of block %d",
+				header.ParentHash, parent.Hash, parent.Number)
+		}
+		parent = header
+
+		if txs, txsErr := transactionsByBlockNumber(txn, number); txsErr != nil {
+			report.add(CheckTransactionCount, &number, nil, "read transactions: %v", txsErr)
+		} else if uint64(len(txs)) != header.TransactionCount {
+			report.add(CheckTransactionCount, &number, nil, "%d transactions stored, the header has %d", len(txs),
+				header.TransactionCount)
+		}
+		if receipts, receiptsErr := receiptsByBlockNumber(txn, number); receiptsErr != nil {
+			report.add(CheckReceiptCount, &number, nil, "read receipts: %v", receiptsErr)
+		} else if uint64(len(receipts)) != header.TransactionCount {
+			report.add(CheckReceiptCount, &number, nil, "%d receipts stored, the header has %d transactions",
+				len(receipts), header.TransactionCount)
+		}
+
+		update, err := StateUpdateByNumber(txn, number)
+		if err != nil {
+			report.add(CheckStateUpdate, &number, nil, "read state update: %v", err)
+			continue
+		}
+		declared := slices.Clone(update.StateDiff.DeclaredV0Classes)
+		for classHash := range update.StateDiff.DeclaredV1Classes {
+			declared = append(declared, new(felt.Felt).Set(&classHash))
+		}
+		for _, classHash := range declared {
+			report.DeclaredClasses++
+			if err = txn.Get(db.Class.Key(classHash.Marshal()), func([]byte) error { return nil }); err != nil {
+				report.add(CheckDeclaredClass, &number, classHash, "read class: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func verifyTransactionIndex(ctx context.Context, txn db.Transaction, height uint64, report *VerifyReport) error {
+	it, err := txn.NewIterator()
+	if err != nil {
+		return err
+	}
+
+	prefix := db.TransactionBlockNumbersAndIndicesByHash.Key()
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			return utils.RunAndWrapOnError(it.Close, err)
+		}
+		report.TransactionIndexEntries++
+
+		txHash := new(felt.Felt).SetBytes(key[len(prefix):])
+		val, vErr := it.Value()
+		if vErr != nil {
+			report.add(CheckTransactionIndex, nil, txHash, "read index entry: %v", vErr)
+			continue
+		}
+		var bnIndex txAndReceiptDBKey
+		if err = bnIndex.UnmarshalBinary(val); err != nil {
+			report.add(CheckTransactionIndex, nil, txHash, "decode index entry: %v", err)
+			continue
+		}
+		if bnIndex.Number > height {
+			report.add(CheckTransactionIndex, &bnIndex.Number, txHash, "index entry points above the chain height %d", height)
+			continue
+		}
+
+		tx, txErr := transactionByBlockNumberAndIndex(txn, &bnIndex)
+		if txErr != nil {
+			report.add(CheckTransactionIndex, &bnIndex.Number, txHash, "read transaction %d: %v", bnIndex.Index, txErr)
+			continue
+		}
+		if !tx.Hash().Equal(txHash) {
+			report.add(CheckTransactionIndex, &bnIndex.Number, txHash, "transaction %d has hash %s", bnIndex.Index, tx.Hash())
+		}
+		if _, err = receiptByBlockNumberAndIndex(txn, &bnIndex); err != nil {
+			report.add(CheckTransactionIndex, &bnIndex.Number, txHash, "read receipt %d: %v", bnIndex.Index, err)
+		}
+	}
+	return it.Close()
+}
+
+func verifyStateRoot(txn db.Transaction, height uint64, report *VerifyReport) {
+	report.StateRootChecked = true
+	header, err := blockHeaderByNumber(txn, height)
+	if err != nil {
+		report.add(CheckStateRoot, &height, nil, "read head header: %v", err)
+		return
+	}
+
+	root, err := core.NewState(txn).Root()
+	if err != nil {
+		report.add(CheckStateRoot, &height, nil, "compute state root: %v", err)
+	} else if !root.Equal(header.GlobalStateRoot) {
+		report.add(CheckStateRoot, &height, nil, "state root %s does not match the root %s of the head",
+			root, header.GlobalStateRoot)
+	}
+}
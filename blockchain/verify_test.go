@@ -0,0 +1,118 @@
+package blockchain_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/encoder"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	gw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Mainnet))
+	testDB := pebble.NewMemTest(t)
+	chain := blockchain.New(testDB, &utils.Mainnet)
+
+	t.Run("empty database", func(t *testing.T) {
+		report, err := blockchain.Verify(context.Background(), testDB, true)
+		require.NoError(t, err)
+		assert.False(t, report.OK())
+		assert.Nil(t, report.Height)
+		require.Len(t, report.Problems, 1)
+		assert.Equal(t, blockchain.CheckChainHeight, report.Problems[0].Check)
+	})
+
+	for number := uint64(0); number < 3; number++ {
+		stateUpdate, block, err := gw.StateUpdateWithBlock(context.Background(), number)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(block, &core.BlockCommitments{}, stateUpdate, nil))
+	}
+
+	t.Run("consistent database", func(t *testing.T) {
+		report, err := blockchain.Verify(context.Background(), testDB, true)
+		require.NoError(t, err)
+		assert.True(t, report.OK(), report.Problems)
+		assert.Equal(t, uint64(2), *report.Height)
+		assert.Equal(t, uint64(3), report.Blocks)
+		assert.True(t, report.StateRootChecked)
+		assert.NotZero(t, report.TransactionIndexEntries)
+	})
+
+	t.Run("corrupted database", func(t *testing.T) {
+		block1, err := chain.BlockByNumber(1)
+		require.NoError(t, err)
+		head, err := chain.HeadsHeader()
+		require.NoError(t, err)
+		unknownClass := new(felt.Felt).SetUint64(42)
+
+		require.NoError(t, testDB.Update(func(txn db.Transaction) error {
+			// drop the first transaction of block 1
+			txKey := binary.BigEndian.AppendUint64(core.MarshalBlockNumber(1), 0)
+			if err = txn.Delete(db.TransactionsByBlockNumberAndIndex.Key(txKey)); err != nil {
+				return err
+			}
+			// drop the state update of block 1
+			if err = txn.Delete(db.StateUpdatesByBlockNumber.Key(core.MarshalBlockNumber(1))); err != nil {
+				return err
+			}
+
+			// the head no longer follows block 1 and its state root is wrong
+			head.ParentHash = new(felt.Felt).SetUint64(1)
+			head.GlobalStateRoot = new(felt.Felt).SetUint64(2)
+			headerBytes, mErr := encoder.Marshal(head)
+			if mErr != nil {
+				return mErr
+			}
+			if err = txn.Set(db.BlockHeadersByNumber.Key(core.MarshalBlockNumber(2)), headerBytes); err != nil {
+				return err
+			}
+
+			// the head declares a class that isn't stored
+			update, sErr := blockchain.StateUpdateByNumber(txn, 2)
+			if sErr != nil {
+				return sErr
+			}
+			update.StateDiff.DeclaredV0Classes = append(update.StateDiff.DeclaredV0Classes, unknownClass)
+			updateBytes, mErr := encoder.Marshal(update)
+			if mErr != nil {
+				return mErr
+			}
+			return txn.Set(db.StateUpdatesByBlockNumber.Key(core.MarshalBlockNumber(2)), updateBytes)
+		}))
+
+		report, err := blockchain.Verify(context.Background(), testDB, true)
+		require.NoError(t, err)
+		assert.False(t, report.OK())
+
+		problems := make(map[string]blockchain.Problem)
+		for _, problem := range report.Problems {
+			problems[problem.Check] = problem
+		}
+		assert.Len(t, problems, 6, report.Problems)
+		assert.Equal(t, uint64(len(report.Problems)), report.ProblemCount)
+
+		for check, number := range map[string]uint64{
+			blockchain.CheckTransactionCount: 1,
+			blockchain.CheckStateUpdate:      1,
+			blockchain.CheckParentHash:       2,
+			blockchain.CheckDeclaredClass:    2,
+			blockchain.CheckStateRoot:        2,
+			blockchain.CheckTransactionIndex: 1,
+		} {
+			require.Contains(t, problems, check)
+			assert.Equal(t, number, *problems[check].BlockNumber, check)
+		}
+		assert.Equal(t, block1.Transactions[0].Hash().String(), problems[blockchain.CheckTransactionIndex].Key)
+		assert.Equal(t, unknownClass.String(), problems[blockchain.CheckDeclaredClass].Key)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -19,10 +20,16 @@ import (
 	"github.com/NethermindEth/juno/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var ErrDeprecatedCompiledClass = errors.New("deprecated compiled class")
 
+var tracer = otel.Tracer("github.com/NethermindEth/juno/clients/feeder")
+
 type Backoff func(wait time.Duration) time.Duration
 
 type Client struct {
@@ -227,12 +234,17 @@ func (c *Client) buildQueryString(endpoint string, args map[string]string) strin
 
 // get performs a "GET" http request with the given URL and returns the response body
 func (c *Client) get(ctx context.Context, queryURL string) (io.ReadCloser, error) {
+	ctx, span := c.startSpan(ctx, queryURL)
+	defer span.End()
+
 	var res *http.Response
 	var err error
 	wait := time.Duration(0)
 	for i := 0; i <= c.maxRetries; i++ {
+		span.SetAttributes(attribute.Int("feeder.attempts", i+1))
 		select {
 		case <-ctx.Done():
+			span.SetStatus(codes.Error, ctx.Err().Error())
 			return nil, ctx.Err()
 		case <-time.After(wait):
 			var req *http.Request
@@ -251,6 +263,7 @@ func (c *Client) get(ctx context.Context, queryURL string) (io.ReadCloser, error
 			res, err = c.client.Do(req)
 			if err == nil {
 				c.listener.OnResponse(req.URL.Path, res.StatusCode, time.Since(reqTimer))
+				span.SetAttributes(attribute.Int("http.status_code", res.StatusCode))
 				if res.StatusCode == http.StatusOK {
 					return res.Body, nil
 				} else {
@@ -270,9 +283,32 @@ func (c *Client) get(ctx context.Context, queryURL string) (io.ReadCloser, error
 			c.log.Debugw("Failed query to feeder, retrying...", "req", req.URL.String(), "retryAfter", wait.String(), "err", err)
 		}
 	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
 	return nil, err
 }
 
+// startSpan starts the span of a query, named after the queried endpoint
+func (c *Client) startSpan(ctx context.Context, queryURL string) (context.Context, trace.Span) {
+	name := "feeder"
+	if u, err := url.Parse(queryURL); err == nil {
+		name += "." + path.Base(u.Path)
+	}
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("http.url", queryURL)))
+}
+
+// Raw returns the response of endpoint as it is, for callers that store the responses rather than decode them
+func (c *Client) Raw(ctx context.Context, endpoint string, args map[string]string) ([]byte, error) {
+	body, err := c.get(ctx, c.buildQueryString(endpoint, args))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
 func (c *Client) StateUpdate(ctx context.Context, blockID string) (*starknet.StateUpdate, error) {
 	queryURL := c.buildQueryString("get_state_update", map[string]string{
 		"blockNumber": blockID,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strconv"
 	"testing"
 	"time"
@@ -649,6 +650,24 @@ func TestBlockTrace(t *testing.T) {
 	})
 }
 
+func TestRaw(t *testing.T) {
+	client := feeder.NewTestClient(t, &utils.Mainnet)
+
+	t.Run("existing block", func(t *testing.T) {
+		raw, err := client.Raw(context.Background(), "get_block", map[string]string{"blockNumber": "0"})
+		require.NoError(t, err)
+
+		expected, err := os.ReadFile("testdata/mainnet/block/0.json")
+		require.NoError(t, err)
+		assert.Equal(t, expected, raw)
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := client.Raw(context.Background(), "get_block", map[string]string{"blockNumber": "10000000000"})
+		assert.Error(t, err)
+	})
+}
+
 func TestEventListener(t *testing.T) {
 	isCalled := false
 	client := feeder.NewTestClient(t, &utils.Integration).WithListener(&feeder.SelectiveListener{
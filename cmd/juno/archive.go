@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/starknetdata/archive"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	archiveFromF   = "from"
+	archiveToF     = "to"
+	archiveOutputF = "output"
+
+	archiveFromUsage   = "First block to download."
+	archiveToUsage     = "Last block to download."
+	archiveOutputUsage = "Archive to create, a zip file if the path ends with .zip and a directory otherwise."
+)
+
+// Archive returns the commands that manage the block archives a node can sync from with --archive.
+func Archive() *cobra.Command {
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Manage block archives for syncing without the feeder gateway.",
+	}
+	archiveCmd.AddCommand(archiveDownload())
+	return archiveCmd
+}
+
+func archiveDownload() *cobra.Command {
+	var (
+		from, to uint64
+		output   string
+		apiKey   string
+		timeout  time.Duration
+	)
+	network := utils.Mainnet
+
+	downloadCmd := &cobra.Command{
+		Use:   "download",
+		Short: "Download blocks, state updates and classes from the feeder gateway into an archive.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runArchiveDownload(cmd.Context(), &network, from, to, output, apiKey, timeout)
+		},
+	}
+
+	downloadCmd.Flags().Var(&network, networkF, networkUsage)
+	downloadCmd.Flags().Uint64Var(&from, archiveFromF, 0, archiveFromUsage)
+	downloadCmd.Flags().Uint64Var(&to, archiveToF, 0, archiveToUsage)
+	downloadCmd.Flags().StringVar(&output, archiveOutputF, "", archiveOutputUsage)
+	downloadCmd.Flags().StringVar(&apiKey, gwAPIKeyF, defaultGwAPIKey, gwAPIKeyUsage)
+	downloadCmd.Flags().DurationVar(&timeout, gwTimeoutF, defaultGwTimeout, gwTimeoutUsage)
+	downloadCmd.MarkFlagRequired(archiveToF)     //nolint:errcheck
+	downloadCmd.MarkFlagRequired(archiveOutputF) //nolint:errcheck
+
+	return downloadCmd
+}
+
+func runArchiveDownload(ctx context.Context, network *utils.Network, from, to uint64, output, apiKey string,
+	timeout time.Duration,
+) error {
+	log, err := utils.NewZapLogger(utils.INFO, false)
+	if err != nil {
+		return err
+	}
+
+	ua := fmt.Sprintf("Juno/%s Starknet Client", Version)
+	client := feeder.NewClient(network.FeederURL).WithUserAgent(ua).WithLogger(log).WithTimeout(timeout).WithAPIKey(apiKey)
+	writer, err := archive.Create(output)
+	if err != nil {
+		return fmt.Errorf("create archive: %w", err)
+	}
+
+	err = archive.Download(ctx, client, from, to, writer, log)
+	return errors.Join(err, writer.Close())
+}
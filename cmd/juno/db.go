@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/db/remote"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	dbOutputF        = "output"
+	verifyStateRootF = "state-root"
+	statsLargestF    = "largest"
+	compactBucketF   = "bucket"
+
+	defaultStatsLargest = 10
+
+	dbOutputUsage        = "File to write the report to. The report is written to stdout if empty."
+	dbRemoteDBUsage      = "gRPC URL of a running Juno node, its database can't be opened while the node runs."
+	verifyStateRootUsage = "Also check that the root of the state tries matches the state root of the head block."
+	statsLargestUsage    = "Number of the largest entries to report for every bucket."
+	compactBucketUsage   = "Name of the bucket to compact, e.g. ContractStorage. The whole database is compacted if empty."
+)
+
+// DB returns the commands that inspect and maintain the database.
+func DB() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Inspect and maintain the database.",
+	}
+	dbCmd.AddCommand(dbVerify(), dbStats(), dbCompact())
+	return dbCmd
+}
+
+func dbVerify() *cobra.Command {
+	var (
+		dbPath, remoteDB string
+		stateRoot        bool
+		output           string
+	)
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check the consistency of the stored chain, exits with an error if it is corrupted or empty.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDBVerify(cmd.Context(), dbPath, remoteDB, stateRoot, output)
+		},
+	}
+
+	verifyCmd.Flags().StringVar(&dbPath, dbPathF, "", dbPathUsage)
+	verifyCmd.Flags().StringVar(&remoteDB, remoteDBF, "", dbRemoteDBUsage)
+	verifyCmd.Flags().BoolVar(&stateRoot, verifyStateRootF, false, verifyStateRootUsage)
+	verifyCmd.Flags().StringVar(&output, dbOutputF, "", dbOutputUsage)
+
+	return verifyCmd
+}
+
+func runDBVerify(ctx context.Context, dbPath, remoteDB string, stateRoot bool, output string) error {
+	log, err := utils.NewZapLogger(utils.INFO, false)
+	if err != nil {
+		return err
+	}
+	database, err := openDB(ctx, dbPath, remoteDB, false, log)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	log.Infow("Verifying the database", "stateRoot", stateRoot)
+	report, err := blockchain.Verify(ctx, database, stateRoot)
+	if err != nil {
+		return err
+	}
+
+	if err = writeReport(output, report); err != nil {
+		return err
+	}
+
+	log.Infow("Verification finished", "blocks", report.Blocks, "transactionIndexEntries", report.TransactionIndexEntries,
+		"declaredClasses", report.DeclaredClasses, "problems", report.ProblemCount)
+	if !report.OK() {
+		return errors.New("the database failed verification")
+	}
+	return nil
+}
+
+func dbStats() *cobra.Command {
+	var (
+		dbPath, remoteDB string
+		largest          int
+		output           string
+	)
+
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Report the number and size of the keys and values of every bucket.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDBStats(cmd.Context(), dbPath, remoteDB, largest, output)
+		},
+	}
+
+	statsCmd.Flags().StringVar(&dbPath, dbPathF, "", dbPathUsage)
+	statsCmd.Flags().StringVar(&remoteDB, remoteDBF, "", dbRemoteDBUsage)
+	statsCmd.Flags().IntVar(&largest, statsLargestF, defaultStatsLargest, statsLargestUsage)
+	statsCmd.Flags().StringVar(&output, dbOutputF, "", dbOutputUsage)
+
+	return statsCmd
+}
+
+func runDBStats(ctx context.Context, dbPath, remoteDB string, largest int, output string) error {
+	if largest < 0 {
+		return fmt.Errorf("--%s can't be negative", statsLargestF)
+	}
+
+	log, err := utils.NewZapLogger(utils.INFO, false)
+	if err != nil {
+		return err
+	}
+	database, err := openDB(ctx, dbPath, remoteDB, false, log)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	log.Infow("Collecting database statistics, this reads every key of the database")
+	stats, err := db.CollectStats(ctx, database, largest)
+	if err != nil {
+		return err
+	}
+	return writeReport(output, stats)
+}
+
+func dbCompact() *cobra.Command {
+	var dbPath, bucketName string
+
+	compactCmd := &cobra.Command{
+		Use:   "compact",
+		Short: "Compact a bucket or the whole database to reclaim the space of deleted and overwritten keys.",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDBCompact(dbPath, bucketName)
+		},
+	}
+
+	compactCmd.Flags().StringVar(&dbPath, dbPathF, "", dbPathUsage)
+	compactCmd.Flags().StringVar(&bucketName, compactBucketF, "", compactBucketUsage)
+	compactCmd.MarkFlagRequired(dbPathF) //nolint:errcheck
+
+	return compactCmd
+}
+
+func runDBCompact(dbPath, bucketName string) error {
+	log, err := utils.NewZapLogger(utils.INFO, false)
+	if err != nil {
+		return err
+	}
+
+	bucket, found := db.BucketByName(bucketName)
+	if bucketName != "" && !found {
+		return fmt.Errorf("unknown bucket %q", bucketName)
+	}
+
+	database, err := openDB(context.Background(), dbPath, "", true, log)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	start := time.Now()
+	if bucketName == "" {
+		log.Infow("Compacting the database")
+		err = pebble.Compact(database)
+	} else {
+		log.Infow("Compacting bucket", "bucket", bucket)
+		err = pebble.CompactBucket(database, bucket)
+	}
+	if err != nil {
+		return err
+	}
+	log.Infow("Compaction finished", "took", time.Since(start).String())
+	return nil
+}
+
+// writeReport writes the report as indented JSON to the output file, or to stdout if output is empty
+func writeReport(output string, report any) error {
+	out := os.Stdout
+	if output != "" {
+		var err error
+		if out, err = os.Create(output); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// openDB opens the existing database at dbPath or connects to the database of the node at remoteDB, exactly one of
+// them must be set. The local database is opened read-only unless writable is set.
+func openDB(ctx context.Context, dbPath, remoteDB string, writable bool, log utils.SimpleLogger) (db.DB, error) {
+	if (dbPath == "") == (remoteDB == "") {
+		return nil, fmt.Errorf("exactly one of --%s and --%s is required", dbPathF, remoteDBF)
+	}
+
+	var (
+		database db.DB
+		err      error
+	)
+	if remoteDB != "" {
+		database, err = remote.New(remoteDB, ctx, log, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		var dbLog *utils.ZapLogger
+		if dbLog, err = utils.NewZapLogger(utils.ERROR, false); err != nil {
+			return nil, err
+		}
+		if writable {
+			database, err = pebble.New(dbPath, defaultCacheSizeMb, defaultMaxHandles, dbLog)
+		} else {
+			database, err = pebble.NewReadOnly(dbPath, defaultCacheSizeMb, defaultMaxHandles, dbLog)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open DB: %w", err)
+	}
+	return database, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/NethermindEth/juno/export"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	exportFromF      = "from"
+	exportToF        = "to"
+	exportOutputF    = "output"
+	exportFormatF    = "format"
+	exportChunkSizeF = "chunk-size"
+	exportWorkersF   = "workers"
+
+	exportFromUsage      = "First block to export."
+	exportToUsage        = "Last block to export, the head block if not set."
+	exportOutputUsage    = "Directory to write the exported tables to."
+	exportFormatUsage    = "Options: parquet, jsonl."
+	exportChunkSizeUsage = "Number of blocks per file."
+	exportWorkersUsage   = "Maximum number of files to export concurrently."
+	exportRemoteDBUsage  = "gRPC URL of a running Juno node to export from, its database can't be opened while the node runs."
+)
+
+// Export returns a command that writes the stored chain data to Parquet or JSONL files.
+func Export() *cobra.Command {
+	var (
+		dbPath, remoteDB string
+		from, to         uint64
+		output           string
+		chunkSize        uint64
+		workers          int
+	)
+	format := export.Parquet
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export blocks, transactions, receipts, events, state diffs and L1 messages to files.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var toBlock *uint64
+			if cmd.Flags().Changed(exportToF) {
+				toBlock = &to
+			}
+			return runExport(cmd.Context(), dbPath, remoteDB, from, toBlock, output, format, chunkSize, workers)
+		},
+	}
+
+	exportCmd.Flags().StringVar(&dbPath, dbPathF, "", dbPathUsage)
+	exportCmd.Flags().StringVar(&remoteDB, remoteDBF, "", exportRemoteDBUsage)
+	exportCmd.Flags().Uint64Var(&from, exportFromF, 0, exportFromUsage)
+	exportCmd.Flags().Uint64Var(&to, exportToF, 0, exportToUsage)
+	exportCmd.Flags().StringVar(&output, exportOutputF, "", exportOutputUsage)
+	exportCmd.Flags().Var(&format, exportFormatF, exportFormatUsage)
+	exportCmd.Flags().Uint64Var(&chunkSize, exportChunkSizeF, export.DefaultChunkSize, exportChunkSizeUsage)
+	exportCmd.Flags().IntVar(&workers, exportWorkersF, runtime.GOMAXPROCS(0), exportWorkersUsage)
+	exportCmd.MarkFlagRequired(exportOutputF) //nolint:errcheck
+
+	return exportCmd
+}
+
+func runExport(ctx context.Context, dbPath, remoteDB string, from uint64, to *uint64, output string, format export.Format,
+	chunkSize uint64, workers int,
+) error {
+	log, err := utils.NewZapLogger(utils.INFO, false)
+	if err != nil {
+		return err
+	}
+
+	database, err := openDB(ctx, dbPath, remoteDB, false, log)
+	if err != nil {
+		return err
+	}
+
+	exporter := export.New(database, output, format, log).WithChunkSize(chunkSize).WithWorkers(workers)
+	if to == nil {
+		var height uint64
+		if height, err = exporter.Height(); err != nil {
+			return errors.Join(fmt.Errorf("get chain height: %w", err), database.Close())
+		}
+		to = &height
+	}
+
+	log.Infow("Exporting blocks", "from", from, "to", *to, "format", format, "output", output)
+	return errors.Join(exporter.Run(ctx, from, *to), database.Close())
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/migration"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	inspectBlockF = "block"
+
+	inspectBlockUsage = "Block to read the state at: a number, a hash, latest or pending."
+)
+
+// inspector reads the database through the same code paths as the RPC endpoints, so the JSON it prints has the
+// format of the matching starknet_* responses
+type inspector struct {
+	database db.DB
+	chain    *blockchain.Blockchain
+	handler  *rpc.Handler
+}
+
+type inspectFunc func(i *inspector, args []string) (any, error)
+
+// Inspect returns the commands that print the data stored in a database as JSON without starting a node. The
+// database is opened read-only, it has to be a copy if the node that owns it is running.
+func Inspect() *cobra.Command {
+	var dbPath string
+	network := utils.Mainnet
+
+	inspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Print the blocks, transactions, state and metadata stored in the database as JSON.",
+	}
+	inspectCmd.PersistentFlags().StringVar(&dbPath, dbPathF, "", dbPathUsage)
+	inspectCmd.PersistentFlags().Var(&network, networkF, networkUsage)
+	inspectCmd.MarkPersistentFlagRequired(dbPathF) //nolint:errcheck
+
+	command := func(use, short string, args cobra.PositionalArgs, fn inspectFunc) *cobra.Command {
+		return &cobra.Command{
+			Use:   use,
+			Short: short,
+			Args:  args,
+			RunE: func(_ *cobra.Command, args []string) error {
+				return runInspect(dbPath, &network, args, fn)
+			},
+		}
+	}
+	// atBlock adds the flag that selects the block the state is read at
+	atBlock := func(cmd *cobra.Command, block *string) *cobra.Command {
+		cmd.Flags().StringVar(block, inspectBlockF, "latest", inspectBlockUsage)
+		return cmd
+	}
+
+	var classBlock, storageBlock, nonceBlock, classHashBlock string
+	inspectCmd.AddCommand(
+		command("block <number|hash|latest|pending>", "Print a block with its transactions and receipts.",
+			cobra.ExactArgs(1), inspectBlock),
+		command("transaction <hash>", "Print a transaction and its receipt.", cobra.ExactArgs(1), inspectTransaction),
+		command("state-update <number|hash|latest|pending>", "Print the state update of a block.", cobra.ExactArgs(1),
+			inspectStateUpdate),
+		atBlock(command("class <class hash>", "Print a class.", cobra.ExactArgs(1),
+			func(i *inspector, args []string) (any, error) { return inspectClass(i, args, classBlock) }), &classBlock),
+		atBlock(command("storage <address> <key>", "Print the value of a storage slot of a contract.", cobra.ExactArgs(2),
+			func(i *inspector, args []string) (any, error) { return inspectStorage(i, args, storageBlock) }), &storageBlock),
+		atBlock(command("nonce <address>", "Print the nonce of a contract.", cobra.ExactArgs(1),
+			func(i *inspector, args []string) (any, error) { return inspectNonce(i, args, nonceBlock) }), &nonceBlock),
+		atBlock(command("class-hash <address>", "Print the class hash of a contract.", cobra.ExactArgs(1),
+			func(i *inspector, args []string) (any, error) { return inspectClassHash(i, args, classHashBlock) }),
+			&classHashBlock),
+		command("pending", "Print the pending block and its state update.", cobra.NoArgs, inspectPending),
+		command("metadata", "Print the chain height, the L1 head and the schema of the database.", cobra.NoArgs,
+			inspectMetadata),
+	)
+	return inspectCmd
+}
+
+func runInspect(dbPath string, network *utils.Network, args []string, fn inspectFunc) error {
+	log, err := utils.NewZapLogger(utils.ERROR, false)
+	if err != nil {
+		return err
+	}
+	database, err := pebble.NewReadOnly(dbPath, defaultCacheSizeMb, defaultMaxHandles, log)
+	if err != nil {
+		return fmt.Errorf("open DB: %w", err)
+	}
+	defer database.Close()
+
+	chain := blockchain.New(database, network)
+	result, err := fn(&inspector{
+		database: database,
+		chain:    chain,
+		handler:  rpc.New(chain, &sync.NoopSynchronizer{}, nil, Version, network, log),
+	}, args)
+	if err != nil {
+		return err
+	}
+	return writeReport("", result)
+}
+
+func inspectBlock(i *inspector, args []string) (any, error) {
+	id, err := parseBlockID(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return rpcResult(i.handler.BlockWithReceipts(id))
+}
+
+func inspectTransaction(i *inspector, args []string) (any, error) {
+	hash, err := parseFelt("transaction hash", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, rpcErr := i.handler.TransactionByHash(*hash)
+	if rpcErr != nil {
+		return nil, errors.New(rpcErr.Message)
+	}
+	receipt, rpcErr := i.handler.TransactionReceiptByHash(*hash)
+	if rpcErr != nil {
+		return nil, errors.New(rpcErr.Message)
+	}
+	return struct {
+		Transaction *rpc.Transaction        `json:"transaction"`
+		Receipt     *rpc.TransactionReceipt `json:"receipt"`
+	}{transaction, receipt}, nil
+}
+
+func inspectStateUpdate(i *inspector, args []string) (any, error) {
+	id, err := parseBlockID(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return rpcResult(i.handler.StateUpdate(id))
+}
+
+func inspectClass(i *inspector, args []string, block string) (any, error) {
+	id, err := parseBlockID(block)
+	if err != nil {
+		return nil, err
+	}
+	classHash, err := parseFelt("class hash", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return rpcResult(i.handler.Class(id, *classHash))
+}
+
+func inspectStorage(i *inspector, args []string, block string) (any, error) {
+	id, err := parseBlockID(block)
+	if err != nil {
+		return nil, err
+	}
+	address, err := parseFelt("address", args[0])
+	if err != nil {
+		return nil, err
+	}
+	key, err := parseFelt("key", args[1])
+	if err != nil {
+		return nil, err
+	}
+	return rpcResult(i.handler.StorageAt(*address, *key, id))
+}
+
+func inspectNonce(i *inspector, args []string, block string) (any, error) {
+	id, err := parseBlockID(block)
+	if err != nil {
+		return nil, err
+	}
+	address, err := parseFelt("address", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return rpcResult(i.handler.Nonce(id, *address))
+}
+
+func inspectClassHash(i *inspector, args []string, block string) (any, error) {
+	id, err := parseBlockID(block)
+	if err != nil {
+		return nil, err
+	}
+	address, err := parseFelt("address", args[0])
+	if err != nil {
+		return nil, err
+	}
+	return rpcResult(i.handler.ClassHashAt(id, *address))
+}
+
+func inspectPending(i *inspector, _ []string) (any, error) {
+	pending := rpc.BlockID{Pending: true}
+	block, rpcErr := i.handler.BlockWithReceipts(pending)
+	if rpcErr != nil {
+		return nil, errors.New(rpcErr.Message)
+	}
+	stateUpdate, rpcErr := i.handler.StateUpdate(pending)
+	if rpcErr != nil {
+		return nil, errors.New(rpcErr.Message)
+	}
+	return struct {
+		Block       *rpc.BlockWithReceipts `json:"block"`
+		StateUpdate *rpc.StateUpdate       `json:"state_update"`
+	}{block, stateUpdate}, nil
+}
+
+type l1Head struct {
+	BlockNumber uint64     `json:"block_number"`
+	BlockHash   *felt.Felt `json:"block_hash"`
+	StateRoot   *felt.Felt `json:"state_root"`
+}
+
+type metadata struct {
+	Network             string  `json:"network"`
+	Height              *uint64 `json:"height"`
+	L1Head              *l1Head `json:"l1_head"`
+	SchemaVersion       uint64  `json:"schema_version"`
+	MigrationInProgress bool    `json:"migration_in_progress"`
+}
+
+// inspectMetadata reports missing values as null, a database that is still syncing may not have them yet
+func inspectMetadata(i *inspector, _ []string) (any, error) {
+	result := metadata{Network: i.chain.Network().String()}
+
+	height, err := i.chain.Height()
+	if err == nil {
+		result.Height = &height
+	} else if !errors.Is(err, db.ErrKeyNotFound) {
+		return nil, err
+	}
+
+	head, err := i.chain.L1Head()
+	if err == nil {
+		result.L1Head = &l1Head{BlockNumber: head.BlockNumber, BlockHash: head.BlockHash, StateRoot: head.StateRoot}
+	} else if !errors.Is(err, db.ErrKeyNotFound) {
+		return nil, err
+	}
+
+	schema, err := migration.SchemaMetadata(i.database)
+	if err != nil {
+		return nil, err
+	}
+	result.SchemaVersion = schema.Version
+	result.MigrationInProgress = len(schema.IntermediateState) > 0
+	return result, nil
+}
+
+func rpcResult[T any](result T, rpcErr *jsonrpc.Error) (any, error) {
+	if rpcErr != nil {
+		return nil, errors.New(rpcErr.Message)
+	}
+	return result, nil
+}
+
+// parseBlockID accepts a block number, a block hash, latest or pending
+func parseBlockID(id string) (rpc.BlockID, error) {
+	switch {
+	case id == "latest":
+		return rpc.BlockID{Latest: true}, nil
+	case id == "pending":
+		return rpc.BlockID{Pending: true}, nil
+	case strings.HasPrefix(id, "0x"):
+		hash, err := parseFelt("block hash", id)
+		if err != nil {
+			return rpc.BlockID{}, err
+		}
+		return rpc.BlockID{Hash: hash}, nil
+	default:
+		number, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return rpc.BlockID{}, fmt.Errorf("invalid block %q: expected a number, a hash, latest or pending", id)
+		}
+		return rpc.BlockID{Number: number}, nil
+	}
+}
+
+func parseFelt(name, value string) (*felt.Felt, error) {
+	f, err := new(felt.Felt).SetString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return f, nil
+}
@@ -46,6 +46,13 @@ const (
 	wsF                    = "ws"
 	wsHostF                = "ws-host"
 	wsPortF                = "ws-port"
+	ipcPathF               = "ipc-path"
+	graphQLPathF           = "graphql-path"
+	graphQLMaxDepthF       = "graphql-max-depth"
+	graphQLMaxComplexityF  = "graphql-max-complexity"
+	feederGatewayPathF     = "feeder-gateway-path"
+	readyMaxBlocksBehindF  = "ready-max-blocks-behind"
+	readyMaxHeadAgeF       = "ready-max-head-age"
 	dbPathF                = "db-path"
 	networkF               = "network"
 	ethNodeF               = "eth-node"
@@ -54,6 +61,7 @@ const (
 	pprofPortF             = "pprof-port"
 	colourF                = "colour"
 	pendingPollIntervalF   = "pending-poll-interval"
+	maxReorgDepthF         = "max-reorg-depth"
 	p2pF                   = "p2p"
 	p2pAddrF               = "p2p-addr"
 	p2pPeersF              = "p2p-peers"
@@ -62,12 +70,19 @@ const (
 	metricsF               = "metrics"
 	metricsHostF           = "metrics-host"
 	metricsPortF           = "metrics-port"
+	traceExporterF         = "trace-exporter"
+	traceOTLPEndpointF     = "trace-otlp-endpoint"
+	traceFileF             = "trace-file"
+	traceDBSampleRatioF    = "trace-db-sample-ratio"
 	grpcF                  = "grpc"
 	grpcHostF              = "grpc-host"
 	grpcPortF              = "grpc-port"
 	maxVMsF                = "max-vms"
 	maxVMQueueF            = "max-vm-queue"
 	remoteDBF              = "remote-db"
+	archiveF               = "archive"
+	webhooksFileF          = "webhooks-file"
+	indexTransfersF        = "index-transfers"
 	rpcMaxBlockScanF       = "rpc-max-block-scan"
 	dbCacheSizeF           = "db-cache-size"
 	dbMaxHandlesF          = "db-max-handles"
@@ -82,6 +97,18 @@ const (
 	cnUnverifiableRangeF   = "cn-unverifiable-range"
 	callMaxStepsF          = "rpc-call-max-steps"
 	corsEnableF            = "rpc-cors-enable"
+	traceStoreSizeF        = "rpc-trace-store-size"
+	traceStoreEagerF       = "rpc-trace-store-eager"
+	responseCacheSizeF     = "rpc-response-cache-size"
+	rateLimitF             = "rpc-rate-limit"
+	rateLimitBurstF        = "rpc-rate-limit-burst"
+	apiKeysFileF           = "rpc-api-keys-file"
+	jwtSecretF             = "rpc-jwt-secret"
+	adminF                 = "admin"
+	adminHostF             = "admin-host"
+	adminPortF             = "admin-port"
+	adminAPIKeysFileF      = "admin-api-keys-file"
+	adminJWTSecretF        = "admin-jwt-secret"
 
 	defaultConfig                   = ""
 	defaulHost                      = "localhost"
@@ -89,11 +116,19 @@ const (
 	defaultHTTPPort                 = 6060
 	defaultWS                       = false
 	defaultWSPort                   = 6061
+	defaultIPCPath                  = ""
+	defaultGraphQLPath              = ""
+	defaultGraphQLMaxDepth          = 0
+	defaultGraphQLMaxComplexity     = 0
+	defaultFeederGatewayPath        = ""
+	defaultReadyMaxBlocksBehind     = 0
+	defaultReadyMaxHeadAge          = 0
 	defaultEthNode                  = ""
 	defaultPprof                    = false
 	defaultPprofPort                = 6062
 	defaultColour                   = true
 	defaultPendingPollInterval      = 5 * time.Second
+	defaultMaxReorgDepth            = 0
 	defaultP2p                      = false
 	defaultP2pAddr                  = ""
 	defaultP2pPeers                 = ""
@@ -101,9 +136,16 @@ const (
 	defaultP2pPrivateKey            = ""
 	defaultMetrics                  = false
 	defaultMetricsPort              = 9090
+	defaultTraceExporter            = ""
+	defaultTraceOTLPEndpoint        = ""
+	defaultTraceFile                = ""
+	defaultTraceDBSampleRatio       = 0
 	defaultGRPC                     = false
 	defaultGRPCPort                 = 6064
 	defaultRemoteDB                 = ""
+	defaultArchive                  = ""
+	defaultWebhooksFile             = ""
+	defaultIndexTransfers           = false
 	defaultRPCMaxBlockScan          = math.MaxUint
 	defaultCacheSizeMb              = 8
 	defaultMaxHandles               = 1024
@@ -117,6 +159,17 @@ const (
 	defaultCallMaxSteps             = 4_000_000
 	defaultGwTimeout                = 5 * time.Second
 	defaultCorsEnable               = false
+	defaultTraceStoreSize           = 0
+	defaultTraceStoreEager          = false
+	defaultResponseCacheSize        = 0
+	defaultRateLimit                = 0
+	defaultRateLimitBurst           = 0
+	defaultAPIKeysFile              = ""
+	defaultJWTSecret                = ""
+	defaultAdmin                    = false
+	defaultAdminPort                = 6063
+	defaultAdminAPIKeysFile         = ""
+	defaultAdminJWTSecret           = ""
 
 	configFlagUsage                       = "The yaml configuration file."
 	logLevelFlagUsage                     = "Options: debug, info, warn, error."
@@ -126,6 +179,17 @@ const (
 	wsUsage                               = "Enables the Websocket RPC server on the default port."
 	wsHostUsage                           = "The interface on which the Websocket RPC server will listen for requests."
 	wsPortUsage                           = "The port on which the websocket server will listen for requests."
+	ipcPathUsage                          = "Unix socket for the latest RPC version, accessible to the user running Juno only."
+	graphQLPathUsage                      = "Path of the GraphQL endpoint on the HTTP server, e.g. /graphql. Disabled if empty."
+	graphQLMaxDepthUsage                  = "Maximum nesting depth of GraphQL queries. 0 uses the default of 12."
+	graphQLMaxComplexityUsage             = "Maximum number of database reads of a GraphQL query. 0 uses the default of 10000."
+	feederGatewayPathUsage                = "Path of the feeder gateway API on the HTTP server, e.g. /feeder_gateway. Disabled if empty."
+	readyMaxBlocksBehindUsage             = "Maximum blocks behind the highest known block for /ready to pass. 0 uses the default of 10."
+	readyMaxHeadAgeUsage                  = "Maximum age of the head block for /ready to pass. 0 uses the default of 30m."
+	traceExporterUsage                    = "Exporter of OpenTelemetry traces. Options: otlp, stdout, file. Tracing is disabled if empty."
+	traceOTLPEndpointUsage                = "OTLP/HTTP collector URL, e.g. http://localhost:4318. Falls back to OTEL_EXPORTER_OTLP_ENDPOINT."
+	traceFileUsage                        = "File the traces are appended to when using the file exporter."
+	traceDBSampleRatioUsage               = "Fraction of database transactions to trace, between 0 and 1."
 	dbPathUsage                           = "Location of the database files."
 	networkUsage                          = "Options: mainnet, sepolia, sepolia-integration."
 	networkCustomName                     = "Custom network name."
@@ -142,6 +206,7 @@ const (
 	ethNodeUsage                          = "Websocket endpoint of the Ethereum node. In order to verify the correctness of the L2 chain, " +
 		"Juno must connect to an Ethereum node and parse events in the Starknet contract."
 	pendingPollIntervalUsage = "Sets how frequently pending block will be updated (0s will disable fetching of pending block)."
+	maxReorgDepthUsage       = "Maximum number of blocks reverted on a reorg, deeper reorgs stop the node. 0 uses the default of 1000."
 	p2pUsage                 = "EXPERIMENTAL: Enables p2p server."
 	p2pAddrUsage             = "EXPERIMENTAL: Specify p2p source address as multiaddr."
 	p2pPeersUsage            = "EXPERIMENTAL: Specify list of p2p peers split by a comma. " +
@@ -158,13 +223,40 @@ const (
 	maxVMsUsage          = "Maximum number for VM instances to be used for RPC calls concurrently"
 	maxVMQueueUsage      = "Maximum number for requests to queue after reaching max-vms before starting to reject incoming requets"
 	remoteDBUsage        = "gRPC URL of a remote Juno node"
-	rpcMaxBlockScanUsage = "Maximum number of blocks scanned in single starknet_getEvents call"
+	archiveUsage         = "Directory or zip file of blocks to sync from instead of the feeder gateway, see juno archive download."
+	webhooksFileUsage    = "YAML or JSON file listing the URLs to POST new blocks, events and reorgs to."
+	indexTransfersUsage  = "Index the ERC20 and ERC721 transfers of new blocks for juno_getTransfers, blocks synced before aren't indexed."
+	rpcMaxBlockScanUsage = "Max blocks scanned per starknet_getEvents call and transactions skipped in juno_getAccountTransactions"
 	dbCacheSizeUsage     = "Determines the amount of memory (in megabytes) allocated for caching data in the database."
 	dbMaxHandlesUsage    = "A soft limit on the number of open files that can be used by the DB"
 	gwAPIKeyUsage        = "API key for gateway endpoints to avoid throttling" //nolint: gosec
 	gwTimeoutUsage       = "Timeout for requests made to the gateway"          //nolint: gosec
 	callMaxStepsUsage    = "Maximum number of steps to be executed in starknet_call requests"
 	corsEnableUsage      = "Enable CORS on RPC endpoints"
+	traceStoreSizeUsage  = "Maximum size (in megabytes) of the block traces persisted in the database. " +
+		"0 disables the trace store."
+	traceStoreEagerUsage = "Trace new blocks as soon as they are synced instead of on the first request. " +
+		"Requires --rpc-trace-store-size to be set."
+	responseCacheSizeUsage = "Maximum number of responses to block scoped queries, such as blocks, receipts, state updates " +
+		"and traces of blocks below the head, cached per RPC version. 0 disables the response cache."
+	rateLimitUsage = "Number of requests per second each client can make over HTTP and websocket, clients are identified " +
+		"by their authenticated identity or IP address. Expensive methods, such as traces, count as several requests. " +
+		"0 disables rate limiting."
+	rateLimitBurstUsage = "Maximum number of requests a client can make at once. " +
+		"If 0, it is the rate limit or the cost of the most expensive method, whichever is larger."
+	apiKeysFileUsage = "YAML or JSON file with a list of API keys, each with a name, a key and the method namespaces it " +
+		"can call (e.g. starknet, juno, graphql, feedergateway or * for all). Clients pass the key in the X-Api-Key header. " +
+		"Keys can also be listed under rpc-api-keys in the config file. Enables authentication on the RPC endpoints " +
+		"and the other APIs of the HTTP server, except /health and /ready."
+	jwtSecretUsage = "Hex encoded secret of the HS256 JSON Web Tokens accepted in the Authorization header. Tokens need " +
+		"an exp claim and list the namespaces they can call in a namespaces claim. " +
+		"Enables authentication on the RPC endpoints and the other APIs of the HTTP server, except /health and /ready."
+	adminUsage = "Enables the juno_admin RPC namespace on its own HTTP listener, on the default port. " +
+		"Requires --admin-api-keys-file or --admin-jwt-secret."
+	adminHostUsage        = "The interface on which the admin RPC server will listen for requests."
+	adminPortUsage        = "The port on which the admin RPC server will listen for requests."
+	adminAPIKeysFileUsage = "File with the API keys of the admin RPC, in the format of --rpc-api-keys-file. Keys need the juno namespace."
+	adminJWTSecretUsage   = "Hex encoded secret of the JSON Web Tokens accepted by the admin RPC. Tokens need the juno namespace."
 )
 
 var Version string
@@ -306,6 +398,13 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 	junoCmd.Flags().Bool(wsF, defaultWS, wsUsage)
 	junoCmd.Flags().String(wsHostF, defaulHost, wsHostUsage)
 	junoCmd.Flags().Uint16(wsPortF, defaultWSPort, wsPortUsage)
+	junoCmd.Flags().String(ipcPathF, defaultIPCPath, ipcPathUsage)
+	junoCmd.Flags().String(graphQLPathF, defaultGraphQLPath, graphQLPathUsage)
+	junoCmd.Flags().Uint(graphQLMaxDepthF, defaultGraphQLMaxDepth, graphQLMaxDepthUsage)
+	junoCmd.Flags().Uint(graphQLMaxComplexityF, defaultGraphQLMaxComplexity, graphQLMaxComplexityUsage)
+	junoCmd.Flags().String(feederGatewayPathF, defaultFeederGatewayPath, feederGatewayPathUsage)
+	junoCmd.Flags().Uint(readyMaxBlocksBehindF, defaultReadyMaxBlocksBehind, readyMaxBlocksBehindUsage)
+	junoCmd.Flags().Duration(readyMaxHeadAgeF, defaultReadyMaxHeadAge, readyMaxHeadAgeUsage)
 	junoCmd.Flags().String(dbPathF, defaultDBPath, dbPathUsage)
 	junoCmd.Flags().Var(&defaultNetwork, networkF, networkUsage)
 	junoCmd.Flags().String(cnNameF, defaultCNName, networkCustomName)
@@ -321,6 +420,7 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 	junoCmd.Flags().Uint16(pprofPortF, defaultPprofPort, pprofPortUsage)
 	junoCmd.Flags().Bool(colourF, defaultColour, colourUsage)
 	junoCmd.Flags().Duration(pendingPollIntervalF, defaultPendingPollInterval, pendingPollIntervalUsage)
+	junoCmd.Flags().Uint(maxReorgDepthF, defaultMaxReorgDepth, maxReorgDepthUsage)
 	junoCmd.Flags().Bool(p2pF, defaultP2p, p2pUsage)
 	junoCmd.Flags().String(p2pAddrF, defaultP2pAddr, p2pAddrUsage)
 	junoCmd.Flags().String(p2pPeersF, defaultP2pPeers, p2pPeersUsage)
@@ -329,12 +429,19 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 	junoCmd.Flags().Bool(metricsF, defaultMetrics, metricsUsage)
 	junoCmd.Flags().String(metricsHostF, defaulHost, metricsHostUsage)
 	junoCmd.Flags().Uint16(metricsPortF, defaultMetricsPort, metricsPortUsage)
+	junoCmd.Flags().String(traceExporterF, defaultTraceExporter, traceExporterUsage)
+	junoCmd.Flags().String(traceOTLPEndpointF, defaultTraceOTLPEndpoint, traceOTLPEndpointUsage)
+	junoCmd.Flags().String(traceFileF, defaultTraceFile, traceFileUsage)
+	junoCmd.Flags().Float64(traceDBSampleRatioF, defaultTraceDBSampleRatio, traceDBSampleRatioUsage)
 	junoCmd.Flags().Bool(grpcF, defaultGRPC, grpcUsage)
 	junoCmd.Flags().String(grpcHostF, defaulHost, grpcHostUsage)
 	junoCmd.Flags().Uint16(grpcPortF, defaultGRPCPort, grpcPortUsage)
 	junoCmd.Flags().Uint(maxVMsF, uint(defaultMaxVMs), maxVMsUsage)
 	junoCmd.Flags().Uint(maxVMQueueF, 2*uint(defaultMaxVMs), maxVMQueueUsage)
 	junoCmd.Flags().String(remoteDBF, defaultRemoteDB, remoteDBUsage)
+	junoCmd.Flags().String(archiveF, defaultArchive, archiveUsage)
+	junoCmd.Flags().String(webhooksFileF, defaultWebhooksFile, webhooksFileUsage)
+	junoCmd.Flags().Bool(indexTransfersF, defaultIndexTransfers, indexTransfersUsage)
 	junoCmd.Flags().Uint(rpcMaxBlockScanF, defaultRPCMaxBlockScan, rpcMaxBlockScanUsage)
 	junoCmd.Flags().Uint(dbCacheSizeF, defaultCacheSizeMb, dbCacheSizeUsage)
 	junoCmd.Flags().String(gwAPIKeyF, defaultGwAPIKey, gwAPIKeyUsage)
@@ -344,8 +451,20 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 	junoCmd.Flags().Uint(callMaxStepsF, defaultCallMaxSteps, callMaxStepsUsage)
 	junoCmd.Flags().Duration(gwTimeoutF, defaultGwTimeout, gwTimeoutUsage)
 	junoCmd.Flags().Bool(corsEnableF, defaultCorsEnable, corsEnableUsage)
+	junoCmd.Flags().Uint(traceStoreSizeF, defaultTraceStoreSize, traceStoreSizeUsage)
+	junoCmd.Flags().Bool(traceStoreEagerF, defaultTraceStoreEager, traceStoreEagerUsage)
+	junoCmd.Flags().Uint(responseCacheSizeF, defaultResponseCacheSize, responseCacheSizeUsage)
+	junoCmd.Flags().Float64(rateLimitF, defaultRateLimit, rateLimitUsage)
+	junoCmd.Flags().Uint(rateLimitBurstF, defaultRateLimitBurst, rateLimitBurstUsage)
+	junoCmd.Flags().String(apiKeysFileF, defaultAPIKeysFile, apiKeysFileUsage)
+	junoCmd.Flags().String(jwtSecretF, defaultJWTSecret, jwtSecretUsage)
+	junoCmd.Flags().Bool(adminF, defaultAdmin, adminUsage)
+	junoCmd.Flags().String(adminHostF, defaulHost, adminHostUsage)
+	junoCmd.Flags().Uint16(adminPortF, defaultAdminPort, adminPortUsage)
+	junoCmd.Flags().String(adminAPIKeysFileF, defaultAdminAPIKeysFile, adminAPIKeysFileUsage)
+	junoCmd.Flags().String(adminJWTSecretF, defaultAdminJWTSecret, adminJWTSecretUsage)
 	junoCmd.MarkFlagsMutuallyExclusive(p2pFeederNodeF, p2pPeersF)
-	junoCmd.AddCommand(GenP2PKeyPair())
+	junoCmd.AddCommand(GenP2PKeyPair(), Replay(), Archive(), Export(), DB(), Inspect())
 
 	return junoCmd
 }
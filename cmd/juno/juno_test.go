@@ -57,6 +57,7 @@ func TestConfigPrecedence(t *testing.T) {
 	defaultMetricsPort := uint16(9090)
 	defaultGRPC := false
 	defaultGRPCPort := uint16(6064)
+	defaultAdminPort := uint16(6063)
 	defaultColour := true
 	defaultPendingPollInterval := 5 * time.Second
 	defaultMaxVMs := uint(3 * runtime.GOMAXPROCS(0))
@@ -97,6 +98,8 @@ func TestConfigPrecedence(t *testing.T) {
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/.juno",
 				Network:             defaultCustomNetwork,
 				Pprof:               true,
@@ -142,6 +145,8 @@ cn-unverifiable-range: [0,10]
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/.juno",
 				Network:             defaultCustomNetwork,
 				Pprof:               true,
@@ -179,6 +184,8 @@ cn-unverifiable-range: [0,10]
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				Colour:              defaultColour,
 				PendingPollInterval: defaultPendingPollInterval,
 				MaxVMs:              defaultMaxVMs,
@@ -206,6 +213,8 @@ cn-unverifiable-range: [0,10]
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        defaultDBPath,
 				Network:             defaultNetwork,
 				Pprof:               defaultPprof,
@@ -243,6 +252,8 @@ cn-unverifiable-range: [0,10]
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				Network:             defaultNetwork,
 				Colour:              defaultColour,
 				PendingPollInterval: defaultPendingPollInterval,
@@ -282,6 +293,8 @@ pprof: true
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/.juno",
 				Network:             utils.Sepolia,
 				Pprof:               true,
@@ -318,6 +331,8 @@ http-port: 4576
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        defaultDBPath,
 				Network:             defaultNetwork,
 				Pprof:               defaultPprof,
@@ -353,6 +368,8 @@ http-port: 4576
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/.juno",
 				Network:             utils.SepoliaIntegration,
 				Pprof:               true,
@@ -388,6 +405,8 @@ http-port: 4576
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/.juno",
 				Network:             utils.Sepolia,
 				Pprof:               defaultPprof,
@@ -444,6 +463,8 @@ db-cache-size: 8
 				Metrics:             true,
 				MetricsHost:         "127.0.0.1",
 				MetricsPort:         4577,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				GRPC:                true,
 				GRPCHost:            "127.0.0.1",
 				GRPCPort:            4577,
@@ -485,6 +506,8 @@ network: sepolia
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/flag/.juno",
 				Network:             utils.Sepolia,
 				Pprof:               defaultPprof,
@@ -519,6 +542,8 @@ network: sepolia
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/flag/.juno",
 				Network:             utils.SepoliaIntegration,
 				Pprof:               true,
@@ -551,6 +576,8 @@ network: sepolia
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        defaultDBPath,
 				Network:             defaultNetwork,
 				Pprof:               defaultPprof,
@@ -584,6 +611,8 @@ network: sepolia
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/flag/.juno",
 				Network:             defaultNetwork,
 				Pprof:               defaultPprof,
@@ -617,6 +646,8 @@ network: sepolia
 				Metrics:             defaultMetrics,
 				MetricsHost:         defaultHost,
 				MetricsPort:         defaultMetricsPort,
+				AdminHost:           defaultHost,
+				AdminPort:           defaultAdminPort,
 				DatabasePath:        "/home/env/.juno",
 				Network:             defaultNetwork,
 				Pprof:               defaultPprof,
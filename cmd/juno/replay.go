@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/replay"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/spf13/cobra"
+)
+
+const (
+	replayFromF   = "from"
+	replayToF     = "to"
+	replayOutputF = "output"
+
+	replayFromUsage   = "First block to replay."
+	replayToUsage     = "Last block to replay."
+	replayOutputUsage = "File to write the mismatch report to. The report is written to stdout if empty."
+	replayMaxVMsUsage = "Maximum number of blocks to replay concurrently."
+)
+
+// Replay returns a command that re-executes stored blocks and verifies that the results match the stored
+// receipts and state updates.
+func Replay() *cobra.Command {
+	var (
+		dbPath   string
+		from, to uint64
+		maxVMs   uint
+		output   string
+	)
+	network := utils.Mainnet
+
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Re-execute stored blocks and compare the results with the database.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runReplay(cmd.Context(), dbPath, &network, from, to, maxVMs, output)
+		},
+	}
+
+	replayCmd.Flags().StringVar(&dbPath, dbPathF, "", dbPathUsage)
+	replayCmd.Flags().Var(&network, networkF, networkUsage)
+	replayCmd.Flags().Uint64Var(&from, replayFromF, 0, replayFromUsage)
+	replayCmd.Flags().Uint64Var(&to, replayToF, 0, replayToUsage)
+	replayCmd.Flags().UintVar(&maxVMs, maxVMsF, uint(runtime.GOMAXPROCS(0)), replayMaxVMsUsage)
+	replayCmd.Flags().StringVar(&output, replayOutputF, "", replayOutputUsage)
+	replayCmd.MarkFlagRequired(dbPathF)   //nolint:errcheck
+	replayCmd.MarkFlagRequired(replayToF) //nolint:errcheck
+
+	return replayCmd
+}
+
+func runReplay(ctx context.Context, dbPath string, network *utils.Network, from, to uint64, maxVMs uint, output string) error {
+	log, err := utils.NewZapLogger(utils.INFO, false)
+	if err != nil {
+		return err
+	}
+	dbLog, err := utils.NewZapLogger(utils.ERROR, false)
+	if err != nil {
+		return err
+	}
+
+	database, err := pebble.NewReadOnly(dbPath, defaultCacheSizeMb, defaultMaxHandles, dbLog)
+	if err != nil {
+		return fmt.Errorf("open DB: %w", err)
+	}
+	defer database.Close()
+
+	chain := blockchain.New(database, network)
+	report, err := replay.New(chain, vm.New(log), log).Run(ctx, from, to, int(maxVMs))
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if output != "" {
+		out, err = os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		return err
+	}
+
+	log.Infow("Replay finished", "matched", report.Matched, "mismatched", report.Mismatched,
+		"failed", report.Failed, "skipped", report.Skipped)
+	if !report.OK() {
+		return errors.New("replayed blocks do not match the database")
+	}
+	return nil
+}
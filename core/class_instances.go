@@ -0,0 +1,100 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/utils"
+)
+
+// ClassInstance is a contract that instantiates a class, or did so in the past if Replaced is set
+type ClassInstance struct {
+	Address    *felt.Felt
+	DeployedAt uint64
+	Replaced   bool
+}
+
+// IndexContractClass records that the contract at addr instantiates classHash from blockNumber on, either because it
+// was deployed or because its class was replaced in that block:
+//
+// [db.ContractsByClassHash](ClassHash, Address, BlockNumber) -> ()
+func IndexContractClass(txn db.Transaction, addr, classHash *felt.Felt, blockNumber uint64) error {
+	return txn.Set(contractsByClassHashKey(addr, classHash, blockNumber), nil)
+}
+
+func unindexContractClass(txn db.Transaction, addr, classHash *felt.Felt, blockNumber uint64) error {
+	return txn.Delete(contractsByClassHashKey(addr, classHash, blockNumber))
+}
+
+func contractsByClassHashKey(addr, classHash *felt.Felt, blockNumber uint64) []byte {
+	return db.ContractsByClassHash.Key(classHash.Marshal(), addr.Marshal(), MarshalBlockNumber(blockNumber))
+}
+
+// ContractsByClassHash returns up to limit contracts that instantiate classHash or did so in the past, ordered by
+// address and starting at the start address if it isn't nil. The address of the next contract is returned as well,
+// it is nil if there are no more contracts.
+func ContractsByClassHash(txn db.Transaction, classHash, start *felt.Felt, limit uint64) ([]*ClassInstance, *felt.Felt,
+	error,
+) {
+	it, err := txn.NewIterator()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prefix := db.ContractsByClassHash.Key(classHash.Marshal())
+	seek := prefix
+	if start != nil {
+		seek = db.ContractsByClassHash.Key(classHash.Marshal(), start.Marshal())
+	}
+
+	var (
+		instances []*ClassInstance
+		next      *felt.Felt
+	)
+	for it.Seek(seek); it.Valid(); it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		if len(key) != len(prefix)+felt.Bytes+8 {
+			return nil, nil, utils.RunAndWrapOnError(it.Close, errors.New("invalid contracts by class hash key"))
+		}
+
+		// a contract that instantiated the class more than once has an entry for each time
+		addr := new(felt.Felt).SetBytes(key[len(prefix) : len(prefix)+felt.Bytes])
+		if len(instances) > 0 && instances[len(instances)-1].Address.Equal(addr) {
+			continue
+		}
+		if uint64(len(instances)) == limit {
+			next = addr
+			break
+		}
+
+		instance, iErr := classInstance(txn, addr, classHash)
+		if iErr != nil {
+			return nil, nil, utils.RunAndWrapOnError(it.Close, iErr)
+		}
+		instances = append(instances, instance)
+	}
+	return instances, next, it.Close()
+}
+
+func classInstance(txn db.Transaction, addr, classHash *felt.Felt) (*ClassInstance, error) {
+	instance := &ClassInstance{Address: addr}
+	if err := txn.Get(db.ContractDeploymentHeight.Key(addr.Marshal()), func(val []byte) error {
+		instance.DeployedAt = binary.BigEndian.Uint64(val)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	currentClassHash, err := ContractClassHash(addr, txn)
+	if err != nil {
+		return nil, err
+	}
+	instance.Replaced = !currentClassHash.Equal(classHash)
+	return instance, nil
+}
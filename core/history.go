@@ -132,3 +132,114 @@ func (h *history) ContractClassHashAt(contractAddress *felt.Felt, height uint64)
 
 	return new(felt.Felt).SetBytes(value), nil
 }
+
+// ValueChange is a value that a state entry was set to at a block
+type ValueChange struct {
+	BlockNumber uint64
+	Value       *felt.Felt
+}
+
+// changes iterates the logs of key from block `from` to block `to` and returns up to limit changes, along with the
+// block of the next change if there are more. A log holds the value before the change, so the value of a change is the
+// old value of the next log, or the current value for the last change. Changes that aren't logged, such as the
+// deployment of a contract, are passed in `changes` and precede the logged ones.
+func (h *history) changes(key []byte, from, to, limit uint64, changes []*ValueChange, current func() (*felt.Felt, error)) (
+	[]*ValueChange, *uint64, error,
+) {
+	it, err := h.txn.NewIterator()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *uint64
+	for it.Seek(logDBKey(key, from)); it.Valid(); it.Next() {
+		seekedKey := it.Key()
+		if len(seekedKey) != len(key)+8 || !bytes.HasPrefix(seekedKey, key) {
+			break
+		}
+
+		if len(changes) > 0 {
+			val, itErr := it.Value()
+			if itErr != nil {
+				return nil, nil, utils.RunAndWrapOnError(it.Close, itErr)
+			}
+			changes[len(changes)-1].Value = new(felt.Felt).SetBytes(val)
+		}
+
+		height := binary.BigEndian.Uint64(seekedKey[len(key):])
+		if height > to {
+			break
+		} else if uint64(len(changes)) == limit {
+			next = &height
+			break
+		}
+		changes = append(changes, &ValueChange{BlockNumber: height})
+	}
+	if err = it.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(changes) > 0 && changes[len(changes)-1].Value == nil {
+		if changes[len(changes)-1].Value, err = current(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return changes, next, nil
+}
+
+func (h *history) contractDeploymentHeight(contractAddress *felt.Felt) (uint64, error) {
+	var deployedAt uint64
+	if err := h.txn.Get(db.ContractDeploymentHeight.Key(contractAddress.Marshal()), func(val []byte) error {
+		deployedAt = binary.BigEndian.Uint64(val)
+		return nil
+	}); err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return 0, ErrContractNotDeployed
+		}
+		return 0, err
+	}
+	return deployedAt, nil
+}
+
+// ContractStorageChanges returns the changes to a storage location of the given contract between the blocks `from`
+// and `to`, see [history.changes]
+func (h *history) ContractStorageChanges(contractAddress, storageLocation *felt.Felt, from, to, limit uint64) (
+	[]*ValueChange, *uint64, error,
+) {
+	if _, err := h.contractDeploymentHeight(contractAddress); err != nil {
+		return nil, nil, err
+	}
+	return h.changes(storageLogKey(contractAddress, storageLocation), from, to, limit, nil, func() (*felt.Felt, error) {
+		return ContractStorage(contractAddress, storageLocation, h.txn)
+	})
+}
+
+// ContractNonceChanges returns the changes to the nonce of the given contract between the blocks `from` and `to`
+func (h *history) ContractNonceChanges(contractAddress *felt.Felt, from, to, limit uint64) ([]*ValueChange, *uint64, error) {
+	if _, err := h.contractDeploymentHeight(contractAddress); err != nil {
+		return nil, nil, err
+	}
+	return h.changes(nonceLogKey(contractAddress), from, to, limit, nil, func() (*felt.Felt, error) {
+		return ContractNonce(contractAddress, h.txn)
+	})
+}
+
+// ContractClassHashChanges returns the class hashes that the given contract instantiated between the blocks `from`
+// and `to`, starting with the class it was deployed with
+func (h *history) ContractClassHashChanges(contractAddress *felt.Felt, from, to, limit uint64) ([]*ValueChange, *uint64,
+	error,
+) {
+	deployedAt, err := h.contractDeploymentHeight(contractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changes []*ValueChange
+	if from <= deployedAt && deployedAt <= to && limit > 0 {
+		changes = append(changes, &ValueChange{BlockNumber: deployedAt})
+		from = deployedAt + 1
+	}
+	return h.changes(classHashLogKey(contractAddress), from, to, limit, changes, func() (*felt.Felt, error) {
+		return ContractClassHash(contractAddress, h.txn)
+	})
+}
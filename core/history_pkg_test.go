@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -102,3 +103,69 @@ func TestHistory(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoryChanges(t *testing.T) {
+	testDB := pebble.NewMemTest(t)
+	txn, err := testDB.NewTransaction(true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, txn.Discard())
+	})
+
+	history := &history{txn: txn}
+	f := func(v uint64) *felt.Felt { return new(felt.Felt).SetUint64(v) }
+	contractAddress, location := f(123), f(456)
+
+	// the slot was set to 1 at height 2, to 3 at height 5 and to 7 at height 9
+	require.NoError(t, history.LogContractStorage(contractAddress, location, &felt.Zero, 2))
+	require.NoError(t, history.LogContractStorage(contractAddress, location, f(1), 5))
+	require.NoError(t, history.LogContractStorage(contractAddress, location, f(3), 9))
+	key := storageLogKey(contractAddress, location)
+	current := func() (*felt.Felt, error) { return f(7), nil }
+
+	t.Run("all changes", func(t *testing.T) {
+		changes, next, err := history.changes(key, 0, 100, 10, nil, current)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, []*ValueChange{{2, f(1)}, {5, f(3)}, {9, f(7)}}, changes)
+	})
+
+	t.Run("block range", func(t *testing.T) {
+		changes, next, err := history.changes(key, 3, 8, 10, nil, current)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, []*ValueChange{{5, f(3)}}, changes)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		changes, next, err := history.changes(key, 0, 100, 2, nil, current)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		assert.Equal(t, uint64(9), *next)
+		assert.Equal(t, []*ValueChange{{2, f(1)}, {5, f(3)}}, changes)
+
+		changes, next, err = history.changes(key, *next, 100, 2, nil, current)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, []*ValueChange{{9, f(7)}}, changes)
+	})
+
+	t.Run("class hash changes start at the deployment", func(t *testing.T) {
+		_, _, err := history.ContractClassHashChanges(contractAddress, 0, 100, 10)
+		require.ErrorIs(t, err, ErrContractNotDeployed)
+
+		require.NoError(t, txn.Set(db.ContractDeploymentHeight.Key(contractAddress.Marshal()), MarshalBlockNumber(1)))
+		require.NoError(t, txn.Set(db.ContractClassHash.Key(contractAddress.Marshal()), f(0xc2).Marshal()))
+		require.NoError(t, history.LogContractClassHash(contractAddress, f(0xc1), 4))
+
+		changes, next, err := history.ContractClassHashChanges(contractAddress, 0, 100, 1)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		assert.Equal(t, []*ValueChange{{1, f(0xc1)}}, changes)
+
+		changes, next, err = history.ContractClassHashChanges(contractAddress, *next, 100, 1)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, []*ValueChange{{4, f(0xc2)}}, changes)
+	})
+}
@@ -223,6 +223,9 @@ func (s *State) Update(blockNumber uint64, update *StateUpdate, declaredClasses
 		if err = s.putNewContract(stateTrie, &addr, classHash, blockNumber); err != nil {
 			return err
 		}
+		if err = IndexContractClass(s.txn, &addr, classHash, blockNumber); err != nil {
+			return err
+		}
 	}
 
 	if err = s.updateContracts(stateTrie, blockNumber, update.StateDiff, true); err != nil {
@@ -256,6 +259,9 @@ func (s *State) updateContracts(stateTrie *trie.Trie, blockNumber uint64, diff *
 			if err = s.LogContractClassHash(&addr, oldClassHash, blockNumber); err != nil {
 				return err
 			}
+			if err = IndexContractClass(s.txn, &addr, classHash, blockNumber); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -551,11 +557,20 @@ func (s *State) Revert(blockNumber uint64, update *StateUpdate) error {
 		return err
 	}
 
+	for addr, classHash := range update.StateDiff.ReplacedClasses {
+		if err = unindexContractClass(s.txn, &addr, classHash, blockNumber); err != nil {
+			return fmt.Errorf("unindex replaced class: %v", err)
+		}
+	}
+
 	// purge deployed contracts
-	for addr := range update.StateDiff.DeployedContracts {
+	for addr, classHash := range update.StateDiff.DeployedContracts {
 		if err = s.purgeContract(&addr); err != nil {
 			return fmt.Errorf("purge contract: %v", err)
 		}
+		if err = unindexContractClass(s.txn, &addr, classHash, blockNumber); err != nil {
+			return fmt.Errorf("unindex deployed contract: %v", err)
+		}
 	}
 
 	// purge noClassContracts
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/NethermindEth/juno/clients/feeder"
@@ -212,6 +213,89 @@ func TestContractClassHash(t *testing.T) {
 	})
 }
 
+func TestContractsByClassHash(t *testing.T) {
+	client := feeder.NewTestClient(t, &utils.Mainnet)
+	gw := adaptfeeder.New(client)
+
+	testDB := pebble.NewMemTest(t)
+	txn, err := testDB.NewTransaction(true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, txn.Discard())
+	})
+
+	state := core.NewState(txn)
+
+	su0, err := gw.StateUpdate(context.Background(), 0)
+	require.NoError(t, err)
+	su1, err := gw.StateUpdate(context.Background(), 1)
+	require.NoError(t, err)
+
+	require.NoError(t, state.Update(0, su0, nil))
+	require.NoError(t, state.Update(1, su1, nil))
+
+	classHash := su1.StateDiff.DeployedContracts[su1FirstDeployedAddress]
+	var want []*felt.Felt
+	for _, su := range []*core.StateUpdate{su0, su1} {
+		for addr, deployedClassHash := range su.StateDiff.DeployedContracts {
+			if deployedClassHash.Equal(classHash) {
+				want = append(want, new(felt.Felt).Set(&addr))
+			}
+		}
+	}
+	slices.SortFunc(want, func(a, b *felt.Felt) int { return a.Cmp(b) })
+	require.Greater(t, len(want), 1)
+
+	instances, next, err := core.ContractsByClassHash(txn, classHash, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, want[0], instances[0].Address)
+	assert.Equal(t, want[1], next)
+
+	replaceUpdate := &core.StateUpdate{
+		OldRoot:   su1.NewRoot,
+		BlockHash: utils.HexToFelt(t, "0xDEADBEEF"),
+		NewRoot:   utils.HexToFelt(t, "0x484ff378143158f9af55a1210b380853ae155dfdd8cd4c228f9ece918bb982b"),
+		StateDiff: &core.StateDiff{
+			ReplacedClasses: map[felt.Felt]*felt.Felt{
+				su1FirstDeployedAddress: utils.HexToFelt(t, "0x1337"),
+			},
+		},
+	}
+	require.NoError(t, state.Update(2, replaceUpdate, nil))
+
+	t.Run("replaced contracts are flagged", func(t *testing.T) {
+		instances, next, err = core.ContractsByClassHash(txn, classHash, nil, uint64(len(want)))
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		require.Len(t, instances, len(want))
+		for i, instance := range instances {
+			assert.Equal(t, want[i], instance.Address)
+			assert.Equal(t, instance.Address.Equal(&su1FirstDeployedAddress), instance.Replaced)
+		}
+
+		instances, _, err = core.ContractsByClassHash(txn, utils.HexToFelt(t, "0x1337"), nil, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []*core.ClassInstance{{Address: &su1FirstDeployedAddress, DeployedAt: 1}}, instances)
+	})
+
+	t.Run("revert removes the contracts", func(t *testing.T) {
+		require.NoError(t, state.Revert(2, replaceUpdate))
+		instances, _, err = core.ContractsByClassHash(txn, utils.HexToFelt(t, "0x1337"), nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, instances)
+
+		require.NoError(t, state.Revert(1, su1))
+		instances, _, err = core.ContractsByClassHash(txn, classHash, nil, 10)
+		require.NoError(t, err)
+		for _, instance := range instances {
+			_, deployedAt0 := su0.StateDiff.DeployedContracts[*instance.Address]
+			assert.True(t, deployedAt0)
+			assert.False(t, instance.Replaced)
+		}
+	})
+}
+
 func TestNonce(t *testing.T) {
 	testDB := pebble.NewMemTest(t)
 	txn, err := testDB.NewTransaction(true)
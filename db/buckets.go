@@ -1,6 +1,9 @@
 package db
 
-import "slices"
+import (
+	"fmt"
+	"slices"
+)
 
 type Bucket byte
 
@@ -32,8 +35,75 @@ const (
 	BlockCommitments
 	Temporary // used temporarily for migrations
 	SchemaIntermediateState
+	BlockTraces                     // maps block number and index to compressed transaction trace
+	WebhookCursors                  // maps webhook name to the blocks delivered to it most recently
+	TokenTransfers                  // maps block number, transaction index and event index to token transfer
+	TokenTransfersByAddress         // maps address, block number, transaction index and event index to nothing
+	TokenTransfersByAddressAndToken // maps address, token, block number, transaction index and event index to nothing
+	ContractsByClassHash            // maps class hash, contract address and the block it started to instantiate the class to nothing
+	TransactionsBySender            // maps sender address, nonce, block number and transaction index to nothing
+	TransferIndexRanges             // the ranges of blocks whose token transfers are indexed
+
+	numBuckets // not a bucket, new buckets go above
 )
 
+var bucketNames = [numBuckets]string{
+	"StateTrie",
+	"Unused",
+	"ContractClassHash",
+	"ContractStorage",
+	"Class",
+	"ContractNonce",
+	"ChainHeight",
+	"BlockHeaderNumbersByHash",
+	"BlockHeadersByNumber",
+	"TransactionBlockNumbersAndIndicesByHash",
+	"TransactionsByBlockNumberAndIndex",
+	"ReceiptsByBlockNumberAndIndex",
+	"StateUpdatesByBlockNumber",
+	"ClassesTrie",
+	"ContractStorageHistory",
+	"ContractNonceHistory",
+	"ContractClassHashHistory",
+	"ContractDeploymentHeight",
+	"L1Height",
+	"SchemaVersion",
+	"Pending",
+	"BlockCommitments",
+	"Temporary",
+	"SchemaIntermediateState",
+	"BlockTraces",
+	"WebhookCursors",
+	"TokenTransfers",
+	"TokenTransfersByAddress",
+	"TokenTransfersByAddressAndToken",
+	"ContractsByClassHash",
+	"TransactionsBySender",
+	"TransferIndexRanges",
+}
+
+// Buckets returns every bucket in the order of their prefixes
+func Buckets() []Bucket {
+	buckets := make([]Bucket, 0, numBuckets)
+	for b := range numBuckets {
+		buckets = append(buckets, b)
+	}
+	return buckets
+}
+
+// BucketByName returns the bucket with the given name, see Bucket.String
+func BucketByName(name string) (Bucket, bool) {
+	index := slices.Index(bucketNames[:], name)
+	return Bucket(index), index >= 0
+}
+
+func (b Bucket) String() string {
+	if b < numBuckets {
+		return bucketNames[b]
+	}
+	return fmt.Sprintf("Bucket(%d)", byte(b))
+}
+
 // Key flattens a prefix and series of byte arrays into a single []byte.
 func (b Bucket) Key(key ...[]byte) []byte {
 	return append([]byte{byte(b)}, slices.Concat(key...)...)
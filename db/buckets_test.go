@@ -28,3 +28,18 @@ func TestKey(t *testing.T) {
 		}
 	})
 }
+
+func TestBucketNames(t *testing.T) {
+	buckets := db.Buckets()
+	for i, bucket := range buckets {
+		assert.Equal(t, db.Bucket(i), bucket)
+		found, ok := db.BucketByName(bucket.String())
+		assert.True(t, ok, bucket)
+		assert.Equal(t, bucket, found)
+	}
+	assert.Equal(t, "BlockHeadersByNumber", db.BlockHeadersByNumber.String())
+	assert.Equal(t, "Bucket(255)", db.Bucket(255).String())
+
+	_, ok := db.BucketByName("Buckets")
+	assert.False(t, ok)
+}
@@ -1,6 +1,7 @@
 package pebble
 
 import (
+	"errors"
 	"sync"
 	"testing"
 
@@ -25,12 +26,24 @@ type DB struct {
 
 // New opens a new database at the given path
 func New(path string, cache uint, maxOpenFiles int, logger pebble.Logger) (db.DB, error) {
+	return open(path, cache, maxOpenFiles, logger, false)
+}
+
+// NewReadOnly opens the existing database at the given path without modifying its files, write transactions fail.
+// Pebble still locks the directory, so the database of a running node has to be copied first.
+func NewReadOnly(path string, cache uint, maxOpenFiles int, logger pebble.Logger) (db.DB, error) {
+	return open(path, cache, maxOpenFiles, logger, true)
+}
+
+func open(path string, cache uint, maxOpenFiles int, logger pebble.Logger, readOnly bool) (db.DB, error) {
 	// Ensure that the specified cache size meets a minimum threshold.
 	cache = max(minCache, cache)
 	pDB, err := newPebble(path, &pebble.Options{
-		Logger:       logger,
-		Cache:        pebble.NewCache(int64(cache * utils.Megabyte)),
-		MaxOpenFiles: maxOpenFiles,
+		Logger:           logger,
+		Cache:            pebble.NewCache(int64(cache * utils.Megabyte)),
+		MaxOpenFiles:     maxOpenFiles,
+		ReadOnly:         readOnly,
+		ErrorIfNotExists: readOnly,
 	})
 	if err != nil {
 		return nil, err
@@ -108,3 +121,22 @@ func (d *DB) Update(fn func(txn db.Transaction) error) error {
 func (d *DB) Impl() any {
 	return d.pebble
 }
+
+// Compact compacts the whole key space of database, which has to be backed by pebble
+func Compact(database db.DB) error {
+	// every key starts with the byte of its bucket
+	return compact(database, []byte{0}, []byte{0xff})
+}
+
+// CompactBucket compacts the keys of a single bucket of database, which has to be backed by pebble
+func CompactBucket(database db.DB, bucket db.Bucket) error {
+	return compact(database, bucket.Key(), []byte{byte(bucket) + 1})
+}
+
+func compact(database db.DB, start, end []byte) error {
+	pDB, ok := database.Impl().(*pebble.DB)
+	if !ok {
+		return errors.New("compaction is only supported on local databases")
+	}
+	return pDB.Compact(start, end, true)
+}
@@ -3,6 +3,7 @@ package pebble_test
 import (
 	"encoding/binary"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -417,3 +418,62 @@ func TestPanic(t *testing.T) {
 		}))
 	})
 }
+
+func TestCompact(t *testing.T) {
+	testDB := pebble.NewMemTest(t)
+	for i := byte(0); i < 10; i++ {
+		require.NoError(t, testDB.Update(func(txn db.Transaction) error {
+			return txn.Set(db.ContractNonce.Key([]byte{i}), []byte{i})
+		}))
+	}
+
+	require.NoError(t, pebble.Compact(testDB))
+	require.NoError(t, pebble.CompactBucket(testDB, db.ContractNonce))
+	require.NoError(t, testDB.View(func(txn db.Transaction) error {
+		return txn.Get(db.ContractNonce.Key([]byte{9}), func(val []byte) error {
+			assert.Equal(t, []byte{9}, val)
+			return nil
+		})
+	}))
+
+	t.Run("only local databases can be compacted", func(t *testing.T) {
+		require.Error(t, pebble.Compact(&nonPebbleDB{testDB}))
+		require.Error(t, pebble.CompactBucket(&nonPebbleDB{testDB}, db.ContractNonce))
+	})
+}
+
+type nonPebbleDB struct {
+	db.DB
+}
+
+func (d *nonPebbleDB) Impl() any {
+	return nil
+}
+
+func TestNewReadOnly(t *testing.T) {
+	path := t.TempDir()
+	_, err := pebble.NewReadOnly(filepath.Join(path, "missing"), 8, 10, nil)
+	require.Error(t, err)
+
+	testDB, err := pebble.New(path, 8, 10, nil)
+	require.NoError(t, err)
+	require.NoError(t, testDB.Update(func(txn db.Transaction) error {
+		return txn.Set([]byte{1}, []byte{2})
+	}))
+	require.NoError(t, testDB.Close())
+
+	readOnlyDB, err := pebble.NewReadOnly(path, 8, 10, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, readOnlyDB.Close())
+	})
+	require.NoError(t, readOnlyDB.View(func(txn db.Transaction) error {
+		return txn.Get([]byte{1}, func(val []byte) error {
+			assert.Equal(t, []byte{2}, val)
+			return nil
+		})
+	}))
+	require.Error(t, readOnlyDB.Update(func(txn db.Transaction) error {
+		return txn.Set([]byte{1}, []byte{3})
+	}))
+}
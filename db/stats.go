@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"encoding/hex"
+	"slices"
+
+	"github.com/NethermindEth/juno/utils"
+)
+
+// Entry is a key and the combined size of the key and its value
+type Entry struct {
+	Key  string `json:"key"`
+	Size uint64 `json:"size"`
+}
+
+type BucketStats struct {
+	Bucket     string  `json:"bucket"`
+	Keys       uint64  `json:"keys"`
+	KeyBytes   uint64  `json:"key_bytes"`
+	ValueBytes uint64  `json:"value_bytes"`
+	Largest    []Entry `json:"largest"`
+}
+
+// Stats summarises the keys of a database. Buckets holds every known bucket and the unknown prefixes that have keys.
+type Stats struct {
+	Keys       uint64        `json:"keys"`
+	KeyBytes   uint64        `json:"key_bytes"`
+	ValueBytes uint64        `json:"value_bytes"`
+	Buckets    []BucketStats `json:"buckets"`
+}
+
+func (s *BucketStats) add(key []byte, valueSize uint64, largest int) {
+	s.Keys++
+	s.KeyBytes += uint64(len(key))
+	s.ValueBytes += valueSize
+
+	size := uint64(len(key)) + valueSize
+	if len(s.Largest) == largest && (largest == 0 || s.Largest[largest-1].Size >= size) {
+		return
+	}
+	// Largest is sorted by descending size
+	i, _ := slices.BinarySearchFunc(s.Largest, size, func(e Entry, size uint64) int {
+		if e.Size > size {
+			return -1
+		}
+		return 1
+	})
+	s.Largest = slices.Insert(s.Largest, i, Entry{Key: hex.EncodeToString(key), Size: size})
+	if len(s.Largest) > largest {
+		s.Largest = s.Largest[:largest]
+	}
+}
+
+// CollectStats iterates over every key of the database in a single read-only transaction and reports the number
+// and size of the keys and values of each bucket, with its largest entries.
+func CollectStats(ctx context.Context, database DB, largest int) (*Stats, error) {
+	var buckets [256]BucketStats
+	for i := range buckets {
+		buckets[i] = BucketStats{Bucket: Bucket(i).String(), Largest: []Entry{}}
+	}
+
+	err := database.View(func(txn Transaction) error {
+		it, err := txn.NewIterator()
+		if err != nil {
+			return err
+		}
+
+		for it.Seek([]byte{0}); it.Valid(); it.Next() {
+			if err = ctx.Err(); err != nil {
+				return utils.RunAndWrapOnError(it.Close, err)
+			}
+
+			key := it.Key()
+			if len(key) == 0 {
+				continue
+			}
+			val, vErr := it.Value()
+			if vErr != nil {
+				return utils.RunAndWrapOnError(it.Close, vErr)
+			}
+			buckets[key[0]].add(key, uint64(len(val)), largest)
+		}
+		return it.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := new(Stats)
+	for i := range buckets {
+		if i >= int(numBuckets) && buckets[i].Keys == 0 {
+			continue
+		}
+		stats.Keys += buckets[i].Keys
+		stats.KeyBytes += buckets[i].KeyBytes
+		stats.ValueBytes += buckets[i].ValueBytes
+		stats.Buckets = append(stats.Buckets, buckets[i])
+	}
+	return stats, nil
+}
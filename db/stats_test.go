@@ -0,0 +1,49 @@
+package db_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectStats(t *testing.T) {
+	testDB := pebble.NewMemTest(t)
+	require.NoError(t, testDB.Update(func(txn db.Transaction) error {
+		for i, size := range []int{1, 5, 3, 4} {
+			if err := txn.Set(db.Class.Key([]byte{byte(i)}), make([]byte, size)); err != nil {
+				return err
+			}
+		}
+		if err := txn.Set(db.ChainHeight.Key(), []byte{1}); err != nil {
+			return err
+		}
+		return txn.Set([]byte{200, 1}, []byte{2})
+	}))
+
+	stats, err := db.CollectStats(context.Background(), testDB, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), stats.Keys)
+	assert.Equal(t, uint64(11), stats.KeyBytes)
+	assert.Equal(t, uint64(15), stats.ValueBytes)
+	require.Len(t, stats.Buckets, len(db.Buckets())+1)
+
+	class := stats.Buckets[db.Class]
+	assert.Equal(t, "Class", class.Bucket)
+	assert.Equal(t, uint64(4), class.Keys)
+	assert.Equal(t, uint64(8), class.KeyBytes)
+	assert.Equal(t, uint64(13), class.ValueBytes)
+	assert.Equal(t, []db.Entry{
+		{Key: hex.EncodeToString(db.Class.Key([]byte{1})), Size: 7},
+		{Key: hex.EncodeToString(db.Class.Key([]byte{3})), Size: 6},
+	}, class.Largest)
+
+	assert.Equal(t, uint64(0), stats.Buckets[db.StateTrie].Keys)
+	unknown := stats.Buckets[len(stats.Buckets)-1]
+	assert.Equal(t, "Bucket(200)", unknown.Bucket)
+	assert.Equal(t, uint64(1), unknown.Keys)
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/NethermindEth/juno/db")
+
+var _ DB = (*TracedDB)(nil)
+
+// TracedDB traces a sample of the transactions on the wrapped DB along with their operations.
+// Transactions don't carry the context of their caller, so each traced transaction is the root of its own trace.
+type TracedDB struct {
+	DB
+	sampleRatio float64
+}
+
+// NewTracedDB traces the given fraction, between 0 and 1, of the transactions on db
+func NewTracedDB(db DB, sampleRatio float64) *TracedDB {
+	return &TracedDB{
+		DB:          db,
+		sampleRatio: sampleRatio,
+	}
+}
+
+// NewTransaction : see db.DB.NewTransaction
+func (d *TracedDB) NewTransaction(update bool) (Transaction, error) {
+	txn, err := d.DB.NewTransaction(update)
+	if err != nil || rand.Float64() >= d.sampleRatio { //nolint:gosec
+		return txn, err
+	}
+
+	ctx, span := tracer.Start(context.Background(), "db.Transaction", trace.WithAttributes(attribute.Bool("db.update", update)))
+	return &tracedTransaction{
+		txn:  txn,
+		ctx:  ctx,
+		span: span,
+	}, nil
+}
+
+// View : see db.DB.View
+func (d *TracedDB) View(fn func(txn Transaction) error) error {
+	return View(d, fn)
+}
+
+// Update : see db.DB.Update
+func (d *TracedDB) Update(fn func(txn Transaction) error) error {
+	return Update(d, fn)
+}
+
+// WithListener registers an EventListener
+func (d *TracedDB) WithListener(listener EventListener) DB {
+	d.DB.WithListener(listener)
+	return d
+}
+
+type tracedTransaction struct {
+	txn  Transaction
+	ctx  context.Context
+	span trace.Span
+}
+
+func (t *tracedTransaction) startOp(name string, key []byte) trace.Span {
+	var attributes []attribute.KeyValue
+	if len(key) > 0 {
+		attributes = append(attributes, attribute.Int("db.bucket", int(key[0])))
+	}
+	_, span := tracer.Start(t.ctx, name, trace.WithAttributes(attributes...))
+	return span
+}
+
+// endOp marks the span as failed if err is an actual failure and ends it
+func endOp(span trace.Span, err error) error {
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
+
+// NewIterator : see db.Transaction.NewIterator
+func (t *tracedTransaction) NewIterator() (Iterator, error) {
+	span := t.startOp("db.NewIterator", nil)
+	it, err := t.txn.NewIterator()
+	return it, endOp(span, err)
+}
+
+// Discard : see db.Transaction.Discard
+func (t *tracedTransaction) Discard() error {
+	// Discard is also called after a Commit, ending the span again is a no-op
+	return endOp(t.span, t.txn.Discard())
+}
+
+// Commit : see db.Transaction.Commit
+func (t *tracedTransaction) Commit() error {
+	err := endOp(t.startOp("db.Commit", nil), t.txn.Commit())
+	return endOp(t.span, err)
+}
+
+// Set : see db.Transaction.Set
+func (t *tracedTransaction) Set(key, val []byte) error {
+	return endOp(t.startOp("db.Set", key), t.txn.Set(key, val))
+}
+
+// Delete : see db.Transaction.Delete
+func (t *tracedTransaction) Delete(key []byte) error {
+	return endOp(t.startOp("db.Delete", key), t.txn.Delete(key))
+}
+
+// Get : see db.Transaction.Get
+func (t *tracedTransaction) Get(key []byte, cb func([]byte) error) error {
+	return endOp(t.startOp("db.Get", key), t.txn.Get(key, cb))
+}
+
+// Impl : see db.Transaction.Impl
+func (t *tracedTransaction) Impl() any {
+	return t.txn.Impl()
+}
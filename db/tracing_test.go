@@ -0,0 +1,52 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedDB(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	key := db.ContractNonce.Key([]byte("key"))
+	t.Run("sampled transactions are traced with their operations", func(t *testing.T) {
+		tracedDB := db.NewTracedDB(pebble.NewMemTest(t), 1)
+		require.NoError(t, tracedDB.Update(func(txn db.Transaction) error {
+			return txn.Set(key, []byte("value"))
+		}))
+		require.ErrorIs(t, tracedDB.View(func(txn db.Transaction) error {
+			return txn.Get([]byte("missing"), nil)
+		}), db.ErrKeyNotFound)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 5)
+		var names []string
+		for _, span := range spans {
+			names = append(names, span.Name())
+		}
+		assert.Equal(t, []string{"db.Set", "db.Commit", "db.Transaction", "db.Get", "db.Transaction"}, names)
+
+		// operations are children of their transaction
+		assert.Equal(t, spans[2].SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, spans[4].SpanContext().SpanID(), spans[3].Parent().SpanID())
+		// a missing key is not a failure
+		assert.Empty(t, spans[3].Events())
+	})
+
+	t.Run("unsampled transactions are not traced", func(t *testing.T) {
+		ended := len(recorder.Ended())
+		tracedDB := db.NewTracedDB(pebble.NewMemTest(t), 0)
+		require.NoError(t, tracedDB.Update(func(txn db.Transaction) error {
+			return txn.Set(key, []byte("value"))
+		}))
+		assert.Len(t, recorder.Ended(), ended)
+	})
+}
@@ -0,0 +1,267 @@
+// Package export writes the blocks, transactions, receipts, events, state diffs and L1 messages stored in the
+// database to Parquet or JSONL files, one file per table and range of blocks.
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/parquet-go/parquet-go"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/spf13/pflag"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format (known: parquet, jsonl)")
+
+const (
+	DefaultChunkSize = 1000
+
+	tmpSuffix = ".tmp"
+)
+
+var Tables = []string{"blocks", "transactions", "receipts", "events", "state_diffs", "l1_messages"}
+
+type Format int
+
+var _ pflag.Value = (*Format)(nil)
+
+const (
+	Parquet Format = iota
+	JSONL
+)
+
+func (f Format) String() string {
+	switch f {
+	case Parquet:
+		return "parquet"
+	case JSONL:
+		return "jsonl"
+	default:
+		// Should not happen.
+		panic(ErrUnknownFormat)
+	}
+}
+
+func (f *Format) Set(s string) error {
+	switch s {
+	case "parquet", "PARQUET":
+		*f = Parquet
+	case "jsonl", "JSONL":
+		*f = JSONL
+	default:
+		return ErrUnknownFormat
+	}
+	return nil
+}
+
+func (f *Format) Type() string {
+	return "Format"
+}
+
+type Exporter struct {
+	database  db.DB
+	dir       string
+	format    Format
+	chunkSize uint64
+	workers   int
+	log       utils.SimpleLogger
+}
+
+// New returns an exporter that writes into dir. Every table gets a subdirectory of its own, which holds one file per
+// chunk of blocks.
+func New(database db.DB, dir string, format Format, log utils.SimpleLogger) *Exporter {
+	blockchain.RegisterCoreTypesToEncoder()
+	return &Exporter{
+		database:  database,
+		dir:       dir,
+		format:    format,
+		chunkSize: DefaultChunkSize,
+		workers:   runtime.GOMAXPROCS(0),
+		log:       log,
+	}
+}
+
+// WithChunkSize sets the number of blocks per file
+func (e *Exporter) WithChunkSize(size uint64) *Exporter {
+	e.chunkSize = size
+	return e
+}
+
+// WithWorkers sets the number of chunks exported in parallel
+func (e *Exporter) WithWorkers(workers int) *Exporter {
+	e.workers = workers
+	return e
+}
+
+// Height returns the number of the head block in the database
+func (e *Exporter) Height() (uint64, error) {
+	var height uint64
+	return height, e.database.View(func(txn db.Transaction) error {
+		var err error
+		height, err = blockchain.ChainHeight(txn)
+		return err
+	})
+}
+
+// Run exports the blocks in [from, to]. The chunks start at from, so an interrupted export resumes from where it
+// stopped if it is run again with the same range and chunk size: chunks whose files all exist are skipped.
+func (e *Exporter) Run(ctx context.Context, from, to uint64) error {
+	if from > to {
+		return fmt.Errorf("invalid block range: %d > %d", from, to)
+	}
+	if e.chunkSize == 0 {
+		return errors.New("chunk size must be positive")
+	}
+	if e.workers < 1 {
+		return errors.New("at least one worker is required")
+	}
+
+	height, err := e.Height()
+	if err != nil {
+		return err
+	}
+	if to > height {
+		return fmt.Errorf("block %d is above the chain height %d", to, height)
+	}
+
+	for _, table := range Tables {
+		if err = os.MkdirAll(filepath.Join(e.dir, table), 0o750); err != nil {
+			return err
+		}
+	}
+
+	workerPool := pool.New().WithErrors().WithFirstError().WithMaxGoroutines(e.workers)
+	for start := from; start <= to && ctx.Err() == nil; {
+		end := min(start+e.chunkSize-1, to)
+		if end < start {
+			// start+chunkSize overflowed
+			end = to
+		}
+		chunkFrom, chunkTo := start, end
+		workerPool.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return e.exportChunk(chunkFrom, chunkTo)
+		})
+
+		if end == to {
+			break
+		}
+		start = end + 1
+	}
+	if err = workerPool.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// exportChunk reads the blocks of the chunk in a single read-only transaction, so the files are consistent even if
+// the node keeps syncing, and moves the files into place once they are complete
+func (e *Exporter) exportChunk(from, to uint64) error {
+	paths := e.chunkPaths(from, to)
+	if e.exported(paths) {
+		e.log.Debugw("Skipping exported blocks", "from", from, "to", to)
+		return nil
+	}
+
+	var chunk rows
+	err := e.database.View(func(txn db.Transaction) error {
+		for number := from; number <= to; number++ {
+			block, err := blockchain.BlockByNumber(txn, number)
+			if err != nil {
+				return fmt.Errorf("get block %d: %w", number, err)
+			}
+			stateUpdate, err := blockchain.StateUpdateByNumber(txn, number)
+			if err != nil {
+				return fmt.Errorf("get state update %d: %w", number, err)
+			}
+			chunk.add(block, stateUpdate)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	writes := []func(string) error{
+		func(path string) error { return writeFile(path, e.format, chunk.blocks) },
+		func(path string) error { return writeFile(path, e.format, chunk.transactions) },
+		func(path string) error { return writeFile(path, e.format, chunk.receipts) },
+		func(path string) error { return writeFile(path, e.format, chunk.events) },
+		func(path string) error { return writeFile(path, e.format, chunk.stateDiffs) },
+		func(path string) error { return writeFile(path, e.format, chunk.l1Messages) },
+	}
+	for i, write := range writes {
+		if err = write(paths[i] + tmpSuffix); err != nil {
+			return fmt.Errorf("write %s of blocks %d-%d: %w", Tables[i], from, to, err)
+		}
+	}
+	for _, path := range paths {
+		if err = os.Rename(path+tmpSuffix, path); err != nil {
+			return err
+		}
+	}
+
+	e.log.Infow("Exported blocks", "from", from, "to", to, "transactions", len(chunk.transactions),
+		"events", len(chunk.events))
+	return nil
+}
+
+// chunkPaths returns the file of every table for the given chunk, in the order of Tables
+func (e *Exporter) chunkPaths(from, to uint64) []string {
+	name := fmt.Sprintf("%010d-%010d.%s", from, to, e.format)
+	paths := make([]string, 0, len(Tables))
+	for _, table := range Tables {
+		paths = append(paths, filepath.Join(e.dir, table, name))
+	}
+	return paths
+}
+
+func (e *Exporter) exported(paths []string) bool {
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func writeFile[T any](path string, format Format, rows []T) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	switch format {
+	case Parquet:
+		writer := parquet.NewGenericWriter[T](file, parquet.Compression(&parquet.Zstd))
+		if _, err = writer.Write(rows); err != nil {
+			return err
+		}
+		return writer.Close()
+	case JSONL:
+		buffered := bufio.NewWriter(file)
+		encoder := json.NewEncoder(buffered)
+		for i := range rows {
+			if err = encoder.Encode(&rows[i]); err != nil {
+				return err
+			}
+		}
+		return buffered.Flush()
+	default:
+		return ErrUnknownFormat
+	}
+}
@@ -0,0 +1,135 @@
+package export_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/export"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) db.DB {
+	t.Helper()
+
+	testDB := pebble.NewMemTest(t)
+	chain := blockchain.New(testDB, &utils.Mainnet)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Mainnet))
+	for number := uint64(0); number < 3; number++ {
+		stateUpdate, block, err := gw.StateUpdateWithBlock(context.Background(), number)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(block, &core.BlockCommitments{}, stateUpdate, nil))
+	}
+	return testDB
+}
+
+func readJSONL[T any](t *testing.T, path string) []T {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var rows []T
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var row T
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.NoError(t, scanner.Err())
+	return rows
+}
+
+func TestExport(t *testing.T) {
+	testDB := newTestDB(t)
+	log := utils.NewNopZapLogger()
+
+	t.Run("jsonl", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, export.New(testDB, dir, export.JSONL, log).WithChunkSize(2).WithWorkers(2).Run(context.Background(), 0, 2))
+
+		for _, table := range export.Tables {
+			entries, err := os.ReadDir(filepath.Join(dir, table))
+			require.NoError(t, err)
+			require.Len(t, entries, 2, table)
+			assert.Equal(t, "0000000000-0000000001.jsonl", entries[0].Name())
+			assert.Equal(t, "0000000002-0000000002.jsonl", entries[1].Name())
+		}
+
+		blocks := readJSONL[export.Block](t, filepath.Join(dir, "blocks", "0000000000-0000000001.jsonl"))
+		require.Len(t, blocks, 2)
+		assert.Equal(t, uint64(1), blocks[1].Number)
+		assert.Equal(t, "0x47c3637b57c2b079b93c61539950c17e868a28f46cdef28f88521067f21e943", blocks[0].Hash)
+		assert.Equal(t, blocks[0].Hash, blocks[1].ParentHash)
+
+		txs := readJSONL[export.Transaction](t, filepath.Join(dir, "transactions", "0000000000-0000000001.jsonl"))
+		assert.Equal(t, int(blocks[0].TransactionCount+blocks[1].TransactionCount), len(txs))
+		assert.Equal(t, "DEPLOY", txs[0].Type)
+
+		diffs := readJSONL[export.StateDiff](t, filepath.Join(dir, "state_diffs", "0000000002-0000000002.jsonl"))
+		require.NotEmpty(t, diffs)
+		for _, diff := range diffs {
+			assert.Equal(t, uint64(2), diff.BlockNumber)
+		}
+	})
+
+	t.Run("parquet", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, export.New(testDB, dir, export.Parquet, log).Run(context.Background(), 0, 2))
+
+		blocks, err := parquet.ReadFile[export.Block](filepath.Join(dir, "blocks", "0000000000-0000000002.parquet"))
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+		assert.Equal(t, uint64(2), blocks[2].Number)
+
+		txs, err := parquet.ReadFile[export.Transaction](filepath.Join(dir, "transactions", "0000000000-0000000002.parquet"))
+		require.NoError(t, err)
+		assert.Equal(t, int(blocks[0].TransactionCount+blocks[1].TransactionCount+blocks[2].TransactionCount), len(txs))
+		assert.NotEmpty(t, txs[0].Calldata)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		dir := t.TempDir()
+		exporter := export.New(testDB, dir, export.JSONL, log).WithChunkSize(2)
+		require.NoError(t, exporter.Run(context.Background(), 0, 2))
+
+		// the first chunk is complete and must not be written again
+		exported := filepath.Join(dir, "events", "0000000000-0000000001.jsonl")
+		require.NoError(t, os.WriteFile(exported, []byte("kept\n"), 0o600))
+		// the second one is missing a file
+		missing := filepath.Join(dir, "receipts", "0000000002-0000000002.jsonl")
+		require.NoError(t, os.Remove(missing))
+
+		require.NoError(t, exporter.Run(context.Background(), 0, 2))
+		kept, err := os.ReadFile(exported)
+		require.NoError(t, err)
+		assert.Equal(t, "kept\n", string(kept))
+		assert.FileExists(t, missing)
+	})
+
+	t.Run("blocks above the head", func(t *testing.T) {
+		assert.Error(t, export.New(testDB, t.TempDir(), export.JSONL, log).Run(context.Background(), 0, 3))
+	})
+}
+
+func TestFormat(t *testing.T) {
+	var format export.Format
+	require.NoError(t, format.Set("jsonl"))
+	assert.Equal(t, export.JSONL, format)
+	assert.Equal(t, "jsonl", format.String())
+	assert.ErrorIs(t, format.Set("csv"), export.ErrUnknownFormat)
+}
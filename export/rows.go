@@ -0,0 +1,329 @@
+package export
+
+import (
+	"slices"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+// The rows of the exported tables. Felts are written as hex strings, since neither format has a 252-bit integer
+// type, and are empty if the value doesn't apply to the row.
+
+type Block struct {
+	Number            uint64 `json:"number" parquet:"number"`
+	Hash              string `json:"hash" parquet:"hash"`
+	ParentHash        string `json:"parent_hash" parquet:"parent_hash"`
+	StateRoot         string `json:"state_root" parquet:"state_root"`
+	SequencerAddress  string `json:"sequencer_address" parquet:"sequencer_address"`
+	Timestamp         uint64 `json:"timestamp" parquet:"timestamp"`
+	ProtocolVersion   string `json:"protocol_version" parquet:"protocol_version"`
+	TransactionCount  uint64 `json:"transaction_count" parquet:"transaction_count"`
+	EventCount        uint64 `json:"event_count" parquet:"event_count"`
+	L1GasPriceWei     string `json:"l1_gas_price_wei" parquet:"l1_gas_price_wei"`
+	L1GasPriceFri     string `json:"l1_gas_price_fri" parquet:"l1_gas_price_fri"`
+	L1DataGasPriceWei string `json:"l1_data_gas_price_wei" parquet:"l1_data_gas_price_wei"`
+	L1DataGasPriceFri string `json:"l1_data_gas_price_fri" parquet:"l1_data_gas_price_fri"`
+	L1DAMode          string `json:"l1_da_mode" parquet:"l1_da_mode"`
+}
+
+type Transaction struct {
+	BlockNumber uint64 `json:"block_number" parquet:"block_number"`
+	Index       uint64 `json:"index" parquet:"index"`
+	Hash        string `json:"hash" parquet:"hash"`
+	Type        string `json:"type" parquet:"type"`
+	Version     string `json:"version" parquet:"version"`
+	// SenderAddress is the account of invoke and declare transactions and the contract of the others
+	SenderAddress      string   `json:"sender_address" parquet:"sender_address"`
+	Nonce              string   `json:"nonce" parquet:"nonce"`
+	ClassHash          string   `json:"class_hash" parquet:"class_hash"`
+	EntryPointSelector string   `json:"entry_point_selector" parquet:"entry_point_selector"`
+	MaxFee             string   `json:"max_fee" parquet:"max_fee"`
+	Tip                uint64   `json:"tip" parquet:"tip"`
+	Calldata           []string `json:"calldata" parquet:"calldata,list"`
+	Signature          []string `json:"signature" parquet:"signature,list"`
+}
+
+type Receipt struct {
+	BlockNumber      uint64 `json:"block_number" parquet:"block_number"`
+	TransactionIndex uint64 `json:"transaction_index" parquet:"transaction_index"`
+	TransactionHash  string `json:"transaction_hash" parquet:"transaction_hash"`
+	ActualFee        string `json:"actual_fee" parquet:"actual_fee"`
+	FeeUnit          string `json:"fee_unit" parquet:"fee_unit"`
+	Reverted         bool   `json:"reverted" parquet:"reverted"`
+	RevertReason     string `json:"revert_reason" parquet:"revert_reason"`
+	Steps            uint64 `json:"steps" parquet:"steps"`
+	EventCount       uint64 `json:"event_count" parquet:"event_count"`
+}
+
+type Event struct {
+	BlockNumber      uint64   `json:"block_number" parquet:"block_number"`
+	TransactionIndex uint64   `json:"transaction_index" parquet:"transaction_index"`
+	TransactionHash  string   `json:"transaction_hash" parquet:"transaction_hash"`
+	Index            uint64   `json:"index" parquet:"index"`
+	FromAddress      string   `json:"from_address" parquet:"from_address"`
+	Keys             []string `json:"keys" parquet:"keys,list"`
+	Data             []string `json:"data" parquet:"data,list"`
+}
+
+// StateDiff is a single change to the state. Value is the new storage value, nonce, class hash of a deployed or
+// replaced contract, or compiled class hash of a declared Cairo 1 class.
+type StateDiff struct {
+	BlockNumber uint64 `json:"block_number" parquet:"block_number"`
+	Type        string `json:"type" parquet:"type"`
+	Address     string `json:"address" parquet:"address"`
+	Key         string `json:"key" parquet:"key"`
+	ClassHash   string `json:"class_hash" parquet:"class_hash"`
+	Value       string `json:"value" parquet:"value"`
+}
+
+// L1Message is a message between L1 and L2, addresses on L1 are written as hex strings as well
+type L1Message struct {
+	BlockNumber      uint64   `json:"block_number" parquet:"block_number"`
+	TransactionIndex uint64   `json:"transaction_index" parquet:"transaction_index"`
+	TransactionHash  string   `json:"transaction_hash" parquet:"transaction_hash"`
+	Direction        string   `json:"direction" parquet:"direction"`
+	FromAddress      string   `json:"from_address" parquet:"from_address"`
+	ToAddress        string   `json:"to_address" parquet:"to_address"`
+	Selector         string   `json:"selector" parquet:"selector"`
+	Nonce            string   `json:"nonce" parquet:"nonce"`
+	Payload          []string `json:"payload" parquet:"payload,list"`
+}
+
+const (
+	stateDiffStorage          = "STORAGE"
+	stateDiffNonce            = "NONCE"
+	stateDiffDeployedContract = "DEPLOYED_CONTRACT"
+	stateDiffReplacedClass    = "REPLACED_CLASS"
+	stateDiffDeclaredV0Class  = "DECLARED_V0_CLASS"
+	stateDiffDeclaredV1Class  = "DECLARED_V1_CLASS"
+
+	directionL1ToL2 = "L1_TO_L2"
+	directionL2ToL1 = "L2_TO_L1"
+)
+
+// rows holds the rows of all the tables for a range of blocks
+type rows struct {
+	blocks       []Block
+	transactions []Transaction
+	receipts     []Receipt
+	events       []Event
+	stateDiffs   []StateDiff
+	l1Messages   []L1Message
+}
+
+func (r *rows) add(block *core.Block, stateUpdate *core.StateUpdate) {
+	r.blocks = append(r.blocks, adaptBlock(block.Header))
+	for i, tx := range block.Transactions {
+		r.transactions = append(r.transactions, adaptTransaction(block.Number, uint64(i), tx))
+	}
+	for i, receipt := range block.Receipts {
+		index := uint64(i)
+		r.receipts = append(r.receipts, adaptReceipt(block.Number, index, receipt))
+		for j, event := range receipt.Events {
+			r.events = append(r.events, Event{
+				BlockNumber:      block.Number,
+				TransactionIndex: index,
+				TransactionHash:  feltString(receipt.TransactionHash),
+				Index:            uint64(j),
+				FromAddress:      feltString(event.From),
+				Keys:             feltStrings(event.Keys),
+				Data:             feltStrings(event.Data),
+			})
+		}
+		r.l1Messages = append(r.l1Messages, adaptL1Messages(block.Number, index, receipt)...)
+	}
+	r.stateDiffs = append(r.stateDiffs, adaptStateDiff(block.Number, stateUpdate.StateDiff)...)
+}
+
+func adaptBlock(header *core.Header) Block {
+	block := Block{
+		Number:           header.Number,
+		Hash:             feltString(header.Hash),
+		ParentHash:       feltString(header.ParentHash),
+		StateRoot:        feltString(header.GlobalStateRoot),
+		SequencerAddress: feltString(header.SequencerAddress),
+		Timestamp:        header.Timestamp,
+		ProtocolVersion:  header.ProtocolVersion,
+		TransactionCount: header.TransactionCount,
+		EventCount:       header.EventCount,
+		L1GasPriceWei:    feltString(header.GasPrice),
+		L1GasPriceFri:    feltString(header.GasPriceSTRK),
+		L1DAMode:         "CALLDATA",
+	}
+	if header.L1DataGasPrice != nil {
+		block.L1DataGasPriceWei = feltString(header.L1DataGasPrice.PriceInWei)
+		block.L1DataGasPriceFri = feltString(header.L1DataGasPrice.PriceInFri)
+	}
+	if header.L1DAMode == core.Blob {
+		block.L1DAMode = "BLOB"
+	}
+	return block
+}
+
+func adaptTransaction(blockNumber, index uint64, transaction core.Transaction) Transaction {
+	tx := Transaction{
+		BlockNumber: blockNumber,
+		Index:       index,
+		Hash:        feltString(transaction.Hash()),
+		Signature:   feltStrings(transaction.Signature()),
+	}
+	if version := transaction.TxVersion(); version != nil {
+		tx.Version = version.String()
+	}
+
+	switch t := transaction.(type) {
+	case *core.InvokeTransaction:
+		tx.Type = "INVOKE"
+		tx.SenderAddress = feltString(t.SenderAddress)
+		if t.SenderAddress == nil {
+			tx.SenderAddress = feltString(t.ContractAddress)
+		}
+		tx.Nonce = feltString(t.Nonce)
+		tx.EntryPointSelector = feltString(t.EntryPointSelector)
+		tx.MaxFee = feltString(t.MaxFee)
+		tx.Tip = t.Tip
+		tx.Calldata = feltStrings(t.CallData)
+	case *core.DeclareTransaction:
+		tx.Type = "DECLARE"
+		tx.SenderAddress = feltString(t.SenderAddress)
+		tx.Nonce = feltString(t.Nonce)
+		tx.ClassHash = feltString(t.ClassHash)
+		tx.MaxFee = feltString(t.MaxFee)
+		tx.Tip = t.Tip
+	case *core.DeployAccountTransaction:
+		tx.Type = "DEPLOY_ACCOUNT"
+		tx.SenderAddress = feltString(t.ContractAddress)
+		tx.Nonce = feltString(t.Nonce)
+		tx.ClassHash = feltString(t.ClassHash)
+		tx.MaxFee = feltString(t.MaxFee)
+		tx.Tip = t.Tip
+		tx.Calldata = feltStrings(t.ConstructorCallData)
+	case *core.DeployTransaction:
+		tx.Type = "DEPLOY"
+		tx.SenderAddress = feltString(t.ContractAddress)
+		tx.ClassHash = feltString(t.ClassHash)
+		tx.Calldata = feltStrings(t.ConstructorCallData)
+	case *core.L1HandlerTransaction:
+		tx.Type = "L1_HANDLER"
+		tx.SenderAddress = feltString(t.ContractAddress)
+		tx.Nonce = feltString(t.Nonce)
+		tx.EntryPointSelector = feltString(t.EntryPointSelector)
+		tx.Calldata = feltStrings(t.CallData)
+	}
+	return tx
+}
+
+func adaptReceipt(blockNumber, index uint64, receipt *core.TransactionReceipt) Receipt {
+	adapted := Receipt{
+		BlockNumber:      blockNumber,
+		TransactionIndex: index,
+		TransactionHash:  feltString(receipt.TransactionHash),
+		ActualFee:        feltString(receipt.Fee),
+		FeeUnit:          "WEI",
+		Reverted:         receipt.Reverted,
+		RevertReason:     receipt.RevertReason,
+		EventCount:       uint64(len(receipt.Events)),
+	}
+	if receipt.FeeUnit == core.STRK {
+		adapted.FeeUnit = "FRI"
+	}
+	if receipt.ExecutionResources != nil {
+		adapted.Steps = receipt.ExecutionResources.Steps
+	}
+	return adapted
+}
+
+func adaptL1Messages(blockNumber, index uint64, receipt *core.TransactionReceipt) []L1Message {
+	messages := make([]L1Message, 0, len(receipt.L2ToL1Message)+1)
+	if msg := receipt.L1ToL2Message; msg != nil {
+		messages = append(messages, L1Message{
+			BlockNumber:      blockNumber,
+			TransactionIndex: index,
+			TransactionHash:  feltString(receipt.TransactionHash),
+			Direction:        directionL1ToL2,
+			FromAddress:      msg.From.Hex(),
+			ToAddress:        feltString(msg.To),
+			Selector:         feltString(msg.Selector),
+			Nonce:            feltString(msg.Nonce),
+			Payload:          feltStrings(msg.Payload),
+		})
+	}
+	for _, msg := range receipt.L2ToL1Message {
+		messages = append(messages, L1Message{
+			BlockNumber:      blockNumber,
+			TransactionIndex: index,
+			TransactionHash:  feltString(receipt.TransactionHash),
+			Direction:        directionL2ToL1,
+			FromAddress:      feltString(msg.From),
+			ToAddress:        msg.To.Hex(),
+			Payload:          feltStrings(msg.Payload),
+		})
+	}
+	return messages
+}
+
+// adaptStateDiff flattens the state diff into one row per change, sorted by address and key so that exports of the
+// same blocks are identical
+func adaptStateDiff(blockNumber uint64, diff *core.StateDiff) []StateDiff {
+	var changes []StateDiff
+	add := func(changeType string, address, key, classHash, value *felt.Felt) {
+		changes = append(changes, StateDiff{
+			BlockNumber: blockNumber,
+			Type:        changeType,
+			Address:     feltString(address),
+			Key:         feltString(key),
+			ClassHash:   feltString(classHash),
+			Value:       feltString(value),
+		})
+	}
+
+	for _, address := range sortedKeys(diff.StorageDiffs) {
+		storage := diff.StorageDiffs[address]
+		for _, key := range sortedKeys(storage) {
+			add(stateDiffStorage, &address, &key, nil, storage[key])
+		}
+	}
+	for _, address := range sortedKeys(diff.Nonces) {
+		add(stateDiffNonce, &address, nil, nil, diff.Nonces[address])
+	}
+	for _, address := range sortedKeys(diff.DeployedContracts) {
+		add(stateDiffDeployedContract, &address, nil, nil, diff.DeployedContracts[address])
+	}
+	for _, address := range sortedKeys(diff.ReplacedClasses) {
+		add(stateDiffReplacedClass, &address, nil, nil, diff.ReplacedClasses[address])
+	}
+	for _, classHash := range diff.DeclaredV0Classes {
+		add(stateDiffDeclaredV0Class, nil, nil, classHash, nil)
+	}
+	for _, classHash := range sortedKeys(diff.DeclaredV1Classes) {
+		add(stateDiffDeclaredV1Class, nil, nil, &classHash, diff.DeclaredV1Classes[classHash])
+	}
+	return changes
+}
+
+func sortedKeys[V any](m map[felt.Felt]V) []felt.Felt {
+	keys := make([]felt.Felt, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b felt.Felt) int {
+		return a.Cmp(&b)
+	})
+	return keys
+}
+
+func feltString(f *felt.Felt) string {
+	if f == nil {
+		return ""
+	}
+	return f.String()
+}
+
+func feltStrings(felts []*felt.Felt) []string {
+	strs := make([]string, 0, len(felts))
+	for _, f := range felts {
+		strs = append(strs, feltString(f))
+	}
+	return strs
+}
@@ -0,0 +1,353 @@
+// Package feedergateway serves the feeder gateway endpoints that clients/feeder.Client consumes from the local
+// database, which lets other nodes and tools that only speak feeder sync from this node instead of the public feeder.
+package feedergateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/NethermindEth/juno/adapters/core2sn"
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/starknet"
+	"github.com/NethermindEth/juno/utils"
+)
+
+const (
+	latestID  = "latest"
+	pendingID = "pending"
+
+	statusPending      = "PENDING"
+	statusAcceptedOnL2 = "ACCEPTED_ON_L2"
+	statusAcceptedOnL1 = "ACCEPTED_ON_L1"
+)
+
+// Error is the error body the feeder gateway responds with
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	status int
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+var (
+	ErrBlockNotFound   = &Error{Code: "StarknetErrorCode.BLOCK_NOT_FOUND", Message: "Block not found", status: http.StatusBadRequest}
+	ErrUndeclaredClass = &Error{Code: "StarknetErrorCode.UNDECLARED_CLASS", Message: "Class not declared", status: http.StatusBadRequest}
+)
+
+func malformedRequest(format string, args ...any) *Error {
+	return &Error{
+		Code:    "StarknetErrorCode.MALFORMED_REQUEST",
+		Message: fmt.Sprintf(format, args...),
+		status:  http.StatusBadRequest,
+	}
+}
+
+type Handler struct {
+	bcReader blockchain.Reader
+	log      utils.SimpleLogger
+	mux      *http.ServeMux
+}
+
+// New returns a handler that serves the endpoints relative to the root of its requests, it is expected to be
+// mounted with http.StripPrefix
+func New(bcReader blockchain.Reader, log utils.SimpleLogger) *Handler {
+	h := &Handler{
+		bcReader: bcReader,
+		log:      log,
+		mux:      http.NewServeMux(),
+	}
+	h.handle("get_block", h.block)
+	h.handle("get_state_update", h.stateUpdate)
+	h.handle("get_signature", h.signature)
+	h.handle("get_class_by_hash", h.class)
+	h.handle("get_compiled_class_by_class_hash", h.compiledClass)
+	return h
+}
+
+func (h *Handler) handle(endpoint string, fn func(query url.Values) (any, error)) {
+	h.mux.HandleFunc("/"+endpoint, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		response, err := fn(r.URL.Query())
+		if err != nil {
+			h.writeError(w, endpoint, err)
+			return
+		}
+		h.write(w, http.StatusOK, response)
+	})
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) write(w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.log.Warnw("Failed to write feeder gateway response", "err", err)
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, endpoint string, err error) {
+	var feederErr *Error
+	if !errors.As(err, &feederErr) {
+		h.log.Errorw("Failed to serve feeder gateway request", "endpoint", endpoint, "err", err)
+		feederErr = &Error{
+			Code:    "StarknetErrorCode.INTERNAL_ERROR",
+			Message: "internal error",
+			status:  http.StatusInternalServerError,
+		}
+	}
+	h.write(w, feederErr.status, feederErr)
+}
+
+// blockID is the block that the blockNumber or blockHash query parameter refers to, latest if neither is set
+type blockID struct {
+	pending bool
+	number  *uint64
+	hash    *felt.Felt
+}
+
+func parseBlockID(query url.Values) (blockID, error) {
+	var id blockID
+	if hash := query.Get("blockHash"); hash != "" {
+		if query.Has("blockNumber") {
+			return id, malformedRequest("only one of blockNumber and blockHash can be set")
+		}
+		var err error
+		if id.hash, err = new(felt.Felt).SetString(hash); err != nil {
+			return id, malformedRequest("invalid blockHash %q", hash)
+		}
+		return id, nil
+	}
+
+	switch number := query.Get("blockNumber"); number {
+	case "", latestID:
+	case pendingID:
+		id.pending = true
+	default:
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return id, malformedRequest("invalid blockNumber %q", number)
+		}
+		id.number = &n
+	}
+	return id, nil
+}
+
+func notFound(err, notFoundErr error) error {
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return notFoundErr
+	}
+	return err
+}
+
+// resolve returns the pending block if the id refers to it and there is one, otherwise the number of the block in
+// the chain the id refers to
+func (h *Handler) resolve(id blockID) (*blockchain.Pending, uint64, error) {
+	switch {
+	case id.pending:
+		pending, err := h.bcReader.Pending()
+		if err == nil {
+			return &pending, 0, nil
+		} else if !errors.Is(err, db.ErrKeyNotFound) {
+			return nil, 0, err
+		}
+		// the feeder serves the latest block when there is no pending one
+		fallthrough
+	case id.number == nil && id.hash == nil:
+		height, err := h.bcReader.Height()
+		return nil, height, notFound(err, ErrBlockNotFound)
+	case id.hash != nil:
+		header, err := h.bcReader.BlockHeaderByHash(id.hash)
+		if err != nil {
+			return nil, 0, notFound(err, ErrBlockNotFound)
+		}
+		return nil, header.Number, nil
+	default:
+		return nil, *id.number, nil
+	}
+}
+
+func (h *Handler) status(number uint64) string {
+	l1Head, err := h.bcReader.L1Head()
+	if err == nil && number <= l1Head.BlockNumber {
+		return statusAcceptedOnL1
+	}
+	return statusAcceptedOnL2
+}
+
+func (h *Handler) adaptBlock(pending *blockchain.Pending, number uint64) (*starknet.Block, error) {
+	if pending != nil {
+		return core2sn.AdaptBlock(pending.Block, nil, statusPending)
+	}
+
+	block, err := h.bcReader.BlockByNumber(number)
+	if err != nil {
+		return nil, notFound(err, ErrBlockNotFound)
+	}
+	commitments, err := h.bcReader.BlockCommitmentsByNumber(number)
+	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+		return nil, err
+	}
+	return core2sn.AdaptBlock(block, commitments, h.status(number))
+}
+
+func (h *Handler) block(query url.Values) (any, error) {
+	id, err := parseBlockID(query)
+	if err != nil {
+		return nil, err
+	}
+	pending, number, err := h.resolve(id)
+	if err != nil {
+		return nil, err
+	}
+	return h.adaptBlock(pending, number)
+}
+
+func (h *Handler) stateUpdate(query url.Values) (any, error) {
+	id, err := parseBlockID(query)
+	if err != nil {
+		return nil, err
+	}
+	pending, number, err := h.resolve(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var update *core.StateUpdate
+	if pending != nil {
+		update = pending.StateUpdate
+	} else if update, err = h.bcReader.StateUpdateByNumber(number); err != nil {
+		return nil, notFound(err, ErrBlockNotFound)
+	}
+	snUpdate := core2sn.AdaptStateUpdate(update)
+	if query.Get("includeBlock") != "true" {
+		return snUpdate, nil
+	}
+
+	block, err := h.adaptBlock(pending, number)
+	if err != nil {
+		return nil, err
+	}
+	return &starknet.StateUpdateWithBlock{
+		Block:       block,
+		StateUpdate: snUpdate,
+	}, nil
+}
+
+func (h *Handler) signature(query url.Values) (any, error) {
+	id, err := parseBlockID(query)
+	if err != nil {
+		return nil, err
+	}
+	if id.pending {
+		// pending blocks are not signed
+		return nil, ErrBlockNotFound
+	}
+
+	_, number, err := h.resolve(id)
+	if err != nil {
+		return nil, err
+	}
+	header, err := h.bcReader.BlockHeaderByNumber(number)
+	if err != nil {
+		return nil, notFound(err, ErrBlockNotFound)
+	}
+	update, err := h.bcReader.StateUpdateByNumber(number)
+	if err != nil {
+		return nil, notFound(err, ErrBlockNotFound)
+	}
+	return core2sn.AdaptSignature(header, update.StateDiff.Commitment()), nil
+}
+
+// declaredClass returns the class with the classHash query parameter as of the requested block
+func (h *Handler) declaredClass(query url.Values) (*core.DeclaredClass, error) {
+	classHash, err := new(felt.Felt).SetString(query.Get("classHash"))
+	if err != nil {
+		return nil, malformedRequest("invalid classHash %q", query.Get("classHash"))
+	}
+	id, err := parseBlockID(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var state core.StateReader
+	var closer blockchain.StateCloser
+	switch {
+	case id.pending:
+		state, closer, err = h.bcReader.PendingState()
+		if errors.Is(err, db.ErrKeyNotFound) {
+			state, closer, err = h.bcReader.HeadState()
+		}
+	case id.number != nil:
+		state, closer, err = h.bcReader.StateAtBlockNumber(*id.number)
+	case id.hash != nil:
+		state, closer, err = h.bcReader.StateAtBlockHash(id.hash)
+	default:
+		state, closer, err = h.bcReader.HeadState()
+	}
+	if err != nil {
+		return nil, notFound(err, ErrBlockNotFound)
+	}
+	defer func() {
+		if closeErr := closer(); closeErr != nil {
+			h.log.Warnw("Failed to close state", "err", closeErr)
+		}
+	}()
+
+	class, err := state.Class(classHash)
+	return class, notFound(err, ErrUndeclaredClass)
+}
+
+func (h *Handler) class(query url.Values) (any, error) {
+	declared, err := h.declaredClass(query)
+	if err != nil {
+		return nil, err
+	}
+
+	switch class := declared.Class.(type) {
+	case *core.Cairo0Class:
+		return core2sn.AdaptCairo0Class(class)
+	case *core.Cairo1Class:
+		return core2sn.AdaptSierraClass(class), nil
+	default:
+		return nil, fmt.Errorf("unknown class type %T", class)
+	}
+}
+
+func (h *Handler) compiledClass(query url.Values) (any, error) {
+	declared, err := h.declaredClass(query)
+	if err != nil {
+		return nil, err
+	}
+
+	switch class := declared.Class.(type) {
+	case *core.Cairo0Class:
+		// the compiled form of a Cairo 0 class is its definition
+		return core2sn.AdaptCairo0Class(class)
+	case *core.Cairo1Class:
+		if class.Compiled == nil {
+			return nil, ErrUndeclaredClass
+		}
+		return core2sn.AdaptCompiledClass(class.Compiled), nil
+	default:
+		return nil, fmt.Errorf("unknown class type %T", class)
+	}
+}
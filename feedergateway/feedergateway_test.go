@@ -0,0 +1,202 @@
+package feedergateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/feedergateway"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeederGateway(t *testing.T) {
+	n := &utils.Integration
+	ctx := context.Background()
+	chain := blockchain.New(pebble.NewMemTest(t), n)
+	upstream := feeder.NewTestClient(t, n)
+	gw := adaptfeeder.New(upstream)
+
+	classHashes := []string{
+		"0x10455c752b86932ce552f2b0fe81a880746649b9aee7e0d842bf3f52378f9f8", // Cairo 0
+		"0x1cd2edfb485241c4403254d550de0a097fa76743cd30696f714a491a454bad5", // Cairo 1
+	}
+	classes := make(map[felt.Felt]core.Class)
+	for _, hash := range classHashes {
+		classHash := utils.HexToFelt(t, hash)
+		class, err := gw.Class(ctx, classHash)
+		require.NoError(t, err)
+		classes[*classHash] = class
+	}
+
+	var blocks []*core.Block
+	var updates []*core.StateUpdate
+	for i := uint64(0); i < 2; i++ {
+		b, err := gw.BlockByNumber(ctx, i)
+		require.NoError(t, err)
+		su, err := gw.StateUpdate(ctx, i)
+		require.NoError(t, err)
+		newClasses := classes
+		if i > 0 {
+			newClasses = nil
+		}
+		require.NoError(t, chain.Store(b, &core.BlockCommitments{}, su, newClasses))
+		blocks = append(blocks, b)
+		updates = append(updates, su)
+	}
+
+	srv := httptest.NewServer(http.StripPrefix("/feeder_gateway", feedergateway.New(chain, utils.NewNopZapLogger())))
+	t.Cleanup(srv.Close)
+	client := feeder.NewClient(srv.URL + "/feeder_gateway/").WithMaxRetries(0)
+	local := adaptfeeder.New(client)
+
+	t.Run("blocks and state updates", func(t *testing.T) {
+		for i := range blocks {
+			block, err := local.BlockByNumber(ctx, uint64(i))
+			require.NoError(t, err)
+			assert.Equal(t, blocks[i], block)
+
+			update, err := local.StateUpdate(ctx, uint64(i))
+			require.NoError(t, err)
+			assert.Equal(t, updates[i], update)
+
+			update, block, err = local.StateUpdateWithBlock(ctx, uint64(i))
+			require.NoError(t, err)
+			assert.Equal(t, updates[i], update)
+			assert.Equal(t, blocks[i], block)
+		}
+
+		latest, err := local.BlockLatest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, blocks[1], latest)
+
+		latestBlock, err := client.Block(ctx, "latest")
+		require.NoError(t, err)
+		assert.Equal(t, "ACCEPTED_ON_L2", latestBlock.Status)
+		for i, receipt := range latestBlock.Receipts {
+			assert.Equal(t, uint64(i), receipt.TransactionIndex)
+		}
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		block, err := client.Block(ctx, "pending")
+		require.NoError(t, err)
+		assert.Equal(t, "PENDING", block.Status)
+		assert.Equal(t, blocks[1].Hash, block.ParentHash)
+
+		update, pendingBlock, err := local.StateUpdatePendingWithBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, blocks[1].Number+1, pendingBlock.Number)
+		assert.Equal(t, updates[1].NewRoot, update.OldRoot)
+	})
+
+	t.Run("signature", func(t *testing.T) {
+		for i := range blocks {
+			want, err := upstream.Signature(ctx, strconv.Itoa(i))
+			require.NoError(t, err)
+			got, err := client.Signature(ctx, strconv.Itoa(i))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("classes", func(t *testing.T) {
+		for _, hash := range classHashes {
+			classHash := utils.HexToFelt(t, hash)
+			want, err := upstream.ClassDefinition(ctx, classHash)
+			require.NoError(t, err)
+			got, err := client.ClassDefinition(ctx, classHash)
+			require.NoError(t, err)
+			assertJSONEq(t, want, got)
+		}
+
+		// syncing from the gateway must yield the same class hash
+		cairo1Hash := utils.HexToFelt(t, classHashes[1])
+		class, err := local.Class(ctx, cairo1Hash)
+		require.NoError(t, err)
+		hash, err := class.Hash()
+		require.NoError(t, err)
+		assert.Equal(t, cairo1Hash, hash)
+
+		want, err := upstream.CompiledClassDefinition(ctx, cairo1Hash)
+		require.NoError(t, err)
+		got, err := client.CompiledClassDefinition(ctx, cairo1Hash)
+		require.NoError(t, err)
+		assertJSONEq(t, want, got)
+
+		_, err = client.CompiledClassDefinition(ctx, utils.HexToFelt(t, classHashes[0]))
+		require.ErrorIs(t, err, feeder.ErrDeprecatedCompiledClass)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := map[string]struct {
+			query  string
+			status int
+			code   string
+		}{
+			"unknown block": {
+				query:  "get_block?blockNumber=100",
+				status: http.StatusBadRequest,
+				code:   "StarknetErrorCode.BLOCK_NOT_FOUND",
+			},
+			"unknown block hash": {
+				query:  "get_state_update?blockHash=0x1",
+				status: http.StatusBadRequest,
+				code:   "StarknetErrorCode.BLOCK_NOT_FOUND",
+			},
+			"invalid block number": {
+				query:  "get_block?blockNumber=first",
+				status: http.StatusBadRequest,
+				code:   "StarknetErrorCode.MALFORMED_REQUEST",
+			},
+			"pending signature": {
+				query:  "get_signature?blockNumber=pending",
+				status: http.StatusBadRequest,
+				code:   "StarknetErrorCode.BLOCK_NOT_FOUND",
+			},
+			"undeclared class": {
+				query:  "get_class_by_hash?classHash=0x1",
+				status: http.StatusBadRequest,
+				code:   "StarknetErrorCode.UNDECLARED_CLASS",
+			},
+			"class at unknown block": {
+				query:  "get_class_by_hash?blockNumber=5&classHash=" + classHashes[0],
+				status: http.StatusBadRequest,
+				code:   "StarknetErrorCode.BLOCK_NOT_FOUND",
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				res, err := http.Get(srv.URL + "/feeder_gateway/" + test.query) //nolint:noctx
+				require.NoError(t, err)
+				defer res.Body.Close()
+				assert.Equal(t, test.status, res.StatusCode)
+
+				var body feedergateway.Error
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.Equal(t, test.code, body.Code)
+			})
+		}
+	})
+}
+
+func assertJSONEq(t *testing.T, want, got any) {
+	t.Helper()
+
+	wantJSON, err := json.Marshal(want)
+	require.NoError(t, err)
+	gotJSON, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(wantJSON), string(gotJSON))
+}
@@ -0,0 +1,80 @@
+// Package graphql serves the chain over GraphQL, which lets clients fetch a block together with its transactions,
+// receipts, state update and classes in a single request.
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// DefaultMaxDepth is the default maximum nesting depth of queries
+	DefaultMaxDepth = 12
+	// DefaultMaxComplexity is the default maximum number of database reads a query can make
+	DefaultMaxComplexity = 10_000
+
+	maxRequestSize = utils.Megabyte
+)
+
+type Handler struct {
+	bcReader      blockchain.Reader
+	log           utils.SimpleLogger
+	schema        *graphql.Schema
+	maxComplexity uint
+}
+
+func New(bcReader blockchain.Reader, log utils.SimpleLogger) *Handler {
+	return (&Handler{
+		bcReader: bcReader,
+		log:      log,
+	}).WithLimits(DefaultMaxDepth, DefaultMaxComplexity)
+}
+
+// WithLimits sets the maximum nesting depth of queries and the maximum number of database reads they can make
+func (h *Handler) WithLimits(maxDepth, maxComplexity uint) *Handler {
+	h.schema = graphql.MustParseSchema(schema, &queryResolver{},
+		graphql.UseFieldResolvers(),
+		graphql.MaxDepth(int(maxDepth)),
+	)
+	h.maxComplexity = maxComplexity
+	return h
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	l := newLoader(h.bcReader, h.maxComplexity)
+	response := h.schema.Exec(withLoader(r.Context(), l), req.Query, req.OperationName, req.Variables)
+	if err := l.close(); err != nil {
+		h.log.Warnw("Failed to close state", "err", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.log.Warnw("Failed to write GraphQL response", "err", err)
+	}
+}
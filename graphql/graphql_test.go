@@ -0,0 +1,213 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/graphql"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, handler http.Handler, q string, variables map[string]any) response {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": q, "variables": variables})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res
+}
+
+func TestGraphQL(t *testing.T) {
+	n := &utils.Mainnet
+	chain := blockchain.New(pebble.NewMemTest(t), n)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+
+	var blocks []*core.Block
+	var updates []*core.StateUpdate
+	for i := uint64(0); i < 3; i++ {
+		b, err := gw.BlockByNumber(context.Background(), i)
+		require.NoError(t, err)
+		su, err := gw.StateUpdate(context.Background(), i)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(b, &core.BlockCommitments{}, su, nil))
+		blocks = append(blocks, b)
+		updates = append(updates, su)
+	}
+
+	handler := graphql.New(chain, utils.NewNopZapLogger())
+
+	t.Run("block with transactions and receipts", func(t *testing.T) {
+		res := query(t, handler, `{
+			block(number: 1) {
+				number hash parentHash transactionCount
+				parent { number }
+				transactions { hash index type receipt { actualFee feeUnit reverted events { from keys data } } }
+			}
+		}`, nil)
+		require.Empty(t, res.Errors)
+
+		var data struct {
+			Block struct {
+				Number           uint64
+				Hash             string
+				ParentHash       string
+				TransactionCount uint64
+				Parent           struct{ Number uint64 }
+				Transactions     []struct {
+					Hash    string
+					Index   uint64
+					Type    string
+					Receipt struct {
+						ActualFee string
+						FeeUnit   string
+						Reverted  bool
+						Events    []struct{ From string }
+					}
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(res.Data, &data))
+
+		block := blocks[1]
+		assert.Equal(t, uint64(1), data.Block.Number)
+		assert.Equal(t, block.Hash.String(), data.Block.Hash)
+		assert.Equal(t, block.ParentHash.String(), data.Block.ParentHash)
+		assert.Equal(t, uint64(0), data.Block.Parent.Number)
+		assert.Equal(t, block.TransactionCount, data.Block.TransactionCount)
+		require.Len(t, data.Block.Transactions, len(block.Transactions))
+		for i, txn := range data.Block.Transactions {
+			assert.Equal(t, block.Transactions[i].Hash().String(), txn.Hash)
+			assert.Equal(t, uint64(i), txn.Index)
+			assert.NotEmpty(t, txn.Type)
+			assert.Equal(t, "WEI", txn.Receipt.FeeUnit)
+			assert.Len(t, txn.Receipt.Events, len(block.Receipts[i].Events))
+		}
+	})
+
+	t.Run("block by hash and latest block", func(t *testing.T) {
+		res := query(t, handler, `query($hash: Felt) { byHash: block(hash: $hash) { number } latest: block { number } }`,
+			map[string]any{"hash": blocks[2].Hash.String()})
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"byHash":{"number":2},"latest":{"number":2}}`, string(res.Data))
+	})
+
+	t.Run("missing objects are null", func(t *testing.T) {
+		res := query(t, handler, `{ block(number: 100) { number } transaction(hash: "0x1") { hash } }`, nil)
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"block":null,"transaction":null}`, string(res.Data))
+	})
+
+	t.Run("blocks", func(t *testing.T) {
+		res := query(t, handler, `{ blocks(from: 1) { number } }`, nil)
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"blocks":[{"number":1},{"number":2}]}`, string(res.Data))
+
+		res = query(t, handler, `{ blocks(from: 2, to: 1) { number } }`, nil)
+		require.Len(t, res.Errors, 1)
+
+		res = query(t, handler, `{ blocks(from: 0, to: "0x1000") { number } }`, nil)
+		require.Len(t, res.Errors, 1)
+		assert.Contains(t, res.Errors[0].Message, "too many blocks")
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		txn := blocks[2].Transactions[0]
+		res := query(t, handler, `query($hash: Felt!) { transaction(hash: $hash) { hash index block { number } } }`,
+			map[string]any{"hash": txn.Hash().String()})
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"transaction":{"hash":"`+txn.Hash().String()+`","index":0,"block":{"number":2}}}`, string(res.Data))
+	})
+
+	t.Run("contract storage at block", func(t *testing.T) {
+		var addr, key felt.Felt
+		var value *felt.Felt
+		for addr = range updates[0].StateDiff.StorageDiffs {
+			for key, value = range updates[0].StateDiff.StorageDiffs[addr] {
+				break
+			}
+			break
+		}
+
+		res := query(t, handler, `query($addr: Felt!, $key: Felt!) {
+			contract(address: $addr, block: 0) { address classHash nonce storage(key: $key) }
+		}`, map[string]any{"addr": addr.String(), "key": key.String()})
+		require.Empty(t, res.Errors)
+
+		var data struct {
+			Contract struct {
+				Address   string
+				ClassHash string
+				Nonce     string
+				Storage   string
+			}
+		}
+		require.NoError(t, json.Unmarshal(res.Data, &data))
+		assert.Equal(t, addr.String(), data.Contract.Address)
+		assert.Equal(t, updates[0].StateDiff.DeployedContracts[addr].String(), data.Contract.ClassHash)
+		assert.Equal(t, "0x0", data.Contract.Nonce)
+		assert.Equal(t, value.String(), data.Contract.Storage)
+	})
+
+	t.Run("state update", func(t *testing.T) {
+		res := query(t, handler, `{ block(number: 0) { stateUpdate { newRoot deployedContracts { address classHash } } } }`, nil)
+		require.Empty(t, res.Errors)
+
+		var data struct {
+			Block struct {
+				StateUpdate struct {
+					NewRoot           string
+					DeployedContracts []struct{ Address, ClassHash string }
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(res.Data, &data))
+		assert.Equal(t, updates[0].NewRoot.String(), data.Block.StateUpdate.NewRoot)
+		assert.Len(t, data.Block.StateUpdate.DeployedContracts, len(updates[0].StateDiff.DeployedContracts))
+	})
+
+	t.Run("complexity limit", func(t *testing.T) {
+		limited := graphql.New(chain, utils.NewNopZapLogger()).WithLimits(graphql.DefaultMaxDepth, 3)
+		res := query(t, limited, `{ blocks(from: 0, to: 2) { number } }`, nil)
+		require.Empty(t, res.Errors)
+
+		res = query(t, limited, `{ blocks(from: 0, to: 2) { transactions { hash } } }`, nil)
+		require.NotEmpty(t, res.Errors)
+		assert.Contains(t, res.Errors[0].Message, graphql.ErrTooComplex.Error())
+	})
+
+	t.Run("depth limit", func(t *testing.T) {
+		limited := graphql.New(chain, utils.NewNopZapLogger()).WithLimits(2, graphql.DefaultMaxComplexity)
+		res := query(t, limited, `{ block { parent { parent { number } } } }`, nil)
+		require.NotEmpty(t, res.Errors)
+	})
+
+	t.Run("only POST is supported", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql", http.NoBody))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,279 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+var ErrTooComplex = errors.New("query exceeds the complexity limit")
+
+// loader reads the data a query needs from the database and keeps it for the rest of the query, so that blocks,
+// state updates and states shared by several fields are only read once. Every read counts towards the complexity
+// limit of the query.
+//
+// Resolvers run concurrently, reads are serialised by the loader.
+type loader struct {
+	bcReader blockchain.Reader
+	maxReads uint
+
+	mu      sync.Mutex
+	reads   uint
+	height  *uint64
+	headers map[uint64]*core.Header
+	blocks  map[uint64]*core.Block
+	updates map[uint64]*core.StateUpdate
+	states  map[uint64]core.StateReader
+	classes map[classKey]*core.DeclaredClass
+	closers []blockchain.StateCloser
+}
+
+type classKey struct {
+	number uint64
+	hash   felt.Felt
+}
+
+func newLoader(bcReader blockchain.Reader, maxReads uint) *loader {
+	return &loader{
+		bcReader: bcReader,
+		maxReads: maxReads,
+		headers:  make(map[uint64]*core.Header),
+		blocks:   make(map[uint64]*core.Block),
+		updates:  make(map[uint64]*core.StateUpdate),
+		states:   make(map[uint64]core.StateReader),
+		classes:  make(map[classKey]*core.DeclaredClass),
+	}
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, l *loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func loaderFrom(ctx context.Context) *loader {
+	return ctx.Value(loaderKey{}).(*loader)
+}
+
+// charge counts a read, the caller must hold the lock
+func (l *loader) charge() error {
+	l.reads++
+	if l.reads > l.maxReads {
+		return ErrTooComplex
+	}
+	return nil
+}
+
+// latest returns the height of the chain when the query first needed it, so that the whole query sees the same
+// head even if blocks are added while it runs
+func (l *loader) latest() (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.height != nil {
+		return *l.height, nil
+	}
+	if err := l.charge(); err != nil {
+		return 0, err
+	}
+	height, err := l.bcReader.Height()
+	if err != nil {
+		return 0, err
+	}
+	l.height = &height
+	return height, nil
+}
+
+// resolve returns number, or the latest block number if it is nil
+func (l *loader) resolve(number *Long) (uint64, error) {
+	if number != nil {
+		return uint64(*number), nil
+	}
+	return l.latest()
+}
+
+func (l *loader) header(number uint64) (*core.Header, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if block, found := l.blocks[number]; found {
+		return block.Header, nil
+	}
+	if header, found := l.headers[number]; found {
+		return header, nil
+	}
+	if err := l.charge(); err != nil {
+		return nil, err
+	}
+	header, err := l.bcReader.BlockHeaderByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	l.headers[number] = header
+	return header, nil
+}
+
+func (l *loader) headerByHash(hash *felt.Felt) (*core.Header, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.charge(); err != nil {
+		return nil, err
+	}
+	header, err := l.bcReader.BlockHeaderByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	l.headers[header.Number] = header
+	return header, nil
+}
+
+func (l *loader) block(number uint64) (*core.Block, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if block, found := l.blocks[number]; found {
+		return block, nil
+	}
+	if err := l.charge(); err != nil {
+		return nil, err
+	}
+	block, err := l.bcReader.BlockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	l.blocks[number] = block
+	return block, nil
+}
+
+// transactionBlock returns the number of the block the transaction is in
+func (l *loader) transactionBlock(hash *felt.Felt) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.charge(); err != nil {
+		return 0, err
+	}
+	_, blockHash, blockNumber, err := l.bcReader.Receipt(hash)
+	if err != nil {
+		return 0, err
+	}
+	if blockHash == nil {
+		// pending transactions are not part of the chain yet
+		return 0, errNotFound
+	}
+	return blockNumber, nil
+}
+
+func (l *loader) stateUpdate(number uint64) (*core.StateUpdate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if update, found := l.updates[number]; found {
+		return update, nil
+	}
+	if err := l.charge(); err != nil {
+		return nil, err
+	}
+	update, err := l.bcReader.StateUpdateByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	l.updates[number] = update
+	return update, nil
+}
+
+// state returns the state as of the given block, the state is open until the query is done
+func (l *loader) state(number uint64) (*lockedState, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state, found := l.states[number]
+	if !found {
+		if err := l.charge(); err != nil {
+			return nil, err
+		}
+
+		var closer blockchain.StateCloser
+		var err error
+		if state, closer, err = l.bcReader.StateAtBlockNumber(number); err != nil {
+			return nil, err
+		}
+		l.states[number] = state
+		l.closers = append(l.closers, closer)
+	}
+	return &lockedState{l: l, number: number, state: state}, nil
+}
+
+// close closes the states opened by the query
+func (l *loader) close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []error
+	for _, closer := range l.closers {
+		errs = append(errs, closer())
+	}
+	l.closers = nil
+	return errors.Join(errs...)
+}
+
+// lockedState reads a state under the lock of its loader and charges every read
+type lockedState struct {
+	l      *loader
+	number uint64
+	state  core.StateReader
+}
+
+func (s *lockedState) classHash(addr *felt.Felt) (*felt.Felt, error) {
+	s.l.mu.Lock()
+	defer s.l.mu.Unlock()
+
+	if err := s.l.charge(); err != nil {
+		return nil, err
+	}
+	return s.state.ContractClassHash(addr)
+}
+
+func (s *lockedState) nonce(addr *felt.Felt) (*felt.Felt, error) {
+	s.l.mu.Lock()
+	defer s.l.mu.Unlock()
+
+	if err := s.l.charge(); err != nil {
+		return nil, err
+	}
+	return s.state.ContractNonce(addr)
+}
+
+func (s *lockedState) storage(addr, key *felt.Felt) (*felt.Felt, error) {
+	s.l.mu.Lock()
+	defer s.l.mu.Unlock()
+
+	if err := s.l.charge(); err != nil {
+		return nil, err
+	}
+	return s.state.ContractStorage(addr, key)
+}
+
+func (s *lockedState) class(hash *felt.Felt) (*core.DeclaredClass, error) {
+	s.l.mu.Lock()
+	defer s.l.mu.Unlock()
+
+	key := classKey{number: s.number, hash: *hash}
+	if class, found := s.l.classes[key]; found {
+		return class, nil
+	}
+	if err := s.l.charge(); err != nil {
+		return nil, err
+	}
+	class, err := s.state.Class(hash)
+	if err != nil {
+		return nil, err
+	}
+	s.l.classes[key] = class
+	return class, nil
+}
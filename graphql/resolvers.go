@@ -0,0 +1,662 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+)
+
+var (
+	errNotFound      = errors.New("not found")
+	errInvalidRange  = errors.New("from must not be greater than to")
+	errAmbiguousID   = errors.New("only one of number and hash can be set")
+	errRangeTooLarge = errors.New("too many blocks requested")
+)
+
+// maxBlockRange is the maximum number of blocks the blocks query returns
+const maxBlockRange = 100
+
+// orNil returns nil instead of the error if err means that the requested object doesn't exist, so that it is
+// returned as null
+func orNil[T any](resolver *T, err error) (*T, error) {
+	if errors.Is(err, db.ErrKeyNotFound) || errors.Is(err, errNotFound) || errors.Is(err, core.ErrContractNotDeployed) {
+		return nil, nil
+	}
+	return resolver, err
+}
+
+type queryResolver struct{}
+
+type blockArgs struct {
+	Number *Long
+	Hash   *Felt
+}
+
+func (r *queryResolver) Block(ctx context.Context, args blockArgs) (*blockResolver, error) {
+	l := loaderFrom(ctx)
+	if args.Number != nil && args.Hash != nil {
+		return nil, errAmbiguousID
+	}
+
+	if args.Hash != nil {
+		header, err := l.headerByHash(args.Hash.felt())
+		if err != nil {
+			return orNil[blockResolver](nil, err)
+		}
+		return &blockResolver{l: l, header: header}, nil
+	}
+
+	number, err := l.resolve(args.Number)
+	if err != nil {
+		return nil, err
+	}
+	return orNil(newBlockResolver(l, number))
+}
+
+type blocksArgs struct {
+	From Long
+	To   *Long
+}
+
+func (r *queryResolver) Blocks(ctx context.Context, args blocksArgs) ([]*blockResolver, error) {
+	l := loaderFrom(ctx)
+	to, err := l.resolve(args.To)
+	if err != nil {
+		return nil, err
+	}
+	from := uint64(args.From)
+	if from > to {
+		return nil, errInvalidRange
+	}
+	if to-from >= maxBlockRange {
+		return nil, errRangeTooLarge
+	}
+
+	blocks := make([]*blockResolver, 0, to-from+1)
+	for number := from; number <= to; number++ {
+		block, err := newBlockResolver(l, number)
+		if errors.Is(err, db.ErrKeyNotFound) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+func (r *queryResolver) Transaction(ctx context.Context, args struct{ Hash Felt }) (*transactionResolver, error) {
+	l := loaderFrom(ctx)
+	number, err := l.transactionBlock(args.Hash.felt())
+	if err != nil {
+		return orNil[transactionResolver](nil, err)
+	}
+
+	block, err := l.block(number)
+	if err != nil {
+		return nil, err
+	}
+	for index, txn := range block.Transactions {
+		if txn.Hash().Equal(args.Hash.felt()) {
+			return newTransactionResolver(l, block, index), nil
+		}
+	}
+	return nil, nil
+}
+
+type contractArgs struct {
+	Address Felt
+	Block   *Long
+}
+
+func (r *queryResolver) Contract(ctx context.Context, args contractArgs) (*contractResolver, error) {
+	l := loaderFrom(ctx)
+	number, err := l.resolve(args.Block)
+	if err != nil {
+		return nil, err
+	}
+	return orNil(newContractResolver(l, number, args.Address.felt()))
+}
+
+type classArgs struct {
+	Hash  Felt
+	Block *Long
+}
+
+func (r *queryResolver) Class(ctx context.Context, args classArgs) (*classResolver, error) {
+	l := loaderFrom(ctx)
+	number, err := l.resolve(args.Block)
+	if err != nil {
+		return nil, err
+	}
+	return orNil(newClassResolver(l, number, args.Hash.felt()))
+}
+
+type blockResolver struct {
+	l      *loader
+	header *core.Header
+}
+
+func newBlockResolver(l *loader, number uint64) (*blockResolver, error) {
+	header, err := l.header(number)
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{l: l, header: header}, nil
+}
+
+func (b *blockResolver) Number() Long {
+	return Long(b.header.Number)
+}
+
+func (b *blockResolver) Hash() Felt {
+	return feltOf(b.header.Hash)
+}
+
+func (b *blockResolver) Parent() (*blockResolver, error) {
+	if b.header.Number == 0 {
+		return nil, nil
+	}
+	return orNil(newBlockResolver(b.l, b.header.Number-1))
+}
+
+func (b *blockResolver) ParentHash() Felt {
+	return feltOf(b.header.ParentHash)
+}
+
+func (b *blockResolver) Timestamp() Long {
+	return Long(b.header.Timestamp)
+}
+
+func (b *blockResolver) SequencerAddress() *Felt {
+	return optionalFelt(b.header.SequencerAddress)
+}
+
+func (b *blockResolver) StateRoot() Felt {
+	return feltOf(b.header.GlobalStateRoot)
+}
+
+func (b *blockResolver) ProtocolVersion() string {
+	return b.header.ProtocolVersion
+}
+
+func (b *blockResolver) L1GasPrice() *Felt {
+	return optionalFelt(b.header.GasPrice)
+}
+
+func (b *blockResolver) L1GasPriceFri() *Felt {
+	return optionalFelt(b.header.GasPriceSTRK)
+}
+
+func (b *blockResolver) TransactionCount() Long {
+	return Long(b.header.TransactionCount)
+}
+
+func (b *blockResolver) EventCount() Long {
+	return Long(b.header.EventCount)
+}
+
+func (b *blockResolver) Transactions() ([]*transactionResolver, error) {
+	block, err := b.l.block(b.header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*transactionResolver, len(block.Transactions))
+	for index := range block.Transactions {
+		transactions[index] = newTransactionResolver(b.l, block, index)
+	}
+	return transactions, nil
+}
+
+func (b *blockResolver) StateUpdate() (*stateUpdateResolver, error) {
+	update, err := b.l.stateUpdate(b.header.Number)
+	if err != nil {
+		return nil, err
+	}
+	return &stateUpdateResolver{l: b.l, number: b.header.Number, update: update}, nil
+}
+
+type transactionResolver struct {
+	l       *loader
+	number  uint64
+	index   int
+	txn     core.Transaction
+	receipt *core.TransactionReceipt
+}
+
+func newTransactionResolver(l *loader, block *core.Block, index int) *transactionResolver {
+	return &transactionResolver{
+		l:       l,
+		number:  block.Number,
+		index:   index,
+		txn:     block.Transactions[index],
+		receipt: block.Receipts[index],
+	}
+}
+
+func (t *transactionResolver) Hash() Felt {
+	return feltOf(t.txn.Hash())
+}
+
+func (t *transactionResolver) Type() string {
+	switch t.txn.(type) {
+	case *core.InvokeTransaction:
+		return "INVOKE"
+	case *core.DeclareTransaction:
+		return "DECLARE"
+	case *core.DeployTransaction:
+		return "DEPLOY"
+	case *core.DeployAccountTransaction:
+		return "DEPLOY_ACCOUNT"
+	case *core.L1HandlerTransaction:
+		return "L1_HANDLER"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (t *transactionResolver) Version() Felt {
+	if version := t.txn.TxVersion(); version != nil {
+		return feltOf(version.AsFelt())
+	}
+	return Felt{}
+}
+
+func (t *transactionResolver) Block() (*blockResolver, error) {
+	return newBlockResolver(t.l, t.number)
+}
+
+func (t *transactionResolver) Index() Long {
+	return Long(t.index)
+}
+
+func (t *transactionResolver) SenderAddress() *Felt {
+	switch txn := t.txn.(type) {
+	case *core.InvokeTransaction:
+		if txn.SenderAddress != nil {
+			return optionalFelt(txn.SenderAddress)
+		}
+		return optionalFelt(txn.ContractAddress)
+	case *core.DeclareTransaction:
+		return optionalFelt(txn.SenderAddress)
+	case *core.DeployTransaction:
+		return optionalFelt(txn.ContractAddress)
+	case *core.DeployAccountTransaction:
+		return optionalFelt(txn.ContractAddress)
+	case *core.L1HandlerTransaction:
+		return optionalFelt(txn.ContractAddress)
+	default:
+		return nil
+	}
+}
+
+func (t *transactionResolver) Nonce() *Felt {
+	switch txn := t.txn.(type) {
+	case *core.InvokeTransaction:
+		return optionalFelt(txn.Nonce)
+	case *core.DeclareTransaction:
+		return optionalFelt(txn.Nonce)
+	case *core.DeployAccountTransaction:
+		return optionalFelt(txn.Nonce)
+	case *core.L1HandlerTransaction:
+		return optionalFelt(txn.Nonce)
+	default:
+		return nil
+	}
+}
+
+func (t *transactionResolver) Calldata() []Felt {
+	switch txn := t.txn.(type) {
+	case *core.InvokeTransaction:
+		return feltsOf(txn.CallData)
+	case *core.DeployTransaction:
+		return feltsOf(txn.ConstructorCallData)
+	case *core.DeployAccountTransaction:
+		return feltsOf(txn.ConstructorCallData)
+	case *core.L1HandlerTransaction:
+		return feltsOf(txn.CallData)
+	default:
+		return []Felt{}
+	}
+}
+
+func (t *transactionResolver) Signature() []Felt {
+	return feltsOf(t.txn.Signature())
+}
+
+func (t *transactionResolver) ClassHash() *Felt {
+	switch txn := t.txn.(type) {
+	case *core.DeclareTransaction:
+		return optionalFelt(txn.ClassHash)
+	case *core.DeployTransaction:
+		return optionalFelt(txn.ClassHash)
+	case *core.DeployAccountTransaction:
+		return optionalFelt(txn.ClassHash)
+	default:
+		return nil
+	}
+}
+
+func (t *transactionResolver) Receipt() *receiptResolver {
+	return &receiptResolver{receipt: t.receipt}
+}
+
+type receiptResolver struct {
+	receipt *core.TransactionReceipt
+}
+
+func (r *receiptResolver) ActualFee() Felt {
+	return feltOf(r.receipt.Fee)
+}
+
+func (r *receiptResolver) FeeUnit() string {
+	if r.receipt.FeeUnit == core.STRK {
+		return "FRI"
+	}
+	return "WEI"
+}
+
+func (r *receiptResolver) Reverted() bool {
+	return r.receipt.Reverted
+}
+
+func (r *receiptResolver) RevertReason() *string {
+	if r.receipt.RevertReason == "" {
+		return nil
+	}
+	return &r.receipt.RevertReason
+}
+
+func (r *receiptResolver) Events() []*eventResolver {
+	events := make([]*eventResolver, len(r.receipt.Events))
+	for i, event := range r.receipt.Events {
+		events[i] = &eventResolver{event: event}
+	}
+	return events
+}
+
+func (r *receiptResolver) MessagesSent() []*messageResolver {
+	messages := make([]*messageResolver, len(r.receipt.L2ToL1Message))
+	for i, message := range r.receipt.L2ToL1Message {
+		messages[i] = &messageResolver{message: message}
+	}
+	return messages
+}
+
+type eventResolver struct {
+	event *core.Event
+}
+
+func (e *eventResolver) From() Felt {
+	return feltOf(e.event.From)
+}
+
+func (e *eventResolver) Keys() []Felt {
+	return feltsOf(e.event.Keys)
+}
+
+func (e *eventResolver) Data() []Felt {
+	return feltsOf(e.event.Data)
+}
+
+type messageResolver struct {
+	message *core.L2ToL1Message
+}
+
+func (m *messageResolver) From() Felt {
+	return feltOf(m.message.From)
+}
+
+func (m *messageResolver) To() string {
+	return m.message.To.Hex()
+}
+
+func (m *messageResolver) Payload() []Felt {
+	return feltsOf(m.message.Payload)
+}
+
+type stateUpdateResolver struct {
+	l      *loader
+	number uint64
+	update *core.StateUpdate
+}
+
+func (s *stateUpdateResolver) BlockHash() Felt {
+	return feltOf(s.update.BlockHash)
+}
+
+func (s *stateUpdateResolver) NewRoot() Felt {
+	return feltOf(s.update.NewRoot)
+}
+
+func (s *stateUpdateResolver) OldRoot() Felt {
+	return feltOf(s.update.OldRoot)
+}
+
+type storageDiffResolver struct {
+	Address Felt
+	Key     Felt
+	Value   Felt
+}
+
+// sortedKeys returns the keys of m in ascending order, so that the results of queries are deterministic
+func sortedKeys[V any](m map[felt.Felt]V) []felt.Felt {
+	keys := make([]felt.Felt, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b felt.Felt) int {
+		return a.Cmp(&b)
+	})
+	return keys
+}
+
+func (s *stateUpdateResolver) StorageDiffs() []*storageDiffResolver {
+	var diffs []*storageDiffResolver
+	for _, addr := range sortedKeys(s.update.StateDiff.StorageDiffs) {
+		storage := s.update.StateDiff.StorageDiffs[addr]
+		for _, key := range sortedKeys(storage) {
+			diffs = append(diffs, &storageDiffResolver{
+				Address: Felt(addr),
+				Key:     Felt(key),
+				Value:   feltOf(storage[key]),
+			})
+		}
+	}
+	return diffs
+}
+
+type nonceUpdateResolver struct {
+	Address Felt
+	Nonce   Felt
+}
+
+func (s *stateUpdateResolver) Nonces() []*nonceUpdateResolver {
+	nonces := make([]*nonceUpdateResolver, 0, len(s.update.StateDiff.Nonces))
+	for _, addr := range sortedKeys(s.update.StateDiff.Nonces) {
+		nonces = append(nonces, &nonceUpdateResolver{
+			Address: Felt(addr),
+			Nonce:   feltOf(s.update.StateDiff.Nonces[addr]),
+		})
+	}
+	return nonces
+}
+
+func (s *stateUpdateResolver) deployedContracts(contracts map[felt.Felt]*felt.Felt) []*deployedContractResolver {
+	deployed := make([]*deployedContractResolver, 0, len(contracts))
+	for _, addr := range sortedKeys(contracts) {
+		deployed = append(deployed, &deployedContractResolver{
+			l:         s.l,
+			number:    s.number,
+			address:   addr,
+			classHash: feltOf(contracts[addr]),
+		})
+	}
+	return deployed
+}
+
+func (s *stateUpdateResolver) DeployedContracts() []*deployedContractResolver {
+	return s.deployedContracts(s.update.StateDiff.DeployedContracts)
+}
+
+func (s *stateUpdateResolver) ReplacedClasses() []*deployedContractResolver {
+	return s.deployedContracts(s.update.StateDiff.ReplacedClasses)
+}
+
+func (s *stateUpdateResolver) DeclaredClasses() []*declaredClassResolver {
+	declared := make([]*declaredClassResolver, 0, len(s.update.StateDiff.DeclaredV0Classes)+len(s.update.StateDiff.DeclaredV1Classes))
+	for _, classHash := range s.update.StateDiff.DeclaredV0Classes {
+		declared = append(declared, &declaredClassResolver{
+			l:         s.l,
+			number:    s.number,
+			classHash: feltOf(classHash),
+		})
+	}
+	for _, classHash := range sortedKeys(s.update.StateDiff.DeclaredV1Classes) {
+		declared = append(declared, &declaredClassResolver{
+			l:                 s.l,
+			number:            s.number,
+			classHash:         Felt(classHash),
+			compiledClassHash: optionalFelt(s.update.StateDiff.DeclaredV1Classes[classHash]),
+		})
+	}
+	return declared
+}
+
+type deployedContractResolver struct {
+	l         *loader
+	number    uint64
+	address   felt.Felt
+	classHash Felt
+}
+
+func (d *deployedContractResolver) Address() Felt {
+	return Felt(d.address)
+}
+
+func (d *deployedContractResolver) ClassHash() Felt {
+	return d.classHash
+}
+
+func (d *deployedContractResolver) Contract() (*contractResolver, error) {
+	return orNil(newContractResolver(d.l, d.number, &d.address))
+}
+
+type declaredClassResolver struct {
+	l                 *loader
+	number            uint64
+	classHash         Felt
+	compiledClassHash *Felt
+}
+
+func (d *declaredClassResolver) ClassHash() Felt {
+	return d.classHash
+}
+
+func (d *declaredClassResolver) CompiledClassHash() *Felt {
+	return d.compiledClassHash
+}
+
+func (d *declaredClassResolver) Class() (*classResolver, error) {
+	return orNil(newClassResolver(d.l, d.number, d.classHash.felt()))
+}
+
+type contractResolver struct {
+	state     *lockedState
+	address   *felt.Felt
+	classHash *felt.Felt
+}
+
+func newContractResolver(l *loader, number uint64, address *felt.Felt) (*contractResolver, error) {
+	state, err := l.state(number)
+	if err != nil {
+		return nil, err
+	}
+	classHash, err := state.classHash(address)
+	if err != nil {
+		return nil, err
+	}
+	return &contractResolver{
+		state:     state,
+		address:   address,
+		classHash: classHash,
+	}, nil
+}
+
+func (c *contractResolver) Address() Felt {
+	return feltOf(c.address)
+}
+
+func (c *contractResolver) ClassHash() Felt {
+	return feltOf(c.classHash)
+}
+
+func (c *contractResolver) Nonce() (Felt, error) {
+	nonce, err := c.state.nonce(c.address)
+	return feltOf(nonce), err
+}
+
+func (c *contractResolver) Storage(args struct{ Key Felt }) (Felt, error) {
+	value, err := c.state.storage(c.address, args.Key.felt())
+	return feltOf(value), err
+}
+
+func (c *contractResolver) Class() (*classResolver, error) {
+	return classResolverFor(c.state, c.classHash)
+}
+
+type classResolver struct {
+	hash     *felt.Felt
+	declared *core.DeclaredClass
+}
+
+func newClassResolver(l *loader, number uint64, hash *felt.Felt) (*classResolver, error) {
+	state, err := l.state(number)
+	if err != nil {
+		return nil, err
+	}
+	return classResolverFor(state, hash)
+}
+
+func classResolverFor(state *lockedState, hash *felt.Felt) (*classResolver, error) {
+	declared, err := state.class(hash)
+	if err != nil {
+		return nil, err
+	}
+	return &classResolver{hash: hash, declared: declared}, nil
+}
+
+func (c *classResolver) Hash() Felt {
+	return feltOf(c.hash)
+}
+
+func (c *classResolver) CairoVersion() Long {
+	return Long(c.declared.Class.Version())
+}
+
+func (c *classResolver) DeclaredAt() Long {
+	return Long(c.declared.At)
+}
+
+func (c *classResolver) Abi() string {
+	switch class := c.declared.Class.(type) {
+	case *core.Cairo0Class:
+		return string(class.Abi)
+	case *core.Cairo1Class:
+		return class.Abi
+	default:
+		return ""
+	}
+}
+
+func (c *classResolver) CompiledClassHash() *Felt {
+	if class, ok := c.declared.Class.(*core.Cairo1Class); ok && class.Compiled != nil {
+		return optionalFelt(class.Compiled.Hash())
+	}
+	return nil
+}
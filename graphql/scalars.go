@@ -0,0 +1,85 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+// Felt is the GraphQL scalar of field elements
+type Felt felt.Felt
+
+func feltOf(f *felt.Felt) Felt {
+	if f == nil {
+		return Felt{}
+	}
+	return Felt(*f)
+}
+
+// optionalFelt returns nil if f is nil, for nullable fields
+func optionalFelt(f *felt.Felt) *Felt {
+	return (*Felt)(f)
+}
+
+func feltsOf(fs []*felt.Felt) []Felt {
+	result := make([]Felt, len(fs))
+	for i, f := range fs {
+		result[i] = feltOf(f)
+	}
+	return result
+}
+
+func (f *Felt) felt() *felt.Felt {
+	return (*felt.Felt)(f)
+}
+
+func (Felt) ImplementsGraphQLType(name string) bool {
+	return name == "Felt"
+}
+
+func (f *Felt) UnmarshalGraphQL(input any) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for Felt", input)
+	}
+	_, err := f.felt().SetString(s)
+	return err
+}
+
+func (f Felt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.felt().String())
+}
+
+// Long is the GraphQL scalar of unsigned 64-bit integers, which don't fit the 32-bit Int scalar
+type Long uint64
+
+func (Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+func (l *Long) UnmarshalGraphQL(input any) error {
+	switch input := input.(type) {
+	case int32:
+		if input < 0 {
+			return fmt.Errorf("negative Long %d", input)
+		}
+		*l = Long(input)
+	case float64:
+		if input < 0 || input > math.MaxUint64 || input != math.Trunc(input) {
+			return fmt.Errorf("invalid Long %v", input)
+		}
+		*l = Long(input)
+	case string:
+		value, err := strconv.ParseUint(input, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Long %q: %w", input, err)
+		}
+		*l = Long(value)
+	default:
+		return fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return nil
+}
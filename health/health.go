@@ -0,0 +1,247 @@
+// Package health reports whether the node is alive and whether it is ready to serve requests, that is whether it is
+// in sync with the network and verifying against L1, in a form that load balancers and orchestrators can probe.
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+)
+
+const (
+	DefaultMaxBlocksBehind = 10
+	DefaultMaxHeadAge      = 30 * time.Minute
+)
+
+const (
+	componentDatabase = "database"
+	componentSync     = "sync"
+	componentHead     = "head"
+	componentL1       = "l1"
+)
+
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDown     Status = "down"
+	StatusDisabled Status = "disabled"
+)
+
+// Component is the status of one of the signals the node's health is computed from
+type Component struct {
+	Status  Status         `json:"status"`
+	Message string         `json:"message,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Report is down if any of its components is down
+type Report struct {
+	Status     Status               `json:"status"`
+	Components map[string]Component `json:"components"`
+}
+
+func newReport(components map[string]Component) *Report {
+	report := &Report{
+		Status:     StatusUp,
+		Components: components,
+	}
+	for _, component := range components {
+		if component.Status == StatusDown {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// L1 is the client that verifies the chain against the state updates on L1
+type L1 interface {
+	Subscribed() bool
+}
+
+type Checker struct {
+	database   db.DB
+	bcReader   blockchain.Reader
+	syncReader sync.Reader
+	l1         L1
+	log        utils.SimpleLogger
+
+	maxBlocksBehind uint64
+	maxHeadAge      time.Duration
+}
+
+func New(database db.DB, bcReader blockchain.Reader, log utils.SimpleLogger) *Checker {
+	return &Checker{
+		database:        database,
+		bcReader:        bcReader,
+		log:             log,
+		maxBlocksBehind: DefaultMaxBlocksBehind,
+		maxHeadAge:      DefaultMaxHeadAge,
+	}
+}
+
+// WithSync sets the synchroniser the distance to the tip of the chain is taken from, the sync component is
+// disabled otherwise
+func (c *Checker) WithSync(syncReader sync.Reader) *Checker {
+	c.syncReader = syncReader
+	return c
+}
+
+// WithL1 sets the L1 client whose subscription must be live, the L1 component is disabled otherwise
+func (c *Checker) WithL1(l1 L1) *Checker {
+	c.l1 = l1
+	return c
+}
+
+// WithThresholds sets how far behind the highest known block and how old the head block can be for the node to be
+// ready
+func (c *Checker) WithThresholds(maxBlocksBehind uint64, maxHeadAge time.Duration) *Checker {
+	c.maxBlocksBehind = maxBlocksBehind
+	c.maxHeadAge = maxHeadAge
+	return c
+}
+
+// Liveness reports whether the node is able to make progress at all, a node that is down should be restarted
+func (c *Checker) Liveness() *Report {
+	return newReport(map[string]Component{
+		componentDatabase: c.checkDatabase(),
+	})
+}
+
+// Readiness reports whether the node serves up to date data, a node that is down should not receive requests
+func (c *Checker) Readiness() *Report {
+	return newReport(map[string]Component{
+		componentDatabase: c.checkDatabase(),
+		componentSync:     c.checkSync(),
+		componentHead:     c.checkHead(),
+		componentL1:       c.checkL1(),
+	})
+}
+
+// LivenessHandler serves the liveness report, with a 503 status if the node is down
+func (c *Checker) LivenessHandler() http.Handler {
+	return c.handler(c.Liveness)
+}
+
+// ReadinessHandler serves the readiness report, with a 503 status if the node is down
+func (c *Checker) ReadinessHandler() http.Handler {
+	return c.handler(c.Readiness)
+}
+
+func (c *Checker) handler(check func() *Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", http.MethodGet+", "+http.MethodHead)
+			http.Error(w, "only GET and HEAD requests are supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		report := check()
+		status := http.StatusOK
+		if report.Status == StatusDown {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			c.log.Debugw("Failed to write health report", "err", err)
+		}
+	})
+}
+
+func down(message string, details map[string]any) Component {
+	return Component{
+		Status:  StatusDown,
+		Message: message,
+		Details: details,
+	}
+}
+
+func (c *Checker) checkDatabase() Component {
+	err := c.database.View(func(txn db.Transaction) error {
+		return txn.Get(db.ChainHeight.Key(), func([]byte) error { return nil })
+	})
+	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+		return down(err.Error(), nil)
+	}
+	return Component{Status: StatusUp}
+}
+
+func (c *Checker) checkSync() Component {
+	if c.syncReader == nil {
+		return Component{Status: StatusDisabled}
+	}
+
+	highest := c.syncReader.HighestBlockHeader()
+	if highest == nil {
+		return down("the highest block of the network is not known yet", nil)
+	}
+	details := map[string]any{
+		"highest":           highest.Number,
+		"max_blocks_behind": c.maxBlocksBehind,
+	}
+
+	height, err := c.bcReader.Height()
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return down("no blocks have been synced yet", details)
+		}
+		return down(err.Error(), details)
+	}
+	details["height"] = height
+
+	var behind uint64
+	if highest.Number > height {
+		behind = highest.Number - height
+	}
+	details["blocks_behind"] = behind
+	if behind > c.maxBlocksBehind {
+		return down("the node is too far behind the highest block", details)
+	}
+	return Component{
+		Status:  StatusUp,
+		Details: details,
+	}
+}
+
+func (c *Checker) checkHead() Component {
+	head, err := c.bcReader.HeadsHeader()
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return down("no blocks have been synced yet", nil)
+		}
+		return down(err.Error(), nil)
+	}
+
+	age := time.Since(time.Unix(int64(head.Timestamp), 0))
+	details := map[string]any{
+		"number":          head.Number,
+		"timestamp":       head.Timestamp,
+		"age_seconds":     int64(age.Seconds()),
+		"max_age_seconds": int64(c.maxHeadAge.Seconds()),
+	}
+	if age > c.maxHeadAge {
+		return down("the head block is too old", details)
+	}
+	return Component{
+		Status:  StatusUp,
+		Details: details,
+	}
+}
+
+func (c *Checker) checkL1() Component {
+	if c.l1 == nil {
+		return Component{Status: StatusDisabled}
+	}
+	if !c.l1.Subscribed() {
+		return down("not subscribed to the state updates on L1", nil)
+	}
+	return Component{Status: StatusUp}
+}
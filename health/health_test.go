@@ -0,0 +1,165 @@
+package health_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/health"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeL1 bool
+
+func (l fakeL1) Subscribed() bool {
+	return bool(l)
+}
+
+// unavailableDB fails to start any transaction
+type unavailableDB struct {
+	db.DB
+}
+
+func (unavailableDB) View(func(txn db.Transaction) error) error {
+	return errors.New("database is unavailable")
+}
+
+func TestReadiness(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockSyncReader := mocks.NewMockSyncReader(mockCtrl)
+	newChecker := func() *health.Checker {
+		return health.New(pebble.NewMemTest(t), mockReader, utils.NewNopZapLogger())
+	}
+	recentHead := &core.Header{Number: 100, Timestamp: uint64(time.Now().Add(-time.Minute).Unix())}
+
+	t.Run("components are disabled if the node runs neither a synchroniser nor an L1 client", func(t *testing.T) {
+		mockReader.EXPECT().HeadsHeader().Return(recentHead, nil)
+
+		report := newChecker().Readiness()
+		assert.Equal(t, health.StatusUp, report.Status)
+		assert.Equal(t, health.StatusUp, report.Components["database"].Status)
+		assert.Equal(t, health.StatusUp, report.Components["head"].Status)
+		assert.Equal(t, health.StatusDisabled, report.Components["sync"].Status)
+		assert.Equal(t, health.StatusDisabled, report.Components["l1"].Status)
+	})
+
+	t.Run("in sync", func(t *testing.T) {
+		mockReader.EXPECT().HeadsHeader().Return(recentHead, nil)
+		mockReader.EXPECT().Height().Return(uint64(100), nil)
+		mockSyncReader.EXPECT().HighestBlockHeader().Return(&core.Header{Number: 105})
+
+		report := newChecker().WithSync(mockSyncReader).WithL1(fakeL1(true)).Readiness()
+		assert.Equal(t, health.StatusUp, report.Status)
+		assert.Equal(t, health.StatusUp, report.Components["l1"].Status)
+		assert.Equal(t, health.Component{
+			Status: health.StatusUp,
+			Details: map[string]any{
+				"height":            uint64(100),
+				"highest":           uint64(105),
+				"blocks_behind":     uint64(5),
+				"max_blocks_behind": uint64(health.DefaultMaxBlocksBehind),
+			},
+		}, report.Components["sync"])
+	})
+
+	t.Run("too far behind", func(t *testing.T) {
+		mockReader.EXPECT().HeadsHeader().Return(recentHead, nil)
+		mockReader.EXPECT().Height().Return(uint64(100), nil)
+		mockSyncReader.EXPECT().HighestBlockHeader().Return(&core.Header{Number: 105})
+
+		report := newChecker().WithSync(mockSyncReader).WithThresholds(4, time.Hour).Readiness()
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, health.StatusDown, report.Components["sync"].Status)
+		assert.Equal(t, health.StatusUp, report.Components["head"].Status)
+	})
+
+	t.Run("highest block is not known yet", func(t *testing.T) {
+		mockReader.EXPECT().HeadsHeader().Return(recentHead, nil)
+		mockSyncReader.EXPECT().HighestBlockHeader().Return(nil)
+
+		report := newChecker().WithSync(mockSyncReader).Readiness()
+		assert.Equal(t, health.StatusDown, report.Components["sync"].Status)
+	})
+
+	t.Run("head is too old", func(t *testing.T) {
+		mockReader.EXPECT().HeadsHeader().Return(recentHead, nil)
+
+		report := newChecker().WithThresholds(health.DefaultMaxBlocksBehind, time.Second).Readiness()
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, health.StatusDown, report.Components["head"].Status)
+		assert.Equal(t, "the head block is too old", report.Components["head"].Message)
+	})
+
+	t.Run("empty chain", func(t *testing.T) {
+		mockReader.EXPECT().HeadsHeader().Return(nil, db.ErrKeyNotFound)
+		mockReader.EXPECT().Height().Return(uint64(0), db.ErrKeyNotFound)
+		mockSyncReader.EXPECT().HighestBlockHeader().Return(&core.Header{Number: 105})
+
+		report := newChecker().WithSync(mockSyncReader).Readiness()
+		assert.Equal(t, health.StatusDown, report.Components["head"].Status)
+		assert.Equal(t, health.StatusDown, report.Components["sync"].Status)
+	})
+
+	t.Run("L1 subscription is down", func(t *testing.T) {
+		mockReader.EXPECT().HeadsHeader().Return(recentHead, nil)
+
+		report := newChecker().WithL1(fakeL1(false)).Readiness()
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, health.StatusDown, report.Components["l1"].Status)
+	})
+
+	t.Run("database is unavailable", func(t *testing.T) {
+		report := health.New(unavailableDB{}, mockReader, utils.NewNopZapLogger()).Liveness()
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, health.Component{Status: health.StatusDown, Message: "database is unavailable"}, report.Components["database"])
+		assert.NotContains(t, report.Components, "sync")
+	})
+}
+
+func TestHandlers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	checker := health.New(pebble.NewMemTest(t), mockReader, utils.NewNopZapLogger())
+
+	t.Run("liveness", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		checker.LivenessHandler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"status":"up","components":{"database":{"status":"up"}}}`, res.Body.String())
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		mockReader.EXPECT().HeadsHeader().Return(nil, errors.New("some error"))
+
+		res := httptest.NewRecorder()
+		checker.ReadinessHandler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/ready", http.NoBody))
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+		assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+
+		var report health.Report
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &report))
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, health.Component{Status: health.StatusDown, Message: "some error"}, report.Components["head"])
+	})
+
+	t.Run("only GET and HEAD are supported", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		checker.ReadinessHandler().ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/ready", http.NoBody))
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+	})
+}
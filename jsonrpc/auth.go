@@ -0,0 +1,227 @@
+package jsonrpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrMissingCredentials = errors.New("missing credentials")
+
+	ErrMethodNotAllowed = &Error{Code: MethodNotAllowed, Message: "Method not allowed"}
+)
+
+const (
+	// AllNamespaces allows an identity to call every method
+	AllNamespaces = "*"
+	// APIKeyHeader carries the key checked by APIKeyAuthenticator
+	APIKeyHeader = "X-Api-Key"
+)
+
+// Identity is the authenticated caller of a request.
+// A method belongs to the namespace before the first underscore of its name, e.g. starknet_call is in the starknet
+// namespace. Credentials without an expiry have a zero Expiry.
+type Identity struct {
+	Name       string
+	Namespaces []string
+	Expiry     time.Time
+}
+
+// Allows returns true if method is in one of the namespaces of the identity
+func (i *Identity) Allows(method string) bool {
+	namespace, _, _ := strings.Cut(method, "_")
+	return slices.Contains(i.Namespaces, namespace) || slices.Contains(i.Namespaces, AllNamespaces)
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the caller of the request handled with ctx, if the request was authenticated
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+type Authenticator interface {
+	// Authenticate returns the identity of the caller of req. It returns ErrMissingCredentials if req doesn't carry
+	// the kind of credentials the authenticator understands.
+	Authenticate(req *http.Request) (*Identity, error)
+}
+
+type AuthListener interface {
+	OnAuthenticated(identity string)
+	OnAuthenticationFailed()
+}
+
+// Auth authenticates requests with the first authenticator that understands their credentials
+type Auth struct {
+	authenticators []Authenticator
+	listener       AuthListener
+}
+
+func NewAuth(authenticators ...Authenticator) *Auth {
+	return &Auth{
+		authenticators: authenticators,
+		listener:       &SelectiveListener{},
+	}
+}
+
+// WithListener registers an AuthListener
+func (a *Auth) WithListener(listener AuthListener) *Auth {
+	a.listener = listener
+	return a
+}
+
+func (a *Auth) authenticate(req *http.Request) (*Identity, error) {
+	for _, authenticator := range a.authenticators {
+		identity, err := authenticator.Authenticate(req)
+		if errors.Is(err, ErrMissingCredentials) {
+			continue
+		} else if err != nil {
+			a.listener.OnAuthenticationFailed()
+			return nil, err
+		}
+		a.listener.OnAuthenticated(identity.Name)
+		return identity, nil
+	}
+	a.listener.OnAuthenticationFailed()
+	return nil, ErrMissingCredentials
+}
+
+// withAuth authenticates req and returns a context that carries the identity of the caller, it returns ctx unchanged
+// if auth is nil
+func withAuth(ctx context.Context, auth *Auth, req *http.Request) (context.Context, error) {
+	if auth == nil {
+		return ctx, nil
+	}
+
+	identity, err := auth.authenticate(req)
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, identityKey{}, identity), nil
+}
+
+// checkPermission returns ErrMethodNotAllowed if the caller of the request isn't allowed to call method
+func checkPermission(ctx context.Context, method string) *Error {
+	if identity, ok := IdentityFromContext(ctx); ok && !identity.Allows(method) {
+		return ErrMethodNotAllowed
+	}
+	return nil
+}
+
+// APIKeyAuthenticator authenticates requests with a static API key in the X-Api-Key header
+type APIKeyAuthenticator struct {
+	keys map[string]*Identity
+}
+
+func NewAPIKeyAuthenticator(keys map[string]*Identity) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{keys: keys}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(req *http.Request) (*Identity, error) {
+	key := req.Header.Get(APIKeyHeader)
+	if key == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	identity, found := a.keys[key]
+	if !found {
+		return nil, ErrInvalidCredentials
+	}
+	return identity, nil
+}
+
+// JWTAuthenticator authenticates requests with a JSON Web Token signed with HS256 in the Authorization header.
+// Tokens must have an expiry and a subject. The identity is taken from the sub claim and its namespaces from the
+// namespaces claim.
+type JWTAuthenticator struct {
+	secret []byte
+	now    func() time.Time
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject    string   `json:"sub"`
+	Expiry     *int64   `json:"exp"`
+	NotBefore  *int64   `json:"nbf"`
+	Namespaces []string `json:"namespaces"`
+}
+
+func NewJWTAuthenticator(secret []byte) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		secret: secret,
+		now:    time.Now,
+	}
+}
+
+// WithClock replaces the clock used to check the expiry of tokens
+func (a *JWTAuthenticator) WithClock(now func() time.Time) *JWTAuthenticator {
+	a.now = now
+	return a
+}
+
+func (a *JWTAuthenticator) Authenticate(req *http.Request) (*Identity, error) {
+	token, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		return nil, ErrMissingCredentials
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidCredentials
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, ErrInvalidCredentials
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidCredentials
+	}
+
+	var claims jwtClaims
+	if err = decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	now := a.now().Unix()
+	if claims.Expiry == nil || now >= *claims.Expiry || (claims.NotBefore != nil && now < *claims.NotBefore) {
+		return nil, ErrInvalidCredentials
+	}
+	// identities without a name would share their rate limit with each other
+	if claims.Subject == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Identity{
+		Name:       claims.Subject,
+		Namespaces: claims.Namespaces,
+		Expiry:     time.Unix(*claims.Expiry, 0),
+	}, nil
+}
+
+func decodeJWTPart(part string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
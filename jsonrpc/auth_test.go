@@ -0,0 +1,207 @@
+package jsonrpc_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+func signJWT(t *testing.T, secret []byte, header, claims string) string {
+	t.Helper()
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, secret)
+	_, err := mac.Write([]byte(payload))
+	require.NoError(t, err)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestIdentityAllows(t *testing.T) {
+	reader := &jsonrpc.Identity{Name: "reader", Namespaces: []string{"starknet"}}
+	assert.True(t, reader.Allows("starknet_call"))
+	assert.False(t, reader.Allows("juno_version"))
+
+	admin := &jsonrpc.Identity{Name: "admin", Namespaces: []string{jsonrpc.AllNamespaces}}
+	assert.True(t, admin.Allows("starknet_call"))
+	assert.True(t, admin.Allows("juno_version"))
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1000, 0)
+	authenticator := jsonrpc.NewJWTAuthenticator(secret).WithClock(func() time.Time { return now })
+
+	authenticate := func(token string) (*jsonrpc.Identity, error) {
+		req := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return authenticator.Authenticate(req)
+	}
+
+	header := `{"alg":"HS256","typ":"JWT"}`
+	validClaims := `{"sub":"alice","exp":2000,"namespaces":["starknet"]}`
+
+	t.Run("valid", func(t *testing.T) {
+		identity, err := authenticate(signJWT(t, secret, header, validClaims))
+		require.NoError(t, err)
+		assert.Equal(t, &jsonrpc.Identity{
+			Name:       "alice",
+			Namespaces: []string{"starknet"},
+			Expiry:     time.Unix(2000, 0),
+		}, identity)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := authenticate("")
+		assert.ErrorIs(t, err, jsonrpc.ErrMissingCredentials)
+	})
+
+	tests := map[string]string{
+		"wrong secret":      signJWT(t, []byte("other"), header, validClaims),
+		"wrong algorithm":   signJWT(t, secret, `{"alg":"none"}`, validClaims),
+		"expired":           signJWT(t, secret, header, `{"sub":"alice","exp":1000}`),
+		"no expiry":         signJWT(t, secret, header, `{"sub":"alice"}`),
+		"not valid yet":     signJWT(t, secret, header, `{"sub":"alice","exp":2000,"nbf":1500}`),
+		"no subject":        signJWT(t, secret, header, `{"exp":2000}`),
+		"empty subject":     signJWT(t, secret, header, `{"sub":"","exp":2000}`),
+		"malformed":         "abc",
+		"tampered with":     signJWT(t, secret, header, validClaims) + "a",
+		"malformed payload": signJWT(t, secret, header, `not json`),
+		"missing signature": base64.RawURLEncoding.EncodeToString([]byte(header)) + ".e30.",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := authenticate(token)
+			assert.ErrorIs(t, err, jsonrpc.ErrInvalidCredentials)
+		})
+	}
+}
+
+func TestHTTPAuth(t *testing.T) {
+	methods := []jsonrpc.Method{
+		{
+			Name: "starknet_echo",
+			Handler: func(ctx context.Context, msg string) (string, *jsonrpc.Error) {
+				identity, ok := jsonrpc.IdentityFromContext(ctx)
+				require.True(t, ok)
+				return identity.Name + ":" + msg, nil
+			},
+			Params: []jsonrpc.Parameter{{Name: "msg"}},
+		},
+		{
+			Name: "juno_echo",
+			Handler: func(msg string) (string, *jsonrpc.Error) {
+				return msg, nil
+			},
+			Params: []jsonrpc.Parameter{{Name: "msg"}},
+		},
+	}
+	log := utils.NewNopZapLogger()
+	rpc := jsonrpc.NewServer(1, log)
+	require.NoError(t, rpc.RegisterMethods(methods...))
+
+	var authenticated []string
+	failures := 0
+	auth := jsonrpc.NewAuth(jsonrpc.NewAPIKeyAuthenticator(map[string]*jsonrpc.Identity{
+		"reader-key": {Name: "reader", Namespaces: []string{"starknet"}},
+	})).WithListener(&jsonrpc.SelectiveListener{
+		OnAuthenticatedCb:        func(identity string) { authenticated = append(authenticated, identity) },
+		OnAuthenticationFailedCb: func() { failures++ },
+	})
+	srv := httptest.NewServer(jsonrpc.NewHTTP(rpc, log).WithAuth(auth))
+	t.Cleanup(srv.Close)
+
+	post := func(t *testing.T, method, apiKey string) (int, string) {
+		msg := `{"jsonrpc" : "2.0", "method" : "` + method + `", "params" : [ "abc" ], "id" : 1}`
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, bytes.NewReader([]byte(msg)))
+		require.NoError(t, err)
+		if apiKey != "" {
+			req.Header.Set(jsonrpc.APIKeyHeader, apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("no credentials", func(t *testing.T) {
+		status, _ := post(t, "starknet_echo", "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		status, _ := post(t, "starknet_echo", "other-key")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("allowed namespace", func(t *testing.T) {
+		status, body := post(t, "starknet_echo", "reader-key")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"reader:abc","id":1}`, body)
+	})
+
+	t.Run("forbidden namespace", func(t *testing.T) {
+		status, body := post(t, "juno_echo", "reader-key")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"jsonrpc":"2.0","error":{"code":-32004,"message":"Method not allowed"},"id":1}`, body)
+	})
+
+	assert.Equal(t, []string{"reader", "reader"}, authenticated)
+	assert.Equal(t, 2, failures)
+}
+
+func TestWebsocketAuthExpiry(t *testing.T) {
+	log := utils.NewNopZapLogger()
+	rpc := jsonrpc.NewServer(1, log)
+	require.NoError(t, rpc.RegisterMethods(jsonrpc.Method{
+		Name:    "starknet_echo",
+		Handler: func(msg string) (string, *jsonrpc.Error) { return msg, nil },
+		Params:  []jsonrpc.Parameter{{Name: "msg"}},
+	}))
+
+	secret := []byte("secret")
+	auth := jsonrpc.NewAuth(jsonrpc.NewJWTAuthenticator(secret))
+	srv := httptest.NewServer(jsonrpc.NewWebsocket(rpc, log).WithAuth(auth))
+	t.Cleanup(srv.Close)
+
+	expiry := time.Now().Add(time.Second).Unix() + 1
+	token := signJWT(t, secret, `{"alg":"HS256"}`, fmt.Sprintf(`{"sub":"alice","exp":%d,"namespaces":["starknet"]}`, expiry))
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	conn, resp, err := websocket.Dial(context.Background(), srv.URL, &websocket.DialOptions{ //nolint:bodyclose
+		HTTPHeader: header,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	msg := `{"jsonrpc" : "2.0", "method" : "starknet_echo", "params" : [ "abc" ], "id" : 1}`
+	require.NoError(t, conn.Write(context.Background(), websocket.MessageText, []byte(msg)))
+	_, got, err := conn.Read(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, `{"jsonrpc":"2.0","result":"abc","id":1}`, string(got))
+
+	// the server closes the connection once the token expires
+	readCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, _, err = conn.Read(readCtx)
+	assert.Equal(t, websocket.StatusPolicyViolation, websocket.CloseStatus(err), err)
+	assert.GreaterOrEqual(t, time.Now().Unix(), expiry)
+}
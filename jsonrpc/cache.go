@@ -0,0 +1,127 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// CachePolicy decides which responses a ResponseCache may keep
+type CachePolicy interface {
+	// Caches returns true if responses to method may be cached at all
+	Caches(method string) bool
+	// Cacheable returns the number of the block the response to a call depends on, or false if the response must
+	// not be cached, e.g. because it depends on the head of the chain.
+	Cacheable(method string, params, result any) (uint64, bool)
+}
+
+type CacheListener interface {
+	OnCacheHit(method string)
+	OnCacheMiss(method string)
+}
+
+// ResponseCache keeps the results of calls whose response can't change as long as the block they depend on is part
+// of the chain. Entries are keyed by method and params and evicted when the cache is full or the block is reverted.
+type ResponseCache struct {
+	policy   CachePolicy
+	listener CacheListener
+
+	mu sync.Mutex
+	// generation is increased on every invalidation so that results computed before it are not added afterwards
+	generation uint64
+	entries    lru.BasicLRU[string, cacheEntry]
+}
+
+type cacheEntry struct {
+	result      json.RawMessage
+	blockNumber uint64
+}
+
+func NewResponseCache(size int, policy CachePolicy) *ResponseCache {
+	return &ResponseCache{
+		policy:   policy,
+		listener: &SelectiveListener{},
+		entries:  lru.NewBasicLRU[string, cacheEntry](size),
+	}
+}
+
+// WithListener registers a CacheListener
+func (c *ResponseCache) WithListener(listener CacheListener) *ResponseCache {
+	c.listener = listener
+	return c
+}
+
+// InvalidateFrom removes the responses that depend on blockNumber or any block after it
+func (c *ResponseCache) InvalidateFrom(blockNumber uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, key := range c.entries.Keys() {
+		if entry, found := c.entries.Peek(key); found && entry.blockNumber >= blockNumber {
+			c.entries.Remove(key)
+		}
+	}
+}
+
+// Purge removes every response
+func (c *ResponseCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries.Purge()
+}
+
+// cacheKey returns the key of a call, or false if calls to method are never cached
+func (c *ResponseCache) cacheKey(method string, params any) (string, bool) {
+	if c == nil || !c.policy.Caches(method) {
+		return "", false
+	}
+
+	// maps are marshalled with sorted keys, so named params don't depend on the order they were sent in
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+	return method + string(paramsJSON), true
+}
+
+// get returns the cached result for key and the generation of the cache, which has to be passed to add
+func (c *ResponseCache) get(method, key string) (json.RawMessage, uint64, bool) {
+	c.mu.Lock()
+	entry, found := c.entries.Get(key)
+	generation := c.generation
+	c.mu.Unlock()
+
+	if found {
+		c.listener.OnCacheHit(method)
+	} else {
+		c.listener.OnCacheMiss(method)
+	}
+	return entry.result, generation, found
+}
+
+// add caches result if the policy allows it and returns the result as it should be sent to the client
+func (c *ResponseCache) add(method, key string, generation uint64, params, result any) any {
+	blockNumber, cacheable := c.policy.Cacheable(method, params, result)
+	if !cacheable {
+		return result
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return result
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation == c.generation {
+		c.entries.Add(key, cacheEntry{
+			result:      resultJSON,
+			blockNumber: blockNumber,
+		})
+	}
+	return json.RawMessage(resultJSON)
+}
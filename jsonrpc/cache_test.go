@@ -0,0 +1,108 @@
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// headPolicy caches the responses of the block method for blocks below head
+type headPolicy struct {
+	head uint64
+}
+
+func (p *headPolicy) Caches(method string) bool {
+	return method == "block"
+}
+
+func (p *headPolicy) Cacheable(_ string, params, _ any) (uint64, bool) {
+	number, err := params.([]any)[0].(json.Number).Int64()
+	if err != nil || uint64(number) >= p.head {
+		return 0, false
+	}
+	return uint64(number), true
+}
+
+func TestResponseCache(t *testing.T) {
+	calls := 0
+	methods := []jsonrpc.Method{
+		{
+			Name: "block",
+			Handler: func(number uint64) (string, *jsonrpc.Error) {
+				calls++
+				return "block " + strconv.FormatUint(number, 10) + " call " + strconv.Itoa(calls), nil
+			},
+			Params: []jsonrpc.Parameter{{Name: "number"}},
+		},
+		{
+			Name: "fail",
+			Handler: func() (string, *jsonrpc.Error) {
+				calls++
+				return "", jsonrpc.Err(jsonrpc.InternalError, nil)
+			},
+		},
+	}
+
+	var hits, misses []string
+	policy := &headPolicy{head: 10}
+	cache := jsonrpc.NewResponseCache(2, policy).WithListener(&jsonrpc.SelectiveListener{
+		OnCacheHitCb:  func(method string) { hits = append(hits, method) },
+		OnCacheMissCb: func(method string) { misses = append(misses, method) },
+	})
+	server := jsonrpc.NewServer(1, utils.NewNopZapLogger()).WithCache(cache)
+	require.NoError(t, server.RegisterMethods(methods...))
+
+	call := func(t *testing.T, method string, number uint64) string {
+		t.Helper()
+		req := `{"jsonrpc":"2.0","method":"` + method + `","params":[` + strconv.FormatUint(number, 10) + `],"id":1}`
+		if method == "fail" {
+			req = `{"jsonrpc":"2.0","method":"fail","id":1}`
+		}
+		res, err := server.HandleReader(context.Background(), strings.NewReader(req))
+		require.NoError(t, err)
+		return string(res)
+	}
+
+	t.Run("blocks below the head are cached", func(t *testing.T) {
+		expected := `{"jsonrpc":"2.0","result":"block 1 call 1","id":1}`
+		assert.Equal(t, expected, call(t, "block", 1))
+		assert.Equal(t, expected, call(t, "block", 1))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("the head is not cached", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"block 10 call 2","id":1}`, call(t, "block", 10))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"block 10 call 3","id":1}`, call(t, "block", 10))
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		call(t, "fail", 0)
+		call(t, "fail", 0)
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("reverted blocks are invalidated", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"block 5 call 6","id":1}`, call(t, "block", 5))
+
+		cache.InvalidateFrom(5)
+		policy.head = 5
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"block 1 call 1","id":1}`, call(t, "block", 1))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"block 5 call 7","id":1}`, call(t, "block", 5))
+	})
+
+	t.Run("purged responses are computed again", func(t *testing.T) {
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"block 1 call 1","id":1}`, call(t, "block", 1))
+		cache.Purge()
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"block 1 call 8","id":1}`, call(t, "block", 1))
+	})
+
+	assert.Equal(t, []string{"block", "block", "block"}, hits)
+	assert.Equal(t, []string{"block", "block", "block", "block", "block", "block"}, misses)
+}
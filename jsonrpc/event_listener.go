@@ -16,6 +16,14 @@ type SelectiveListener struct {
 	OnNewRequestCb     func(method string)
 	OnRequestHandledCb func(method string, took time.Duration)
 	OnRequestFailedCb  func(method string, data any)
+	OnRequestAllowedCb func(method string)
+	OnRequestLimitedCb func(method string)
+
+	OnAuthenticatedCb        func(identity string)
+	OnAuthenticationFailedCb func()
+
+	OnCacheHitCb  func(method string)
+	OnCacheMissCb func(method string)
 }
 
 func (l *SelectiveListener) OnNewRequest(method string) {
@@ -35,3 +43,39 @@ func (l *SelectiveListener) OnRequestFailed(method string, data any) {
 		l.OnRequestFailedCb(method, data)
 	}
 }
+
+func (l *SelectiveListener) OnRequestAllowed(method string) {
+	if l.OnRequestAllowedCb != nil {
+		l.OnRequestAllowedCb(method)
+	}
+}
+
+func (l *SelectiveListener) OnRequestLimited(method string) {
+	if l.OnRequestLimitedCb != nil {
+		l.OnRequestLimitedCb(method)
+	}
+}
+
+func (l *SelectiveListener) OnAuthenticated(identity string) {
+	if l.OnAuthenticatedCb != nil {
+		l.OnAuthenticatedCb(identity)
+	}
+}
+
+func (l *SelectiveListener) OnAuthenticationFailed() {
+	if l.OnAuthenticationFailedCb != nil {
+		l.OnAuthenticationFailedCb()
+	}
+}
+
+func (l *SelectiveListener) OnCacheHit(method string) {
+	if l.OnCacheHitCb != nil {
+		l.OnCacheHitCb(method)
+	}
+}
+
+func (l *SelectiveListener) OnCacheMiss(method string) {
+	if l.OnCacheMissCb != nil {
+		l.OnCacheMissCb(method)
+	}
+}
@@ -13,6 +13,8 @@ type HTTP struct {
 	log utils.SimpleLogger
 
 	listener NewRequestListener
+	limiter  *RateLimiter
+	auth     *Auth
 }
 
 func NewHTTP(rpc *Server, log utils.SimpleLogger) *HTTP {
@@ -30,6 +32,18 @@ func (h *HTTP) WithListener(listener NewRequestListener) *HTTP {
 	return h
 }
 
+// WithRateLimiter limits the rate of requests per client
+func (h *HTTP) WithRateLimiter(limiter *RateLimiter) *HTTP {
+	h.limiter = limiter
+	return h
+}
+
+// WithAuth requires requests to be authenticated
+func (h *HTTP) WithAuth(auth *Auth) *HTTP {
+	h.auth = auth
+	return h
+}
+
 // ServeHTTP processes an incoming HTTP request
 func (h *HTTP) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 	if req.Method == http.MethodGet {
@@ -46,11 +60,19 @@ func (h *HTTP) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 
 	req.Body = http.MaxBytesReader(writer, req.Body, MaxRequestBodySize)
 	h.listener.OnNewRequest("any")
-	resp, err := h.rpc.HandleReader(req.Context(), req.Body)
+	ctx, err := withAuth(traceContext(req), h.auth, req)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	ctx, limit := withRateLimit(ctx, h.limiter, req)
+	resp, err := h.rpc.HandleReader(ctx, req.Body)
 	writer.Header().Set("Content-Type", "application/json")
 	if err != nil {
 		h.log.Errorw("Handler failure", "err", err)
 		writer.WriteHeader(http.StatusInternalServerError)
+	} else if status := limit.writeHeaders(writer); status != http.StatusOK {
+		writer.WriteHeader(status)
 	}
 	if resp != nil {
 		_, err = writer.Write(resp)
@@ -59,3 +81,49 @@ func (h *HTTP) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 		}
 	}
 }
+
+// HTTPMiddleware authenticates and rate limits the requests of handlers served next to the JSON-RPC servers, such as
+// GraphQL, the same way HTTP does
+type HTTPMiddleware struct {
+	limiter *RateLimiter
+	auth    *Auth
+}
+
+func NewHTTPMiddleware() *HTTPMiddleware {
+	return &HTTPMiddleware{}
+}
+
+// WithRateLimiter limits the rate of requests per client
+func (m *HTTPMiddleware) WithRateLimiter(limiter *RateLimiter) *HTTPMiddleware {
+	m.limiter = limiter
+	return m
+}
+
+// WithAuth requires requests to be authenticated
+func (m *HTTPMiddleware) WithAuth(auth *Auth) *HTTPMiddleware {
+	m.auth = auth
+	return m
+}
+
+// Wrap returns a handler that serves the requests allowed by the middleware with next. Every request counts as a call
+// of method, so authenticated callers need access to its namespace.
+func (m *HTTPMiddleware) Wrap(method string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		ctx, err := withAuth(req.Context(), m.auth, req)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if rpcErr := checkPermission(ctx, method); rpcErr != nil {
+			http.Error(writer, rpcErr.Message, http.StatusForbidden)
+			return
+		}
+
+		ctx, limit := withRateLimit(ctx, m.limiter, req)
+		if rpcErr := checkRateLimit(ctx, method); rpcErr != nil {
+			http.Error(writer, rpcErr.Message, limit.writeHeaders(writer))
+			return
+		}
+		next.ServeHTTP(writer, req.WithContext(ctx))
+	})
+}
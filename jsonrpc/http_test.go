@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/NethermindEth/juno/jsonrpc"
 	"github.com/NethermindEth/juno/utils"
@@ -74,3 +75,49 @@ func TestHTTP(t *testing.T) {
 		assert.Len(t, listener.OnNewRequestLogs, 1)
 	})
 }
+
+func TestHTTPMiddleware(t *testing.T) {
+	auth := jsonrpc.NewAuth(jsonrpc.NewAPIKeyAuthenticator(map[string]*jsonrpc.Identity{
+		"graphql": {Name: "graphql client", Namespaces: []string{"graphql"}},
+		"rpc":     {Name: "rpc client", Namespaces: []string{"starknet"}},
+	}))
+	limiter := jsonrpc.NewRateLimiter(1, 1).WithClock(func() time.Time { return time.Unix(0, 0) })
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := jsonrpc.IdentityFromContext(r.Context())
+		require.True(t, ok)
+		_, err := w.Write([]byte(identity.Name))
+		require.NoError(t, err)
+	})
+	handler := jsonrpc.NewHTTPMiddleware().WithRateLimiter(limiter).WithAuth(auth).Wrap("graphql", next)
+
+	serve := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", http.NoBody)
+		if apiKey != "" {
+			req.Header.Set(jsonrpc.APIKeyHeader, apiKey)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve("").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve("unknown").Code)
+	})
+
+	t.Run("namespace not allowed", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve("rpc").Code)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		rec := serve("graphql")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "graphql client", rec.Body.String())
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		rec := serve("graphql")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	})
+}
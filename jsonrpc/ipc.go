@@ -0,0 +1,133 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/NethermindEth/juno/utils"
+	"github.com/sourcegraph/conc"
+)
+
+// maxIPCMessageSize is the size of the largest request accepted on an IPC connection
+const maxIPCMessageSize = 32 * utils.Megabyte
+
+// IPC serves the JSON-RPC server over local stream sockets, such as Unix domain sockets.
+// Every request and every message sent to the client is a single line of JSON. Like websocket connections, IPC
+// connections support batches and subscriptions.
+type IPC struct {
+	rpc      *Server
+	log      utils.SimpleLogger
+	listener NewRequestListener
+}
+
+func NewIPC(rpc *Server, log utils.SimpleLogger) *IPC {
+	return &IPC{
+		rpc:      rpc,
+		log:      log,
+		listener: &SelectiveListener{},
+	}
+}
+
+// WithListener registers a NewRequestListener
+func (i *IPC) WithListener(listener NewRequestListener) *IPC {
+	i.listener = listener
+	return i
+}
+
+// Serve handles the connections accepted by l until ctx is cancelled, at which point l and every open connection are
+// closed.
+func (i *IPC) Serve(ctx context.Context, l net.Listener) error {
+	stopListening := context.AfterFunc(ctx, func() {
+		if err := l.Close(); err != nil {
+			i.log.Warnw("Failed to close IPC listener", "err", err)
+		}
+	})
+	defer stopListening()
+
+	var wg conc.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Go(func() {
+			i.serveConn(ctx, conn)
+		})
+	}
+}
+
+func (i *IPC) serveConn(ctx context.Context, conn net.Conn) {
+	// subscriptions started on the connection end with it
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stopConn := context.AfterFunc(ctx, func() {
+		i.closeConn(conn)
+	})
+	defer func() {
+		if stopConn() {
+			i.closeConn(conn)
+		}
+	}()
+
+	ic := &ipcConn{conn: conn}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, bufferSize), maxIPCMessageSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		i.listener.OnNewRequest("any")
+		ic.r = bytes.NewReader(line)
+		if err := i.rpc.HandleReadWriter(ctx, ic); err != nil {
+			i.log.Warnw("Closing IPC connection", "err", err)
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		i.log.Warnw("Closing IPC connection", "err", err)
+	}
+}
+
+func (i *IPC) closeConn(conn net.Conn) {
+	if err := conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		i.log.Warnw("Failed to close IPC connection", "err", err)
+	}
+}
+
+// ipcConn reads the current request of a connection and writes every message on its own line
+type ipcConn struct {
+	r io.Reader
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (c *ipcConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// Write returns the number of bytes of p sent, not including the newline.
+func (c *ipcConn) Write(p []byte) (int, error) {
+	msg := make([]byte, 0, len(p)+1)
+	msg = append(append(msg, p...), '\n')
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.conn.Write(msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
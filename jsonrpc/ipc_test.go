@@ -0,0 +1,139 @@
+package jsonrpc_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/sourcegraph/conc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	handlers := conc.NewWaitGroup()
+	t.Cleanup(handlers.Wait)
+	watchDone := make(chan struct{})
+	methods := []jsonrpc.Method{
+		{
+			Name:   "test_echo",
+			Params: []jsonrpc.Parameter{{Name: "msg"}},
+			Handler: func(msg string) (string, *jsonrpc.Error) {
+				return msg, nil
+			},
+		},
+		{
+			Name: "test_subscribe",
+			Handler: func(ctx context.Context) (int, *jsonrpc.Error) {
+				conn, ok := jsonrpc.ConnFromContext(ctx)
+				require.True(t, ok)
+				handlers.Go(func() {
+					_, err := conn.Write([]byte(`{"event":1}`))
+					require.NoError(t, err)
+				})
+				return 7, nil
+			},
+		},
+		{
+			Name: "test_watch",
+			Handler: func(ctx context.Context) (int, *jsonrpc.Error) {
+				handlers.Go(func() {
+					<-ctx.Done()
+					close(watchDone)
+				})
+				return 0, nil
+			},
+		},
+	}
+	log := utils.NewNopZapLogger()
+	rpc := jsonrpc.NewServer(1, log)
+	require.NoError(t, rpc.RegisterMethods(methods...))
+
+	listener := CountingEventListener{}
+	socketPath := filepath.Join(t.TempDir(), "juno.ipc")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	served := make(chan error)
+	go func() {
+		served <- jsonrpc.NewIPC(rpc, log).WithListener(&listener).Serve(ctx, l)
+	}()
+
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	reader := bufio.NewReader(conn)
+
+	roundTrip := func(t *testing.T, req string, want ...string) {
+		t.Helper()
+		_, err := conn.Write([]byte(req + "\n"))
+		require.NoError(t, err)
+		for _, line := range want {
+			got, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			assert.Equal(t, line+"\n", got)
+		}
+	}
+
+	t.Run("single request", func(t *testing.T) {
+		roundTrip(t, `{"jsonrpc":"2.0","method":"test_echo","params":["abc"],"id":1}`,
+			`{"jsonrpc":"2.0","result":"abc","id":1}`)
+	})
+
+	t.Run("empty lines and notifications get no response", func(t *testing.T) {
+		roundTrip(t, "")
+		roundTrip(t, `{"jsonrpc":"2.0","method":"test_echo","params":["abc"]}`)
+		roundTrip(t, `{"jsonrpc":"2.0","method":"test_echo","params":["def"],"id":2}`,
+			`{"jsonrpc":"2.0","result":"def","id":2}`)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		roundTrip(t, `[{"jsonrpc":"2.0","method":"test_echo","params":["a"],"id":1},`+
+			`{"jsonrpc":"2.0","method":"test_echo","params":["b"],"id":2}]`,
+			`[{"jsonrpc":"2.0","result":"a","id":1},{"jsonrpc":"2.0","result":"b","id":2}]`)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := conn.Write([]byte("{\n"))
+		require.NoError(t, err)
+		got, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Contains(t, got, `"code":-32700`)
+	})
+
+	t.Run("subscription", func(t *testing.T) {
+		roundTrip(t, `{"jsonrpc":"2.0","method":"test_subscribe","id":3}`,
+			`{"jsonrpc":"2.0","result":7,"id":3}`, `{"event":1}`)
+	})
+
+	t.Run("subscriptions end with the connection", func(t *testing.T) {
+		watchConn, err := net.Dial("unix", socketPath)
+		require.NoError(t, err)
+		_, err = watchConn.Write([]byte(`{"jsonrpc":"2.0","method":"test_watch","id":4}` + "\n"))
+		require.NoError(t, err)
+		_, err = bufio.NewReader(watchConn).ReadString('\n')
+		require.NoError(t, err)
+
+		require.NoError(t, watchConn.Close())
+		select {
+		case <-watchDone:
+		case <-time.After(time.Second):
+			require.Fail(t, "subscription context wasn't cancelled")
+		}
+	})
+
+	assert.Len(t, listener.OnNewRequestLogs, 7)
+
+	cancel()
+	require.NoError(t, <-served)
+	_, err = reader.ReadString('\n')
+	assert.Error(t, err)
+}
@@ -0,0 +1,285 @@
+package jsonrpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DiscoverMethod is the name of the method that returns the OpenRPC document of a server
+const DiscoverMethod = "rpc.discover"
+
+const (
+	openRPCVersion = "1.2.6"
+	schemaRefBase  = "#/components/schemas/"
+	// maxEnumProbe is the number of values tried when listing the values of an integer enum
+	maxEnumProbe = 256
+)
+
+var (
+	textMarshalerInterface = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerInterface = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	invalidSchemaNameChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]`)
+)
+
+// Schema is a JSON schema as used by OpenRPC
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	// Validate holds the validation tag of struct fields, which the server checks on top of the schema
+	Validate string `json:"x-validate,omitempty"`
+}
+
+type OpenRPCInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type OpenRPCContentDescriptor struct {
+	Name     string  `json:"name"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type OpenRPCMethod struct {
+	Name   string                     `json:"name"`
+	Params []OpenRPCContentDescriptor `json:"params"`
+	Result OpenRPCContentDescriptor   `json:"result"`
+	Errors []*Error                   `json:"errors,omitempty"`
+}
+
+type OpenRPCComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type OpenRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       OpenRPCInfo       `json:"info"`
+	Methods    []OpenRPCMethod   `json:"methods"`
+	Components OpenRPCComponents `json:"components"`
+}
+
+// OpenRPC generates the OpenRPC document of the methods by reflecting over their handlers. The schemas replace the
+// generated schema of types whose JSON representation doesn't follow from their Go type.
+func OpenRPC(info OpenRPCInfo, methods []Method, schemas map[reflect.Type]*Schema) (*OpenRPCDocument, error) {
+	r := &schemaReflector{
+		overrides: schemas,
+		names:     make(map[reflect.Type]string),
+		schemas:   make(map[string]*Schema),
+	}
+
+	doc := &OpenRPCDocument{
+		OpenRPC:    openRPCVersion,
+		Info:       info,
+		Methods:    make([]OpenRPCMethod, 0, len(methods)),
+		Components: OpenRPCComponents{Schemas: r.schemas},
+	}
+	for _, method := range methods {
+		handlerT := reflect.TypeOf(method.Handler)
+		if handlerT == nil || handlerT.Kind() != reflect.Func || handlerT.NumOut() != 2 {
+			return nil, errors.New("handler of " + method.Name + " must be a function returning 2 values")
+		}
+
+		argOffset := 0
+		if handlerT.NumIn() > 0 && handlerT.In(0).Implements(contextInterface) {
+			argOffset = 1
+		}
+		if handlerT.NumIn()-argOffset != len(method.Params) {
+			return nil, errors.New("number of non-context params and param names of " + method.Name + " must match")
+		}
+
+		rpcMethod := OpenRPCMethod{
+			Name:   method.Name,
+			Params: make([]OpenRPCContentDescriptor, len(method.Params)),
+			Result: OpenRPCContentDescriptor{
+				Name:   "result",
+				Schema: r.schema(handlerT.Out(0)),
+			},
+			Errors: method.Errors,
+		}
+		for i, param := range method.Params {
+			rpcMethod.Params[i] = OpenRPCContentDescriptor{
+				Name:     param.Name,
+				Required: !param.Optional,
+				Schema:   r.schema(handlerT.In(i + argOffset)),
+			}
+		}
+		doc.Methods = append(doc.Methods, rpcMethod)
+	}
+
+	slices.SortFunc(doc.Methods, func(a, b OpenRPCMethod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return doc, nil
+}
+
+// schemaReflector builds the schemas of Go types, named structs are added to the components and referenced
+type schemaReflector struct {
+	overrides map[reflect.Type]*Schema
+	names     map[reflect.Type]string
+	schemas   map[string]*Schema
+}
+
+func (r *schemaReflector) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if override, found := r.overrides[t]; found {
+		return override
+	}
+
+	switch {
+	case t.Implements(textMarshalerInterface) || reflect.PointerTo(t).Implements(textMarshalerInterface):
+		return &Schema{Type: "string", Enum: enumValues(t)}
+	case t.Kind() != reflect.Struct && (t.Implements(jsonMarshalerInterface) || reflect.PointerTo(t).Implements(jsonMarshalerInterface)):
+		// the representation is up to the marshaller
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are base64 encoded
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return &Schema{Ref: schemaRefBase + r.namedStruct(t)}
+	default:
+		// interfaces, channels and functions can hold anything
+		return &Schema{}
+	}
+}
+
+// namedStruct adds the schema of the struct to the components and returns its name
+func (r *schemaReflector) namedStruct(t reflect.Type) string {
+	if name, found := r.names[t]; found {
+		return name
+	}
+
+	name := invalidSchemaNameChars.ReplaceAllString(t.Name(), "_")
+	if _, taken := r.schemas[name]; taken {
+		name = path.Base(t.PkgPath()) + "_" + name
+	}
+	// register the name before building the schema, so that recursive types refer to themselves
+	r.names[t] = name
+	r.schemas[name] = nil
+	r.schemas[name] = r.structSchema(t)
+	return name
+}
+
+func (r *schemaReflector) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t)
+	return schema
+}
+
+func (r *schemaReflector) addFields(schema *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		fieldT := field.Type
+		for fieldT.Kind() == reflect.Pointer {
+			fieldT = fieldT.Elem()
+		}
+		if field.Anonymous && name == "" && fieldT.Kind() == reflect.Struct {
+			// fields of embedded structs are promoted
+			r.addFields(schema, fieldT)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := r.schema(field.Type)
+		if validate := field.Tag.Get("validate"); validate != "" {
+			// copy, so that the tag doesn't leak into the shared schemas of the field type
+			annotated := *fieldSchema
+			annotated.Validate = validate
+			fieldSchema = &annotated
+			if slices.Contains(strings.Split(validate, ","), "required") {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		schema.Properties[name] = fieldSchema
+	}
+}
+
+// enumValues returns the texts of the values of an integer enum, nil if t is not an integer type
+func enumValues(t reflect.Type) []any {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return nil
+	}
+
+	var texts []string
+	seen := make(map[string]int)
+	for i := range maxEnumProbe {
+		v := reflect.New(t)
+		if v.Elem().CanInt() {
+			v.Elem().SetInt(int64(i))
+		} else {
+			v.Elem().SetUint(uint64(i))
+		}
+
+		marshaler, ok := v.Interface().(encoding.TextMarshaler)
+		if !ok {
+			return nil
+		}
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			continue
+		}
+		if seen[string(text)] == 0 {
+			texts = append(texts, string(text))
+		}
+		seen[string(text)]++
+	}
+
+	var values []any
+	for _, text := range texts {
+		// a text shared by several values comes from a fallback branch, e.g. "<unknown>"
+		if seen[text] == 1 {
+			values = append(values, text)
+		}
+	}
+	return values
+}
@@ -0,0 +1,111 @@
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type colour uint8
+
+func (c colour) MarshalText() ([]byte, error) {
+	switch c {
+	case 0:
+		return []byte("RED"), nil
+	case 1:
+		return []byte("BLUE"), nil
+	case 2, 3:
+		return []byte("<unknown>"), nil
+	default:
+		return nil, errors.New("unknown colour")
+	}
+}
+
+type opaque struct{ Value string }
+
+type base struct {
+	ID uint64 `json:"id"`
+}
+
+type node struct {
+	base
+	Name     string            `json:"name" validate:"required,min=1"`
+	Colour   colour            `json:"colour,omitempty"`
+	Children []*node           `json:"children"`
+	Labels   map[string]string `json:"labels"`
+	Secret   string            `json:"-"`
+	Opaque   *opaque           `json:"opaque"`
+}
+
+func TestOpenRPC(t *testing.T) {
+	errNotFound := &jsonrpc.Error{Code: 1, Message: "Not found"}
+	methods := []jsonrpc.Method{
+		{
+			Name:    "test_get",
+			Params:  []jsonrpc.Parameter{{Name: "id"}, {Name: "depth", Optional: true}},
+			Handler: func(ctx context.Context, id uint64, depth *int) (*node, *jsonrpc.Error) { return nil, nil },
+			Errors:  []*jsonrpc.Error{errNotFound},
+		},
+		{
+			Name:    "test_add",
+			Params:  []jsonrpc.Parameter{{Name: "nodes"}},
+			Handler: func(nodes []node) (bool, *jsonrpc.Error) { return false, nil },
+		},
+	}
+	opaqueSchema := &jsonrpc.Schema{Type: "string", Pattern: "^[a-z]+$"}
+	overrides := map[reflect.Type]*jsonrpc.Schema{reflect.TypeOf(opaque{}): opaqueSchema}
+
+	doc, err := jsonrpc.OpenRPC(jsonrpc.OpenRPCInfo{Title: "Test", Version: "1.0.0"}, methods, overrides)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Test", doc.Info.Title)
+	require.Len(t, doc.Methods, 2)
+	// sorted by name
+	add, get := doc.Methods[0], doc.Methods[1]
+
+	assert.Equal(t, "test_add", add.Name)
+	require.Len(t, add.Params, 1)
+	assert.Equal(t, &jsonrpc.Schema{Type: "array", Items: &jsonrpc.Schema{Ref: "#/components/schemas/node"}}, add.Params[0].Schema)
+	assert.Equal(t, &jsonrpc.Schema{Type: "boolean"}, add.Result.Schema)
+	assert.Empty(t, add.Errors)
+
+	assert.Equal(t, "test_get", get.Name)
+	assert.Equal(t, []jsonrpc.OpenRPCContentDescriptor{
+		{Name: "id", Required: true, Schema: &jsonrpc.Schema{Type: "integer"}},
+		{Name: "depth", Schema: &jsonrpc.Schema{Type: "integer"}},
+	}, get.Params)
+	assert.Equal(t, &jsonrpc.Schema{Ref: "#/components/schemas/node"}, get.Result.Schema)
+	assert.Equal(t, []*jsonrpc.Error{errNotFound}, get.Errors)
+
+	assert.Equal(t, map[string]*jsonrpc.Schema{
+		"node": {
+			Type: "object",
+			Properties: map[string]*jsonrpc.Schema{
+				"id":       {Type: "integer"},
+				"name":     {Type: "string", Validate: "required,min=1"},
+				"colour":   {Type: "string", Enum: []any{"RED", "BLUE"}},
+				"children": {Type: "array", Items: &jsonrpc.Schema{Ref: "#/components/schemas/node"}},
+				"labels":   {Type: "object", AdditionalProperties: &jsonrpc.Schema{Type: "string"}},
+				"opaque":   opaqueSchema,
+			},
+			Required: []string{"name"},
+		},
+	}, doc.Components.Schemas)
+
+	_, err = json.Marshal(doc)
+	require.NoError(t, err)
+
+	t.Run("params must match the handler", func(t *testing.T) {
+		_, err := jsonrpc.OpenRPC(jsonrpc.OpenRPCInfo{}, []jsonrpc.Method{{
+			Name:    "test_get",
+			Handler: func(id uint64) (bool, *jsonrpc.Error) { return false, nil },
+		}}, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,197 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+const (
+	// maximum number of clients the limiter keeps track of, the least recently seen clients are forgotten first
+	maxRateLimitedClients = 10_000
+)
+
+var ErrRateLimited = &Error{Code: LimitExceeded, Message: "Request rate limit exceeded"}
+
+type RateLimitListener interface {
+	OnRequestAllowed(method string)
+	OnRequestLimited(method string)
+}
+
+// RateLimiter limits the rate of requests per client with token buckets. Every client gets a bucket of burst tokens
+// that refills at rate tokens per second, and each request takes as many tokens as the weight of its method.
+type RateLimiter struct {
+	rate          float64
+	burst         float64
+	weights       map[string]uint
+	defaultWeight uint
+	listener      RateLimitListener
+	now           func() time.Time
+
+	mu      sync.Mutex
+	buckets lru.BasicLRU[string, *tokenBucket]
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func NewRateLimiter(rate float64, burst uint) *RateLimiter {
+	return &RateLimiter{
+		rate:          rate,
+		burst:         float64(burst),
+		weights:       make(map[string]uint),
+		defaultWeight: 1,
+		listener:      &SelectiveListener{},
+		now:           time.Now,
+		buckets:       lru.NewBasicLRU[string, *tokenBucket](maxRateLimitedClients),
+	}
+}
+
+// WithWeights sets the cost of the given methods, methods that are not listed cost a single token
+func (l *RateLimiter) WithWeights(weights map[string]uint) *RateLimiter {
+	for method, weight := range weights {
+		l.weights[method] = weight
+	}
+	return l
+}
+
+// WithListener registers a RateLimitListener
+func (l *RateLimiter) WithListener(listener RateLimitListener) *RateLimiter {
+	l.listener = listener
+	return l
+}
+
+// WithClock replaces the clock used to refill the buckets
+func (l *RateLimiter) WithClock(now func() time.Time) *RateLimiter {
+	l.now = now
+	return l
+}
+
+// Take takes the tokens needed by a call to method from the bucket of client.
+// If there are not enough tokens, it returns how long the client needs to wait before retrying.
+func (l *RateLimiter) Take(client, method string) (bool, time.Duration) {
+	weight, found := l.weights[method]
+	if !found {
+		weight = l.defaultWeight
+	}
+	// methods heavier than the bucket would never be allowed otherwise
+	cost := math.Min(float64(weight), l.burst)
+
+	l.mu.Lock()
+	now := l.now()
+	bucket, found := l.buckets.Get(client)
+	if !found {
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets.Add(client, bucket)
+	}
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rate)
+	bucket.updated = now
+
+	allowed := bucket.tokens >= cost
+	var retryAfter time.Duration
+	if allowed {
+		bucket.tokens -= cost
+	} else {
+		retryAfter = time.Duration((cost - bucket.tokens) / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if allowed {
+		l.listener.OnRequestAllowed(method)
+	} else {
+		l.listener.OnRequestLimited(method)
+	}
+	return allowed, retryAfter
+}
+
+// rateLimitKey is the context key of the clientLimit of a connection
+type rateLimitKey struct{}
+
+// clientLimit tracks the requests of a single HTTP request or websocket connection
+type clientLimit struct {
+	limiter *RateLimiter
+	client  string
+
+	mu         sync.Mutex
+	allowed    int
+	limited    int
+	retryAfter time.Duration
+}
+
+// withRateLimit returns a context that rate limits the requests handled with it, it returns ctx unchanged if
+// limiter is nil
+func withRateLimit(ctx context.Context, limiter *RateLimiter, req *http.Request) (context.Context, *clientLimit) {
+	if limiter == nil {
+		return ctx, nil
+	}
+
+	limit := &clientLimit{
+		limiter: limiter,
+		client:  clientKey(ctx, req),
+	}
+	return context.WithValue(ctx, rateLimitKey{}, limit), limit
+}
+
+// clientKey identifies clients by their identity if they are authenticated and by their IP address otherwise.
+// Unverified credentials are ignored, as clients could otherwise get a fresh limit by sending a new one.
+func clientKey(ctx context.Context, req *http.Request) string {
+	if identity, ok := IdentityFromContext(ctx); ok {
+		return "id:" + identity.Name
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// checkRateLimit returns ErrRateLimited if the client making the request is over its limit
+func checkRateLimit(ctx context.Context, method string) *Error {
+	limit, ok := ctx.Value(rateLimitKey{}).(*clientLimit)
+	if !ok {
+		return nil
+	}
+
+	allowed, retryAfter := limit.limiter.Take(limit.client, method)
+
+	limit.mu.Lock()
+	defer limit.mu.Unlock()
+	if allowed {
+		limit.allowed++
+		return nil
+	}
+	limit.limited++
+	limit.retryAfter = max(limit.retryAfter, retryAfter)
+	return ErrRateLimited.CloneWithData(fmt.Sprintf("retry after %s", retryAfter.Round(time.Millisecond)))
+}
+
+// writeHeaders sets the Retry-After header if any of the requests were limited and returns the status code of
+// the response. Responses are only rejected as a whole if none of their requests were allowed.
+func (l *clientLimit) writeHeaders(writer http.ResponseWriter) int {
+	if l == nil {
+		return http.StatusOK
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limited == 0 {
+		return http.StatusOK
+	}
+
+	seconds := int(math.Ceil(l.retryAfter.Seconds()))
+	writer.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+	if l.allowed == 0 {
+		return http.StatusTooManyRequests
+	}
+	return http.StatusOK
+}
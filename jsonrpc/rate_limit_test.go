@@ -0,0 +1,134 @@
+package jsonrpc_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	var allowed, limited []string
+	limiter := jsonrpc.NewRateLimiter(2, 4).
+		WithWeights(map[string]uint{"heavy": 3, "huge": 10}).
+		WithClock(func() time.Time { return now }).
+		WithListener(&jsonrpc.SelectiveListener{
+			OnRequestAllowedCb: func(method string) { allowed = append(allowed, method) },
+			OnRequestLimitedCb: func(method string) { limited = append(limited, method) },
+		})
+
+	ok, _ := limiter.Take("a", "heavy")
+	assert.True(t, ok)
+	ok, _ = limiter.Take("a", "light")
+	assert.True(t, ok)
+
+	ok, retryAfter := limiter.Take("a", "heavy")
+	assert.False(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, retryAfter)
+
+	// other clients have their own buckets
+	ok, _ = limiter.Take("b", "heavy")
+	assert.True(t, ok)
+
+	// buckets refill over time
+	now = now.Add(1500 * time.Millisecond)
+	ok, _ = limiter.Take("a", "heavy")
+	assert.True(t, ok)
+
+	// methods heavier than the bucket take the whole bucket
+	now = now.Add(time.Hour)
+	ok, _ = limiter.Take("a", "huge")
+	assert.True(t, ok)
+	ok, _ = limiter.Take("a", "light")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"heavy", "light", "heavy", "heavy", "huge"}, allowed)
+	assert.Equal(t, []string{"heavy", "light"}, limited)
+}
+
+func TestHTTPRateLimit(t *testing.T) {
+	method := jsonrpc.Method{
+		Name: "echo",
+		Handler: func(msg string) (string, *jsonrpc.Error) {
+			return msg, nil
+		},
+		Params: []jsonrpc.Parameter{{Name: "msg"}},
+	}
+	log := utils.NewNopZapLogger()
+	rpc := jsonrpc.NewServer(1, log)
+	require.NoError(t, rpc.RegisterMethods(method))
+
+	now := time.Unix(0, 0)
+	limiter := jsonrpc.NewRateLimiter(0.5, 2).WithClock(func() time.Time { return now })
+	srv := httptest.NewServer(jsonrpc.NewHTTP(rpc, log).WithRateLimiter(limiter))
+	t.Cleanup(srv.Close)
+	auth := jsonrpc.NewAuth(jsonrpc.NewAPIKeyAuthenticator(map[string]*jsonrpc.Identity{
+		"key": {Name: "client", Namespaces: []string{jsonrpc.AllNamespaces}},
+	}))
+	authSrv := httptest.NewServer(jsonrpc.NewHTTP(rpc, log).WithRateLimiter(limiter).WithAuth(auth))
+	t.Cleanup(authSrv.Close)
+
+	postTo := func(t *testing.T, url, msg, apiKey string) (*http.Response, string) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader([]byte(msg)))
+		require.NoError(t, err)
+		if apiKey != "" {
+			req.Header.Set(jsonrpc.APIKeyHeader, apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp, string(body)
+	}
+	post := func(t *testing.T, msg, apiKey string) (*http.Response, string) {
+		return postTo(t, srv.URL, msg, apiKey)
+	}
+
+	single := `{"jsonrpc" : "2.0", "method" : "echo", "params" : [ "abc" ], "id" : 1}`
+	batch := `[{"jsonrpc" : "2.0", "method" : "echo", "params" : [ "abc" ], "id" : 1},
+		{"jsonrpc" : "2.0", "method" : "echo", "params" : [ "abc" ], "id" : 2}]`
+	limitedResponse := `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Request rate limit exceeded",` +
+		`"data":"retry after 2s"},"id":1}`
+
+	t.Run("single requests", func(t *testing.T) {
+		resp, body := post(t, single, "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Retry-After"))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"abc","id":1}`, body)
+
+		resp, _ = post(t, single, "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, body = post(t, single, "")
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+		assert.Equal(t, limitedResponse, body)
+	})
+
+	t.Run("unauthenticated api keys share the address limit", func(t *testing.T) {
+		resp, _ := post(t, single, "other key")
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	t.Run("authenticated clients have their own limit", func(t *testing.T) {
+		resp, _ := postTo(t, authSrv.URL, single, "key")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("partially limited batch", func(t *testing.T) {
+		now = now.Add(2 * time.Second)
+		resp, _ := post(t, batch, "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	})
+}
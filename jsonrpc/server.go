@@ -16,14 +16,18 @@ import (
 
 	"github.com/NethermindEth/juno/utils"
 	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	InvalidJSON    = -32700 // Invalid JSON was received by the server.
-	InvalidRequest = -32600 // The JSON sent is not a valid Request object.
-	MethodNotFound = -32601 // The method does not exist / is not available.
-	InvalidParams  = -32602 // Invalid method parameter(s).
-	InternalError  = -32603 // Internal JSON-RPC error.
+	InvalidJSON      = -32700 // Invalid JSON was received by the server.
+	InvalidRequest   = -32600 // The JSON sent is not a valid Request object.
+	MethodNotFound   = -32601 // The method does not exist / is not available.
+	InvalidParams    = -32602 // Invalid method parameter(s).
+	InternalError    = -32603 // Internal JSON-RPC error.
+	MethodNotAllowed = -32004 // The caller is not allowed to call the method.
+	LimitExceeded    = -32005 // Request exceeds defined limit.
 )
 
 var (
@@ -110,6 +114,8 @@ type Method struct {
 	Name    string
 	Params  []Parameter
 	Handler any
+	// Errors are the application errors the handler can return, they are listed in the OpenRPC document.
+	Errors []*Error
 
 	// The method takes a context as its first parameter.
 	// Set upon successful registration.
@@ -122,6 +128,7 @@ type Server struct {
 	pool      *pool.Pool
 	log       utils.SimpleLogger
 	listener  EventListener
+	cache     *ResponseCache
 }
 
 type Validator interface {
@@ -152,6 +159,12 @@ func (s *Server) WithListener(listener EventListener) *Server {
 	return s
 }
 
+// WithCache serves the responses the cache holds without calling their handlers
+func (s *Server) WithCache(cache *ResponseCache) *Server {
+	s.cache = cache
+	return s
+}
+
 // RegisterMethods verifies and creates an endpoint that the server recognises.
 //
 // - name is the method name
@@ -275,6 +288,9 @@ func (s *Server) HandleReadWriter(ctx context.Context, rw io.ReadWriter) error {
 func (s *Server) HandleReader(ctx context.Context, reader io.Reader) ([]byte, error) {
 	bufferedReader := bufio.NewReaderSize(reader, bufferSize)
 	requestIsBatch := isBatch(bufferedReader)
+	ctx, span := tracer.Start(ctx, "jsonrpc.HandleReader", trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.Bool("rpc.jsonrpc.batch", requestIsBatch)))
+	defer span.End()
 	res := &response{
 		Version: "2.0",
 	}
@@ -412,6 +428,24 @@ func (s *Server) handleRequest(ctx context.Context, req *Request) (*response, er
 		return res, nil
 	}
 
+	ctx, span := startRequestSpan(ctx, req.Method)
+	defer func() { endRequestSpan(span, res.Error) }()
+
+	if permissionErr := checkPermission(ctx, req.Method); permissionErr != nil {
+		if res.ID == nil { // notification
+			return nil, nil
+		}
+		res.Error = permissionErr
+		return res, nil
+	}
+	if limitErr := checkRateLimit(ctx, req.Method); limitErr != nil {
+		if res.ID == nil { // notification
+			return nil, nil
+		}
+		res.Error = limitErr
+		return res, nil
+	}
+
 	handlerTimer := time.Now()
 	s.listener.OnNewRequest(req.Method)
 	args, err := s.buildArguments(ctx, req.Params, calledMethod)
@@ -423,6 +457,18 @@ func (s *Server) handleRequest(ctx context.Context, req *Request) (*response, er
 		s.listener.OnRequestHandled(req.Method, time.Since(handlerTimer))
 	}()
 
+	var generation uint64
+	cacheKey, cached := s.cache.cacheKey(req.Method, req.Params)
+	if cached && res.ID != nil {
+		var result json.RawMessage
+		var hit bool
+		if result, generation, hit = s.cache.get(req.Method, cacheKey); hit {
+			span.SetAttributes(attribute.Bool("rpc.jsonrpc.cache_hit", true))
+			res.Result = result
+			return res, nil
+		}
+	}
+
 	tuple := reflect.ValueOf(calledMethod.Handler).Call(args)
 	if res.ID == nil { // notification
 		return nil, nil
@@ -434,11 +480,18 @@ func (s *Server) handleRequest(ctx context.Context, req *Request) (*response, er
 			s.listener.OnRequestFailed(req.Method, res.Error)
 			reqJSON, _ := json.Marshal(req)
 			errJSON, _ := json.Marshal(res.Error)
-			s.log.Debugw("Failed handing RPC request", "req", string(reqJSON), "res", string(errJSON))
+			logArgs := []any{"req", string(reqJSON), "res", string(errJSON)}
+			if identity, ok := IdentityFromContext(ctx); ok {
+				logArgs = append(logArgs, "identity", identity.Name)
+			}
+			s.log.Debugw("Failed handing RPC request", logArgs...)
 		}
 		return res, nil
 	}
 	res.Result = tuple[0].Interface()
+	if cached {
+		res.Result = s.cache.add(req.Method, cacheKey, generation, req.Params, res.Result)
+	}
 	return res, nil
 }
 
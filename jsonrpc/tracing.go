@@ -0,0 +1,35 @@
+package jsonrpc
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/NethermindEth/juno/jsonrpc")
+
+// traceContext continues the trace propagated in the headers of the request, if any
+func traceContext(req *http.Request) context.Context {
+	return otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+}
+
+func startRequestSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, method, trace.WithAttributes(
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", method),
+	))
+}
+
+// endRequestSpan records the error returned to the caller, if any, and ends the span
+func endRequestSpan(span trace.Span, rpcErr *Error) {
+	if rpcErr != nil {
+		span.SetAttributes(attribute.Int("rpc.jsonrpc.error_code", rpcErr.Code))
+		span.SetStatus(codes.Error, rpcErr.Message)
+	}
+	span.End()
+}
@@ -0,0 +1,68 @@
+package jsonrpc_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var handlerSpan trace.SpanContext
+	server := jsonrpc.NewServer(1, utils.NewNopZapLogger())
+	require.NoError(t, server.RegisterMethods(jsonrpc.Method{
+		Name: "test_fail",
+		Handler: func(ctx context.Context) (int, *jsonrpc.Error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return 0, jsonrpc.Err(jsonrpc.InternalError, nil)
+		},
+	}))
+	srv := httptest.NewServer(jsonrpc.NewHTTP(server, utils.NewNopZapLogger()))
+	t.Cleanup(srv.Close)
+
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL,
+		bytes.NewBufferString(`{"jsonrpc":"2.0","method":"test_fail","id":1}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	methodSpan, readerSpan := spans[0], spans[1]
+
+	assert.Equal(t, "jsonrpc.HandleReader", readerSpan.Name())
+	assert.Equal(t, traceID, readerSpan.SpanContext().TraceID().String())
+	assert.Equal(t, parentID, readerSpan.Parent().SpanID().String())
+
+	assert.Equal(t, "test_fail", methodSpan.Name())
+	assert.Equal(t, readerSpan.SpanContext().SpanID(), methodSpan.Parent().SpanID())
+	assert.Equal(t, codes.Error, methodSpan.Status().Code)
+	assert.Contains(t, methodSpan.Attributes(), attribute.Int("rpc.jsonrpc.error_code", jsonrpc.InternalError))
+
+	// the handler runs within the span of the method
+	assert.Equal(t, methodSpan.SpanContext().SpanID(), handlerSpan.SpanID())
+}
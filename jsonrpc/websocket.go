@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/NethermindEth/juno/utils"
@@ -18,6 +19,8 @@ type Websocket struct {
 	log        utils.SimpleLogger
 	connParams *WebsocketConnParams
 	listener   NewRequestListener
+	limiter    *RateLimiter
+	auth       *Auth
 }
 
 func NewWebsocket(rpc *Server, log utils.SimpleLogger) *Websocket {
@@ -43,9 +46,29 @@ func (ws *Websocket) WithListener(listener NewRequestListener) *Websocket {
 	return ws
 }
 
+// WithRateLimiter limits the rate of requests per client, all the requests of a connection are attributed to the
+// client that opened it
+func (ws *Websocket) WithRateLimiter(limiter *RateLimiter) *Websocket {
+	ws.limiter = limiter
+	return ws
+}
+
+// WithAuth requires connections to be authenticated when they are opened. Connections are closed once their
+// credentials expire.
+func (ws *Websocket) WithAuth(auth *Auth) *Websocket {
+	ws.auth = auth
+	return ws
+}
+
 // ServeHTTP processes an HTTP request and upgrades it to a websocket connection.
 // The connection's entire "lifetime" is spent in this function.
 func (ws *Websocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, err := withAuth(r.Context(), ws.auth, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
 	conn, err := websocket.Accept(w, r, nil /* TODO: options */)
 	if err != nil {
 		ws.log.Errorw("Failed to upgrade connection", "err", err)
@@ -54,7 +77,21 @@ func (ws *Websocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// TODO include connection information, such as the remote address, in the logs.
 
-	wsc := newWebsocketConn(r.Context(), conn, ws.connParams)
+	ctx, _ = withRateLimit(ctx, ws.limiter, r)
+	wsc := newWebsocketConn(ctx, conn, ws.connParams)
+
+	var expired atomic.Bool
+	if identity, ok := IdentityFromContext(ctx); ok && !identity.Expiry.IsZero() {
+		expiry := time.AfterFunc(time.Until(identity.Expiry), func() {
+			expired.Store(true)
+			ws.log.Debugw("Closing websocket connection with expired credentials")
+			closeErr := conn.Close(websocket.StatusPolicyViolation, "credentials expired")
+			if closeErr != nil && websocket.CloseStatus(closeErr) == -1 {
+				ws.log.Debugw("Failed to close websocket connection", "err", closeErr)
+			}
+		})
+		defer expiry.Stop()
+	}
 
 	for {
 		_, wsc.r, err = wsc.conn.Reader(wsc.ctx)
@@ -71,6 +108,9 @@ func (ws *Websocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if expired.Load() {
+		return
+	}
 	if status := websocket.CloseStatus(err); status != -1 {
 		ws.log.Infow("Client closed websocket connection", "status", status)
 		return
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/NethermindEth/juno/blockchain"
@@ -33,6 +34,7 @@ type Client struct {
 	pollFinalisedInterval time.Duration
 	nonFinalisedLogs      map[uint64]*contract.StarknetLogStateUpdate
 	listener              EventListener
+	subscribed            atomic.Bool
 }
 
 var _ service.Service = (*Client)(nil)
@@ -66,6 +68,11 @@ func (c *Client) WithPollFinalisedInterval(delay time.Duration) *Client {
 	return c
 }
 
+// Subscribed reports whether the client is currently subscribed to the state updates on L1
+func (c *Client) Subscribed() bool {
+	return c.subscribed.Load()
+}
+
 func (c *Client) subscribeToUpdates(ctx context.Context, updateChan chan *contract.StarknetLogStateUpdate) (event.Subscription, error) {
 	for {
 		select {
@@ -114,6 +121,8 @@ func (c *Client) Run(ctx context.Context) error {
 		return err
 	}
 	defer updateSub.Unsubscribe()
+	c.subscribed.Store(true)
+	defer c.subscribed.Store(false)
 
 	c.log.Infow("Subscribed to L1 updates")
 
@@ -132,6 +141,7 @@ func (c *Client) Run(ctx context.Context) error {
 					// We can't use a warn log level here since we guarantee the L1 url will only be printed
 					// in debug logs and panics (to avoid leaking the API key).
 					c.log.Debugw("L1 update subscription failed, resubscribing", "error", err)
+					c.subscribed.Store(false)
 					updateSub.Unsubscribe()
 
 					updateSub, err = c.subscribeToUpdates(ctx, updateChan)
@@ -139,6 +149,7 @@ func (c *Client) Run(ctx context.Context) error {
 						return err
 					}
 					defer updateSub.Unsubscribe() //nolint:gocritic
+					c.subscribed.Store(true)
 				case logStateUpdate := <-updateChan:
 					c.log.Debugw("Received L1 LogStateUpdate",
 						"number", logStateUpdate.BlockNumber,
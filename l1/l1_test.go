@@ -72,6 +72,7 @@ func TestFailToCreateSubscription(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	require.ErrorContains(t, client.Run(ctx), "context canceled before resubscribe was successful")
 	cancel()
+	require.False(t, client.Subscribed())
 }
 
 func TestMismatchedChainID(t *testing.T) {
@@ -136,12 +137,15 @@ func TestEventListener(t *testing.T) {
 	subscriber.EXPECT().Close().Times(1)
 
 	var got *core.L1Head
-	client := l1.NewClient(subscriber, chain, nopLog).
+	var subscribed bool
+	var client *l1.Client
+	client = l1.NewClient(subscriber, chain, nopLog).
 		WithResubscribeDelay(0).
 		WithPollFinalisedInterval(time.Nanosecond).
 		WithEventListener(l1.SelectiveListener{
 			OnNewL1HeadCb: func(head *core.L1Head) {
 				got = head
+				subscribed = client.Subscribed()
 			},
 		})
 
@@ -149,6 +153,9 @@ func TestEventListener(t *testing.T) {
 	require.NoError(t, client.Run(ctx))
 	cancel()
 
+	require.True(t, subscribed)
+	require.False(t, client.Subscribed())
+
 	require.Equal(t, &core.L1Head{
 		BlockHash: new(felt.Felt),
 		StateRoot: new(felt.Felt),
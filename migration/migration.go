@@ -63,8 +63,10 @@ var defaultMigrations = []Migration{
 	NewBucketMigrator(db.ContractStorage, migrateTrieNodesFromBitsetToTrieKey(db.ContractStorage)).
 		WithKeyFilter(nodesFilter(db.ContractStorage)),
 	NewBucketMover(db.Temporary, db.ContractStorage),
-	NewBucketMigrator(db.StateUpdatesByBlockNumber, changeStateDiffStruct).WithBatchSize(100), //nolint:gomnd
-	NewBucketMigrator(db.Class, migrateCairo1CompiledClass).WithBatchSize(1_000),              //nolint:gomnd
+	NewBucketMigrator(db.StateUpdatesByBlockNumber, changeStateDiffStruct).WithBatchSize(100),               //nolint:gomnd
+	NewBucketMigrator(db.Class, migrateCairo1CompiledClass).WithBatchSize(1_000),                            //nolint:gomnd
+	NewBucketMigrator(db.StateUpdatesByBlockNumber, indexContractsByClassHash).WithBatchSize(100),           //nolint:gomnd
+	NewBucketMigrator(db.TransactionsByBlockNumberAndIndex, indexTransactionsBySender).WithBatchSize(1_000), //nolint:gomnd
 }
 
 var ErrCallWithNewTransaction = errors.New("call with new transaction")
@@ -640,6 +642,37 @@ func changeStateDiffStruct(txn db.Transaction, key, value []byte, _ *utils.Netwo
 	return nil
 }
 
+// indexContractsByClassHash builds the class hash to contracts index from the deployed contracts and replaced classes
+// of stored state updates
+func indexContractsByClassHash(txn db.Transaction, key, value []byte, _ *utils.Network) error {
+	update := new(core.StateUpdate)
+	if err := encoder.Unmarshal(value, update); err != nil {
+		return fmt.Errorf("unmarshal: %v", err)
+	}
+
+	blockNumber := binary.BigEndian.Uint64(key[1:])
+	for _, contracts := range []map[felt.Felt]*felt.Felt{update.StateDiff.DeployedContracts, update.StateDiff.ReplacedClasses} {
+		for addr, classHash := range contracts {
+			if err := core.IndexContractClass(txn, &addr, classHash, blockNumber); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// indexTransactionsBySender builds the sender index from the stored transactions
+func indexTransactionsBySender(txn db.Transaction, key, value []byte, _ *utils.Network) error {
+	var transaction core.Transaction
+	if err := encoder.Unmarshal(value, &transaction); err != nil {
+		return fmt.Errorf("unmarshal: %v", err)
+	}
+
+	blockNumber := binary.BigEndian.Uint64(key[1:])
+	index := binary.BigEndian.Uint64(key[9:])
+	return blockchain.IndexTransactionSender(txn, transaction, blockNumber, index)
+}
+
 type declaredClass struct {
 	At    uint64
 	Class oldCairo1Class
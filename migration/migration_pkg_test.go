@@ -697,3 +697,68 @@ func randFelt(t *testing.T) *felt.Felt {
 
 	return f
 }
+
+func TestIndexContractsByClassHash(t *testing.T) {
+	testdb := pebble.NewMemTest(t)
+	classHash := utils.HexToFelt(t, "0xc1")
+	deployed, replaced := utils.HexToFelt(t, "0x1"), utils.HexToFelt(t, "0x2")
+
+	require.NoError(t, testdb.Update(func(txn db.Transaction) error {
+		suBytes, err := encoder.Marshal(&core.StateUpdate{
+			StateDiff: &core.StateDiff{
+				DeployedContracts: map[felt.Felt]*felt.Felt{*deployed: classHash},
+				ReplacedClasses:   map[felt.Felt]*felt.Felt{*replaced: classHash},
+			},
+		})
+		require.NoError(t, err)
+		return txn.Set(db.StateUpdatesByBlockNumber.Key(core.MarshalBlockNumber(3)), suBytes)
+	}))
+
+	require.NoError(t, testdb.Update(func(txn db.Transaction) error {
+		migrator := NewBucketMigrator(db.StateUpdatesByBlockNumber, indexContractsByClassHash)
+		require.NoError(t, migrator.Before(nil))
+		intermediateState, err := migrator.Migrate(context.Background(), txn, &utils.Mainnet)
+		require.NoError(t, err)
+		require.Nil(t, intermediateState)
+		return nil
+	}))
+
+	require.NoError(t, testdb.View(func(txn db.Transaction) error {
+		for _, addr := range []*felt.Felt{deployed, replaced} {
+			key := db.ContractsByClassHash.Key(classHash.Marshal(), addr.Marshal(), core.MarshalBlockNumber(3))
+			require.NoError(t, txn.Get(key, func([]byte) error { return nil }))
+		}
+		return nil
+	}))
+}
+
+func TestIndexTransactionsBySender(t *testing.T) {
+	testdb := pebble.NewMemTest(t)
+	sender := utils.HexToFelt(t, "0xa")
+	nonce := utils.HexToFelt(t, "0x5")
+
+	txKey := db.TransactionsByBlockNumberAndIndex.Key(core.MarshalBlockNumber(3), core.MarshalBlockNumber(2))
+	require.NoError(t, testdb.Update(func(txn db.Transaction) error {
+		txBytes, err := encoder.Marshal(core.Transaction(&core.InvokeTransaction{
+			TransactionHash: utils.HexToFelt(t, "0x1"),
+			SenderAddress:   sender,
+			Nonce:           nonce,
+		}))
+		require.NoError(t, err)
+		return txn.Set(txKey, txBytes)
+	}))
+
+	require.NoError(t, testdb.Update(func(txn db.Transaction) error {
+		migrator := NewBucketMigrator(db.TransactionsByBlockNumberAndIndex, indexTransactionsBySender)
+		require.NoError(t, migrator.Before(nil))
+		intermediateState, err := migrator.Migrate(context.Background(), txn, &utils.Mainnet)
+		require.NoError(t, err)
+		require.Nil(t, intermediateState)
+		return nil
+	}))
+
+	require.NoError(t, testdb.View(func(txn db.Transaction) error {
+		id := blockchain.SenderTransactionID{Nonce: *nonce, BlockNumber: 3, Index: 2}
+		return txn.Get(db.TransactionsBySender.Key(sender.Marshal(), id.MarshalBinary()), func([]byte) error { return nil })
+	}))
+}
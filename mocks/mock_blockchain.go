@@ -117,6 +117,70 @@ func (mr *MockReaderMockRecorder) BlockHeaderByNumber(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockHeaderByNumber", reflect.TypeOf((*MockReader)(nil).BlockHeaderByNumber), arg0)
 }
 
+// ContractClassHashChanges mocks base method.
+func (m *MockReader) ContractClassHashChanges(arg0 *felt.Felt, arg1, arg2, arg3 uint64) ([]*core.ValueChange, *uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractClassHashChanges", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*core.ValueChange)
+	ret1, _ := ret[1].(*uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ContractClassHashChanges indicates an expected call of ContractClassHashChanges.
+func (mr *MockReaderMockRecorder) ContractClassHashChanges(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractClassHashChanges", reflect.TypeOf((*MockReader)(nil).ContractClassHashChanges), arg0, arg1, arg2, arg3)
+}
+
+// ContractNonceChanges mocks base method.
+func (m *MockReader) ContractNonceChanges(arg0 *felt.Felt, arg1, arg2, arg3 uint64) ([]*core.ValueChange, *uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractNonceChanges", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*core.ValueChange)
+	ret1, _ := ret[1].(*uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ContractNonceChanges indicates an expected call of ContractNonceChanges.
+func (mr *MockReaderMockRecorder) ContractNonceChanges(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractNonceChanges", reflect.TypeOf((*MockReader)(nil).ContractNonceChanges), arg0, arg1, arg2, arg3)
+}
+
+// ContractStorageChanges mocks base method.
+func (m *MockReader) ContractStorageChanges(arg0, arg1 *felt.Felt, arg2, arg3, arg4 uint64) ([]*core.ValueChange, *uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractStorageChanges", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*core.ValueChange)
+	ret1, _ := ret[1].(*uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ContractStorageChanges indicates an expected call of ContractStorageChanges.
+func (mr *MockReaderMockRecorder) ContractStorageChanges(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractStorageChanges", reflect.TypeOf((*MockReader)(nil).ContractStorageChanges), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ContractsByClassHash mocks base method.
+func (m *MockReader) ContractsByClassHash(arg0, arg1 *felt.Felt, arg2 uint64) ([]*core.ClassInstance, *felt.Felt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractsByClassHash", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*core.ClassInstance)
+	ret1, _ := ret[1].(*felt.Felt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ContractsByClassHash indicates an expected call of ContractsByClassHash.
+func (mr *MockReaderMockRecorder) ContractsByClassHash(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractsByClassHash", reflect.TypeOf((*MockReader)(nil).ContractsByClassHash), arg0, arg1, arg2)
+}
+
 // EventFilter mocks base method.
 func (m *MockReader) EventFilter(arg0 *felt.Felt, arg1 [][]felt.Felt) (*blockchain.EventFilter, error) {
 	m.ctrl.T.Helper()
@@ -193,6 +257,21 @@ func (mr *MockReaderMockRecorder) Height() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Height", reflect.TypeOf((*MockReader)(nil).Height))
 }
 
+// IndexedTransferBlocks mocks base method.
+func (m *MockReader) IndexedTransferBlocks() ([]blockchain.BlockRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexedTransferBlocks")
+	ret0, _ := ret[0].([]blockchain.BlockRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexedTransferBlocks indicates an expected call of IndexedTransferBlocks.
+func (mr *MockReaderMockRecorder) IndexedTransferBlocks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexedTransferBlocks", reflect.TypeOf((*MockReader)(nil).IndexedTransferBlocks))
+}
+
 // L1Head mocks base method.
 func (m *MockReader) L1Head() (*core.L1Head, error) {
 	m.ctrl.T.Helper()
@@ -270,6 +349,21 @@ func (mr *MockReaderMockRecorder) Receipt(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receipt", reflect.TypeOf((*MockReader)(nil).Receipt), arg0)
 }
 
+// ReceiptsByBlockNumber mocks base method.
+func (m *MockReader) ReceiptsByBlockNumber(arg0 uint64) ([]*core.TransactionReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiptsByBlockNumber", arg0)
+	ret0, _ := ret[0].([]*core.TransactionReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiptsByBlockNumber indicates an expected call of ReceiptsByBlockNumber.
+func (mr *MockReaderMockRecorder) ReceiptsByBlockNumber(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiptsByBlockNumber", reflect.TypeOf((*MockReader)(nil).ReceiptsByBlockNumber), arg0)
+}
+
 // StateAtBlockHash mocks base method.
 func (m *MockReader) StateAtBlockHash(arg0 *felt.Felt) (core.StateReader, func() error, error) {
 	m.ctrl.T.Helper()
@@ -361,3 +455,35 @@ func (mr *MockReaderMockRecorder) TransactionByHash(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionByHash", reflect.TypeOf((*MockReader)(nil).TransactionByHash), arg0)
 }
+
+// TransactionsBySender mocks base method.
+func (m *MockReader) TransactionsBySender(arg0 *felt.Felt, arg1 *blockchain.SenderTransactionsFilter, arg2 *blockchain.SenderTransactionID, arg3 uint64) ([]*blockchain.SenderTransaction, *blockchain.SenderTransactionID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionsBySender", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*blockchain.SenderTransaction)
+	ret1, _ := ret[1].(*blockchain.SenderTransactionID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TransactionsBySender indicates an expected call of TransactionsBySender.
+func (mr *MockReaderMockRecorder) TransactionsBySender(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionsBySender", reflect.TypeOf((*MockReader)(nil).TransactionsBySender), arg0, arg1, arg2, arg3)
+}
+
+// Transfers mocks base method.
+func (m *MockReader) Transfers(arg0, arg1 *felt.Felt, arg2 *blockchain.TransferID, arg3 uint64) ([]*blockchain.Transfer, *blockchain.TransferID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*blockchain.Transfer)
+	ret1, _ := ret[1].(*blockchain.TransferID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Transfers indicates an expected call of Transfers.
+func (mr *MockReaderMockRecorder) Transfers(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfers", reflect.TypeOf((*MockReader)(nil).Transfers), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,64 @@
+package node
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/NethermindEth/juno/admin"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/mitchellh/mapstructure"
+)
+
+const redacted = "<redacted>"
+
+// secretConfigs are the options juno_admin_config doesn't reveal, the Ethereum node URL usually embeds an API key
+var secretConfigs = []string{"eth-node", "rpc-jwt-secret", "admin-jwt-secret", "gw-api-key", "p2p-private-key"}
+
+// adminConfig returns the configuration served by juno_admin_config, keyed by option name and without the secrets
+func adminConfig(cfg *Config) (map[string]any, error) {
+	config := make(map[string]any)
+	if err := mapstructure.Decode(cfg, &config); err != nil {
+		return nil, err
+	}
+
+	for name, value := range config {
+		switch v := value.(type) {
+		case utils.LogLevel:
+			config[name] = v.String()
+		case time.Duration:
+			config[name] = v.String()
+		}
+	}
+	for _, name := range secretConfigs {
+		if value, found := config[name]; found && !reflect.ValueOf(value).IsZero() {
+			config[name] = redacted
+		}
+	}
+	apiKeys := make([]APIKey, 0, len(cfg.RPCAPIKeys))
+	for _, key := range cfg.RPCAPIKeys {
+		key.Key = redacted
+		apiKeys = append(apiKeys, key)
+	}
+	config["rpc-api-keys"] = apiKeys
+	return config, nil
+}
+
+// makeAdmin serves the admin methods on a listener of their own, which always requires authentication
+func makeAdmin(cfg *Config, handler *admin.Handler, log utils.SimpleLogger) (*httpService, error) {
+	auth, err := makeRPCAuth("admin", nil, cfg.AdminAPIKeysFile, cfg.AdminJWTSecret, cfg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	if auth == nil {
+		return nil, errors.New("the admin RPC requires an API keys file or a JWT secret")
+	}
+
+	server := jsonrpc.NewServer(1, log)
+	if err = server.RegisterMethods(handler.Methods()...); err != nil {
+		return nil, err
+	}
+	return makeRPCOverHTTP(cfg.AdminHost, cfg.AdminPort, map[string]*jsonrpc.Server{"/": server}, nil, log, false, false, nil, auth),
+		nil
+}
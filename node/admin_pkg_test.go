@@ -0,0 +1,39 @@
+package node
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminConfig(t *testing.T) {
+	cfg := &Config{
+		LogLevel:            utils.DEBUG,
+		HTTPPort:            6060,
+		Network:             utils.Sepolia,
+		EthNode:             "wss://eth.example.com/v3/secret",
+		PendingPollInterval: 5 * time.Second,
+		RPCAPIKeys:          []APIKey{{Name: "indexer", Key: "secret", Namespaces: []string{"starknet"}}},
+		RPCJWTSecret:        "0x1234",
+	}
+
+	config, err := adminConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", config["log-level"])
+	assert.Equal(t, uint16(6060), config["http-port"])
+	assert.Equal(t, "5s", config["pending-poll-interval"])
+	assert.Equal(t, redacted, config["eth-node"])
+	assert.Equal(t, redacted, config["rpc-jwt-secret"])
+	assert.Equal(t, "", config["gw-api-key"], "unset secrets are left as they are")
+	assert.Equal(t, []APIKey{{Name: "indexer", Key: redacted, Namespaces: []string{"starknet"}}}, config["rpc-api-keys"])
+
+	_, err = json.Marshal(config)
+	require.NoError(t, err)
+
+	// the config of the node is left untouched
+	assert.Equal(t, "secret", cfg.RPCAPIKeys[0].Key)
+}
@@ -0,0 +1,83 @@
+package node
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"gopkg.in/yaml.v3"
+)
+
+// APIKey is a static credential for the RPC endpoints. Namespaces lists the method namespaces the key is allowed to
+// call, e.g. starknet or juno, "*" allows every method.
+type APIKey struct {
+	Name       string   `mapstructure:"name" yaml:"name"`
+	Key        string   `mapstructure:"key" yaml:"key"`
+	Namespaces []string `mapstructure:"namespaces" yaml:"namespaces"`
+}
+
+// loadAPIKeys reads a YAML or JSON list of API keys
+func loadAPIKeys(path string) ([]APIKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read API keys: %w", err)
+	}
+
+	var keys []APIKey
+	if err = yaml.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("parse API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// makeRPCAuth returns nil if neither API keys nor a JWT secret are configured
+func makeRPCAuth(subsystem string, keys []APIKey, keysFile, jwtSecret string, metricsEnabled bool) (*jsonrpc.Auth, error) {
+	if keysFile != "" {
+		fileKeys, err := loadAPIKeys(keysFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys[:len(keys):len(keys)], fileKeys...)
+	}
+
+	var authenticators []jsonrpc.Authenticator
+	names := make(map[string]struct{}, len(keys))
+	if len(keys) > 0 {
+		identities := make(map[string]*jsonrpc.Identity, len(keys))
+		for _, key := range keys {
+			if key.Name == "" || key.Key == "" {
+				return nil, errors.New("API keys need a name and a key")
+			}
+			if _, found := identities[key.Key]; found {
+				return nil, fmt.Errorf("duplicate API key for %s", key.Name)
+			}
+			identities[key.Key] = &jsonrpc.Identity{
+				Name:       key.Name,
+				Namespaces: key.Namespaces,
+			}
+			names[key.Name] = struct{}{}
+		}
+		authenticators = append(authenticators, jsonrpc.NewAPIKeyAuthenticator(identities))
+	}
+
+	if jwtSecret != "" {
+		secret, err := hex.DecodeString(strings.TrimPrefix(jwtSecret, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("decode JWT secret: %w", err)
+		}
+		authenticators = append(authenticators, jsonrpc.NewJWTAuthenticator(secret))
+	}
+
+	if len(authenticators) == 0 {
+		return nil, nil
+	}
+
+	auth := jsonrpc.NewAuth(authenticators...)
+	if metricsEnabled {
+		auth = auth.WithListener(makeAuthMetrics(subsystem, names))
+	}
+	return auth, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -11,11 +12,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/NethermindEth/juno/blockchain"
 	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/feedergateway"
+	"github.com/NethermindEth/juno/graphql"
 	junogrpc "github.com/NethermindEth/juno/grpc"
 	"github.com/NethermindEth/juno/grpc/gen"
+	"github.com/NethermindEth/juno/health"
 	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/l1"
 	"github.com/NethermindEth/juno/service"
+	"github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -71,8 +78,59 @@ func exactPathServer(path string, handler http.Handler) http.HandlerFunc {
 	}
 }
 
-func makeRPCOverHTTP(host string, port uint16, servers map[string]*jsonrpc.Server,
-	log utils.SimpleLogger, metricsEnabled bool, corsEnabled bool,
+// rpcMethodWeights are the rate limiter costs of the methods that are more expensive than a simple lookup,
+// every other method costs a single token.
+var rpcMethodWeights = map[string]uint{
+	"starknet_call":                   5,
+	"starknet_getEvents":              5,
+	"juno_feeHistory":                 5,
+	"juno_suggestResourceBounds":      5,
+	"starknet_estimateFee":            10,
+	"starknet_estimateMessageFee":     10,
+	"starknet_simulateTransactions":   10,
+	"starknet_traceTransaction":       10,
+	"starknet_traceBlockTransactions": 50,
+}
+
+// makeRateLimiter returns nil if rate limiting is disabled. If burst is 0, the buckets hold enough tokens for a
+// second worth of requests or the most expensive method, whichever is larger.
+func makeRateLimiter(name string, rate float64, burst uint, metricsEnabled bool) *jsonrpc.RateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+
+	if burst == 0 {
+		burst = uint(math.Ceil(rate))
+		for _, weight := range rpcMethodWeights {
+			burst = max(burst, weight)
+		}
+	}
+
+	limiter := jsonrpc.NewRateLimiter(rate, burst).WithWeights(rpcMethodWeights)
+	if metricsEnabled {
+		limiter = limiter.WithListener(makeRateLimitMetrics(name))
+	}
+	return limiter
+}
+
+// publicPaths are served without authentication and rate limiting, so that load balancers and orchestrators can
+// check the health of the node
+var publicPaths = map[string]bool{
+	"/health": true,
+	"/ready":  true,
+}
+
+// extraHandler is served next to the RPC servers
+type extraHandler struct {
+	handler http.Handler
+	// method names the requests of the handler for rate limiting and authorisation, callers need access to its namespace
+	method string
+}
+
+// makeRPCOverHTTP serves the RPC servers and the extra handlers, such as GraphQL, on their paths. Extra handlers are
+// authenticated and rate limited like the RPC servers unless their path is public.
+func makeRPCOverHTTP(host string, port uint16, servers map[string]*jsonrpc.Server, extraHandlers map[string]extraHandler,
+	log utils.SimpleLogger, metricsEnabled bool, corsEnabled bool, limiter *jsonrpc.RateLimiter, auth *jsonrpc.Auth,
 ) *httpService {
 	var listener jsonrpc.NewRequestListener
 	if metricsEnabled {
@@ -85,9 +143,36 @@ func makeRPCOverHTTP(host string, port uint16, servers map[string]*jsonrpc.Serve
 		if listener != nil {
 			httpHandler = httpHandler.WithListener(listener)
 		}
+		if limiter != nil {
+			httpHandler = httpHandler.WithRateLimiter(limiter)
+		}
+		if auth != nil {
+			httpHandler = httpHandler.WithAuth(auth)
+		}
 		mux.Handle(path, exactPathServer(path, httpHandler))
 	}
 
+	middleware := jsonrpc.NewHTTPMiddleware()
+	if limiter != nil {
+		middleware = middleware.WithRateLimiter(limiter)
+	}
+	if auth != nil {
+		middleware = middleware.WithAuth(auth)
+	}
+	for path, extra := range extraHandlers {
+		handler := extra.handler
+		if !publicPaths[path] {
+			handler = middleware.Wrap(extra.method, handler)
+		}
+
+		if strings.HasSuffix(path, "/") {
+			// the handler serves the whole subtree
+			mux.Handle(path, handler)
+			continue
+		}
+		mux.Handle(path, exactPathServer(path, handler))
+	}
+
 	var handler http.Handler = mux
 	if corsEnabled {
 		handler = cors.Default().Handler(handler)
@@ -96,7 +181,7 @@ func makeRPCOverHTTP(host string, port uint16, servers map[string]*jsonrpc.Serve
 }
 
 func makeRPCOverWebsocket(host string, port uint16, servers map[string]*jsonrpc.Server,
-	log utils.SimpleLogger, metricsEnabled bool, corsEnabled bool,
+	log utils.SimpleLogger, metricsEnabled bool, corsEnabled bool, limiter *jsonrpc.RateLimiter, auth *jsonrpc.Auth,
 ) *httpService {
 	var listener jsonrpc.NewRequestListener
 	if metricsEnabled {
@@ -109,6 +194,12 @@ func makeRPCOverWebsocket(host string, port uint16, servers map[string]*jsonrpc.
 		if listener != nil {
 			wsHandler = wsHandler.WithListener(listener)
 		}
+		if limiter != nil {
+			wsHandler = wsHandler.WithRateLimiter(limiter)
+		}
+		if auth != nil {
+			wsHandler = wsHandler.WithAuth(auth)
+		}
 		mux.Handle(path, exactPathServer(path, wsHandler))
 		wsPrefixedPath := strings.TrimSuffix("/ws"+path, "/")
 		mux.Handle(wsPrefixedPath, exactPathServer(wsPrefixedPath, wsHandler))
@@ -121,6 +212,48 @@ func makeRPCOverWebsocket(host string, port uint16, servers map[string]*jsonrpc.
 	return makeHTTPService(host, port, handler)
 }
 
+// makeGraphQL returns the GraphQL handler, limits that are 0 are replaced with their defaults
+func makeGraphQL(bcReader blockchain.Reader, maxDepth, maxComplexity uint, log utils.SimpleLogger) *graphql.Handler {
+	if maxDepth == 0 {
+		maxDepth = graphql.DefaultMaxDepth
+	}
+	if maxComplexity == 0 {
+		maxComplexity = graphql.DefaultMaxComplexity
+	}
+	return graphql.New(bcReader, log).WithLimits(maxDepth, maxComplexity)
+}
+
+// makeFeederGateway returns the feeder gateway handler and the subtree of the HTTP server it is served on
+func makeFeederGateway(path string, bcReader blockchain.Reader, log utils.SimpleLogger) (string, http.Handler, error) {
+	prefix := strings.TrimSuffix(path, "/")
+	if prefix == "" {
+		return "", nil, errors.New("the feeder gateway cannot be served on the root path")
+	}
+	return prefix + "/", http.StripPrefix(prefix, feedergateway.New(bcReader, log)), nil
+}
+
+// makeHealth returns the health checker of the node, thresholds that are 0 are replaced with their defaults. The sync
+// and L1 components are disabled if the node doesn't run a synchroniser or an L1 client.
+func makeHealth(database db.DB, bcReader blockchain.Reader, synchronizer *sync.Synchronizer, l1Client *l1.Client,
+	maxBlocksBehind uint, maxHeadAge time.Duration, log utils.SimpleLogger,
+) *health.Checker {
+	if maxBlocksBehind == 0 {
+		maxBlocksBehind = health.DefaultMaxBlocksBehind
+	}
+	if maxHeadAge == 0 {
+		maxHeadAge = health.DefaultMaxHeadAge
+	}
+
+	checker := health.New(database, bcReader, log).WithThresholds(uint64(maxBlocksBehind), maxHeadAge)
+	if synchronizer != nil {
+		checker = checker.WithSync(synchronizer)
+	}
+	if l1Client != nil {
+		checker = checker.WithL1(l1Client)
+	}
+	return checker
+}
+
 func makeMetrics(host string, port uint16) *httpService {
 	return makeHTTPService(host, port,
 		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{Registry: prometheus.DefaultRegisterer}))
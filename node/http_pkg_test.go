@@ -0,0 +1,43 @@
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCOverHTTPExtraHandlers(t *testing.T) {
+	log := utils.NewNopZapLogger()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	auth := jsonrpc.NewAuth(jsonrpc.NewAPIKeyAuthenticator(map[string]*jsonrpc.Identity{
+		"key": {Name: "client", Namespaces: []string{jsonrpc.AllNamespaces}},
+	}))
+	service := makeRPCOverHTTP("localhost", 0, nil, map[string]extraHandler{
+		"/health":   {handler: ok},
+		"/ready":    {handler: ok},
+		"/graphql":  {handler: ok, method: "graphql"},
+		"/gateway/": {handler: ok, method: "feedergateway"},
+	}, log, false, false, nil, auth)
+
+	serve := func(path, apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		if apiKey != "" {
+			req.Header.Set(jsonrpc.APIKeyHeader, apiKey)
+		}
+		rec := httptest.NewRecorder()
+		service.srv.Handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for _, path := range []string{"/health", "/ready"} {
+		assert.Equal(t, http.StatusOK, serve(path, ""), path)
+	}
+	for _, path := range []string{"/graphql", "/gateway/get_block"} {
+		assert.Equal(t, http.StatusUnauthorized, serve(path, ""), path)
+		assert.Equal(t, http.StatusOK, serve(path, "key"), path)
+	}
+}
@@ -0,0 +1,102 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/service"
+	"github.com/NethermindEth/juno/utils"
+)
+
+// ipcSocketPermissions only lets processes running as the same user as the node connect to the socket
+const ipcSocketPermissions fs.FileMode = 0o600
+
+type ipcService struct {
+	path string
+	ipc  *jsonrpc.IPC
+	log  utils.SimpleLogger
+}
+
+var _ service.Service = (*ipcService)(nil)
+
+func (i *ipcService) Run(ctx context.Context) error {
+	if err := removeStaleSocket(i.path); err != nil {
+		return err
+	}
+
+	l, err := listenIPC(i.path)
+	if err != nil {
+		return fmt.Errorf("listen on IPC socket %s: %w", i.path, err)
+	}
+	defer func() {
+		if rmErr := os.Remove(i.path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			i.log.Warnw("Failed to remove IPC socket", "err", rmErr)
+		}
+	}()
+
+	return i.ipc.Serve(ctx, l)
+}
+
+// removeStaleSocket removes a socket left behind by a node that didn't shut down cleanly, which would make listening
+// fail. Anything else at path is left alone.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("check IPC socket path: %w", err)
+	}
+
+	if info.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf("IPC socket path %s exists and is not a socket", path)
+	}
+	if err = os.Remove(path); err != nil {
+		return fmt.Errorf("remove stale IPC socket: %w", err)
+	}
+	return nil
+}
+
+// listenIPC listens on a Unix domain socket at path that only the user running the node can connect to.
+// The socket is created in a private directory and moved to path once its permissions are set, so it is never
+// accessible to other users.
+func listenIPC(path string) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".juno-ipc-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	tmpPath := filepath.Join(dir, "socket")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmpPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// the socket is moved, so the listener can't remove it when it is closed
+	l.SetUnlinkOnClose(false)
+
+	if err = os.Chmod(tmpPath, ipcSocketPermissions); err != nil {
+		return nil, errors.Join(err, l.Close())
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		return nil, errors.Join(err, l.Close())
+	}
+	return l, nil
+}
+
+func makeRPCOverIPC(path string, server *jsonrpc.Server, log utils.SimpleLogger, metricsEnabled bool) *ipcService {
+	ipc := jsonrpc.NewIPC(server, log)
+	if metricsEnabled {
+		ipc = ipc.WithListener(makeIPCMetrics())
+	}
+	return &ipcService{
+		path: path,
+		ipc:  ipc,
+		log:  log,
+	}
+}
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"context"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPCService(t *testing.T) {
+	log := utils.NewNopZapLogger()
+	server := jsonrpc.NewServer(1, log)
+
+	t.Run("doesn't remove files that aren't sockets", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "juno.ipc")
+		require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+		err := makeRPCOverIPC(path, server, log, false).Run(context.Background())
+		require.ErrorContains(t, err, "is not a socket")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data"), data)
+	})
+
+	t.Run("replaces stale sockets", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "juno.ipc")
+		stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+		require.NoError(t, err)
+		stale.SetUnlinkOnClose(false)
+		require.NoError(t, stale.Close())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		served := make(chan error)
+		go func() {
+			served <- makeRPCOverIPC(path, server, log, false).Run(ctx)
+		}()
+
+		require.Eventually(t, func() bool {
+			conn, dialErr := net.Dial("unix", path)
+			if dialErr != nil {
+				return false
+			}
+			return conn.Close() == nil
+		}, time.Second, 10*time.Millisecond)
+
+		info, err := os.Lstat(path)
+		require.NoError(t, err)
+		assert.Equal(t, fs.ModeSocket, info.Mode().Type())
+		assert.Equal(t, ipcSocketPermissions, info.Mode().Perm())
+		// only the socket is left in the directory
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		cancel()
+		require.NoError(t, <-served)
+		_, err = os.Lstat(path)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
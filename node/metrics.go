@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"math"
 	"strconv"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/NethermindEth/juno/jemalloc"
 	"github.com/NethermindEth/juno/jsonrpc"
 	"github.com/NethermindEth/juno/l1"
+	"github.com/NethermindEth/juno/service"
 	"github.com/NethermindEth/juno/sync"
 	"github.com/cockroachdb/pebble"
 	"github.com/prometheus/client_golang/prometheus"
@@ -110,6 +112,72 @@ func makeWSMetrics() jsonrpc.NewRequestListener {
 	}
 }
 
+func makeIPCMetrics() jsonrpc.NewRequestListener {
+	reqCounter := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: "ipc",
+		Name:      "requests",
+	})
+	prometheus.MustRegister(reqCounter)
+
+	return &jsonrpc.SelectiveListener{
+		OnNewRequestCb: func(method string) {
+			reqCounter.Inc()
+		},
+	}
+}
+
+func makeRateLimitMetrics(subsystem string) jsonrpc.RateLimitListener {
+	allowed := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: subsystem,
+		Name:      "rate_limit_allowed",
+	}, []string{"method"})
+	limited := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: subsystem,
+		Name:      "rate_limit_rejected",
+	}, []string{"method"})
+	prometheus.MustRegister(allowed, limited)
+
+	return &jsonrpc.SelectiveListener{
+		OnRequestAllowedCb: func(method string) {
+			allowed.WithLabelValues(method).Inc()
+		},
+		OnRequestLimitedCb: func(method string) {
+			limited.WithLabelValues(method).Inc()
+		},
+	}
+}
+
+// makeAuthMetrics labels authenticated requests with the identity of the caller if it is one of the given names and
+// with "other" otherwise, so that the subjects of tokens don't add a label value each
+func makeAuthMetrics(subsystem string, identities map[string]struct{}) jsonrpc.AuthListener {
+	authenticated := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: subsystem,
+		Name:      "authenticated_requests",
+	}, []string{"identity"})
+	failures := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: subsystem,
+		Name:      "authentication_failures",
+	})
+	prometheus.MustRegister(authenticated, failures)
+
+	return &jsonrpc.SelectiveListener{
+		OnAuthenticatedCb: func(identity string) {
+			if _, found := identities[identity]; !found {
+				identity = "other"
+			}
+			authenticated.WithLabelValues(identity).Inc()
+		},
+		OnAuthenticationFailedCb: func() {
+			failures.Inc()
+		},
+	}
+}
+
 func makeRPCMetrics(version, legacyVersion string) (jsonrpc.EventListener, jsonrpc.EventListener) {
 	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "rpc",
@@ -151,6 +219,36 @@ func makeRPCMetrics(version, legacyVersion string) (jsonrpc.EventListener, jsonr
 		}
 }
 
+func makeResponseCacheMetrics(version, legacyVersion string) (jsonrpc.CacheListener, jsonrpc.CacheListener) {
+	hits := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: "server",
+		Name:      "response_cache_hits",
+	}, []string{"method", "version"})
+	misses := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: "server",
+		Name:      "response_cache_misses",
+	}, []string{"method", "version"})
+	prometheus.MustRegister(hits, misses)
+
+	return &jsonrpc.SelectiveListener{
+		OnCacheHitCb: func(method string) {
+			hits.WithLabelValues(method, version).Inc()
+		},
+		OnCacheMissCb: func(method string) {
+			misses.WithLabelValues(method, version).Inc()
+		},
+	}, &jsonrpc.SelectiveListener{
+		OnCacheHitCb: func(method string) {
+			hits.WithLabelValues(method, legacyVersion).Inc()
+		},
+		OnCacheMissCb: func(method string) {
+			misses.WithLabelValues(method, legacyVersion).Inc()
+		},
+	}
+}
+
 func makeSyncMetrics(syncReader sync.Reader, bcReader blockchain.Reader) sync.EventListener {
 	opTimerHistogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "sync",
@@ -164,6 +262,20 @@ func makeSyncMetrics(syncReader sync.Reader, bcReader blockchain.Reader) sync.Ev
 		Namespace: "sync",
 		Name:      "reorganisations",
 	})
+	reorgDepthHistogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "sync",
+		Name:      "reorganisation_depth",
+		Buckets: []float64{
+			1,
+			2,
+			3,
+			5,
+			10,
+			20,
+			50,
+			100,
+		},
+	})
 	chainHeightGauge := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
 		Namespace: "sync",
 		Name:      "blockchain_height",
@@ -182,7 +294,7 @@ func makeSyncMetrics(syncReader sync.Reader, bcReader blockchain.Reader) sync.Ev
 		return 0
 	})
 
-	prometheus.MustRegister(opTimerHistogram, blockCount, chainHeightGauge, bestBlockGauge, reorgCount)
+	prometheus.MustRegister(opTimerHistogram, blockCount, chainHeightGauge, bestBlockGauge, reorgCount, reorgDepthHistogram)
 
 	return &sync.SelectiveListener{
 		OnSyncStepDoneCb: func(op string, blockNum uint64, took time.Duration) {
@@ -191,8 +303,9 @@ func makeSyncMetrics(syncReader sync.Reader, bcReader blockchain.Reader) sync.Ev
 				blockCount.Inc()
 			}
 		},
-		OnReorgCb: func(blockNum uint64) {
+		OnReorgCb: func(blockNum, depth uint64) {
 			reorgCount.Inc()
+			reorgDepthHistogram.Observe(float64(depth))
 		},
 	}
 }
@@ -264,10 +377,12 @@ func makeGatewayMetrics() gateway.EventListener {
 	}
 }
 
-func makePebbleMetrics(nodeDB db.DB) {
+// makePebbleMetrics returns the service that samples the disk usage of the buckets, nil if nodeDB isn't backed by
+// pebble
+func makePebbleMetrics(nodeDB db.DB) service.Service {
 	pebbleDB, ok := nodeDB.Impl().(*pebble.DB)
 	if !ok {
-		return
+		return nil
 	}
 
 	blockCacheSize := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
@@ -300,7 +415,43 @@ func makePebbleMetrics(nodeDB db.DB) {
 		metrics := pebbleDB.Metrics()
 		return float64(metrics.TableCache.Hits) / float64(metrics.TableCache.Hits+metrics.TableCache.Misses)
 	})
-	prometheus.MustRegister(blockCacheSize, blockHitRate, tableCacheSize, tableHitRate)
+	bucketUsage := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "pebble",
+		Subsystem: "bucket",
+		Name:      "disk_usage",
+	}, []string{"bucket"})
+	prometheus.MustRegister(blockCacheSize, blockHitRate, tableCacheSize, tableHitRate, bucketUsage)
+	return &bucketUsageSampler{pebbleDB: pebbleDB, usage: bucketUsage}
+}
+
+const bucketUsageSampleInterval = time.Minute
+
+// bucketUsageSampler estimates the disk usage of every bucket periodically. The estimates come from the metadata of
+// the tables, so unlike the exact sizes reported by juno db stats they are cheap to compute.
+type bucketUsageSampler struct {
+	pebbleDB *pebble.DB
+	usage    *prometheus.GaugeVec
+}
+
+func (s *bucketUsageSampler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(bucketUsageSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, bucket := range db.Buckets() {
+			usage, err := s.pebbleDB.EstimateDiskUsage(bucket.Key(), []byte{byte(bucket) + 1})
+			if err != nil {
+				continue
+			}
+			s.usage.WithLabelValues(bucket.String()).Set(float64(usage))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
 }
 
 func makeJeMallocMetrics() {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/NethermindEth/juno/admin"
 	"github.com/NethermindEth/juno/blockchain"
 	"github.com/NethermindEth/juno/clients/feeder"
 	"github.com/NethermindEth/juno/clients/gateway"
@@ -23,12 +24,15 @@ import (
 	"github.com/NethermindEth/juno/p2p"
 	"github.com/NethermindEth/juno/rpc"
 	"github.com/NethermindEth/juno/service"
+	"github.com/NethermindEth/juno/starknetdata"
+	"github.com/NethermindEth/juno/starknetdata/archive"
 	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
 	"github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/upgrader"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/NethermindEth/juno/validator"
 	"github.com/NethermindEth/juno/vm"
+	"github.com/NethermindEth/juno/webhook"
 	"github.com/mitchellh/mapstructure"
 	"github.com/sourcegraph/conc"
 	"google.golang.org/grpc"
@@ -52,6 +56,9 @@ type Config struct {
 	Websocket           bool           `mapstructure:"ws"`
 	WebsocketHost       string         `mapstructure:"ws-host"`
 	WebsocketPort       uint16         `mapstructure:"ws-port"`
+	IPCPath             string         `mapstructure:"ipc-path"`
+	GraphQLPath         string         `mapstructure:"graphql-path"`
+	FeederGatewayPath   string         `mapstructure:"feeder-gateway-path"`
 	GRPC                bool           `mapstructure:"grpc"`
 	GRPCHost            string         `mapstructure:"grpc-host"`
 	GRPCPort            uint16         `mapstructure:"grpc-port"`
@@ -63,12 +70,27 @@ type Config struct {
 	PprofPort           uint16         `mapstructure:"pprof-port"`
 	Colour              bool           `mapstructure:"colour"`
 	PendingPollInterval time.Duration  `mapstructure:"pending-poll-interval"`
+	MaxReorgDepth       uint           `mapstructure:"max-reorg-depth"`
 	RemoteDB            string         `mapstructure:"remote-db"`
+	Archive             string         `mapstructure:"archive"`
+	WebhooksFile        string         `mapstructure:"webhooks-file"`
+	IndexTransfers      bool           `mapstructure:"index-transfers"`
 
 	Metrics     bool   `mapstructure:"metrics"`
 	MetricsHost string `mapstructure:"metrics-host"`
 	MetricsPort uint16 `mapstructure:"metrics-port"`
 
+	Admin            bool   `mapstructure:"admin"`
+	AdminHost        string `mapstructure:"admin-host"`
+	AdminPort        uint16 `mapstructure:"admin-port"`
+	AdminAPIKeysFile string `mapstructure:"admin-api-keys-file"`
+	AdminJWTSecret   string `mapstructure:"admin-jwt-secret"`
+
+	TraceExporter      string  `mapstructure:"trace-exporter"`
+	TraceOTLPEndpoint  string  `mapstructure:"trace-otlp-endpoint"`
+	TraceFile          string  `mapstructure:"trace-file"`
+	TraceDBSampleRatio float64 `mapstructure:"trace-db-sample-ratio"`
+
 	P2P           bool   `mapstructure:"p2p"`
 	P2PAddr       string `mapstructure:"p2p-addr"`
 	P2PPeers      string `mapstructure:"p2p-peers"`
@@ -80,6 +102,24 @@ type Config struct {
 	RPCMaxBlockScan uint `mapstructure:"rpc-max-block-scan"`
 	RPCCallMaxSteps uint `mapstructure:"rpc-call-max-steps"`
 
+	RPCTraceStoreSize  uint `mapstructure:"rpc-trace-store-size"`
+	RPCTraceStoreEager bool `mapstructure:"rpc-trace-store-eager"`
+
+	RPCResponseCacheSize uint `mapstructure:"rpc-response-cache-size"`
+
+	ReadyMaxBlocksBehind uint          `mapstructure:"ready-max-blocks-behind"`
+	ReadyMaxHeadAge      time.Duration `mapstructure:"ready-max-head-age"`
+
+	GraphQLMaxDepth      uint `mapstructure:"graphql-max-depth"`
+	GraphQLMaxComplexity uint `mapstructure:"graphql-max-complexity"`
+
+	RPCRateLimit      float64 `mapstructure:"rpc-rate-limit"`
+	RPCRateLimitBurst uint    `mapstructure:"rpc-rate-limit-burst"`
+
+	RPCAPIKeys     []APIKey `mapstructure:"rpc-api-keys"`
+	RPCAPIKeysFile string   `mapstructure:"rpc-api-keys-file"`
+	RPCJWTSecret   string   `mapstructure:"rpc-jwt-secret"`
+
 	DBCacheSize  uint `mapstructure:"db-cache-size"`
 	DBMaxHandles int  `mapstructure:"db-max-handles"`
 
@@ -91,6 +131,7 @@ type Node struct {
 	cfg        *Config
 	db         db.DB
 	blockchain *blockchain.Blockchain
+	archive    *archive.Archive // nil unless the node syncs from an archive
 
 	metricsService service.Service // Start the metrics service earlier than other services.
 	services       []service.Service
@@ -112,6 +153,16 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 		return nil, fmt.Errorf("create DB logger: %w", err)
 	}
 
+	var tracing *tracingService
+	if cfg.TraceExporter != "" {
+		if cfg.TraceDBSampleRatio < 0 || cfg.TraceDBSampleRatio > 1 {
+			return nil, fmt.Errorf("trace DB sample ratio must be between 0 and 1, got %v", cfg.TraceDBSampleRatio)
+		}
+		if tracing, err = makeTracing(cfg.TraceExporter, cfg.TraceOTLPEndpoint, cfg.TraceFile, version); err != nil {
+			return nil, fmt.Errorf("set up tracing: %w", err)
+		}
+	}
+
 	dbIsRemote := cfg.RemoteDB != ""
 	var database db.DB
 	if dbIsRemote {
@@ -122,11 +173,20 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 	if err != nil {
 		return nil, fmt.Errorf("open DB: %w", err)
 	}
+	if tracing != nil && cfg.TraceDBSampleRatio > 0 {
+		database = db.NewTracedDB(database, cfg.TraceDBSampleRatio)
+	}
 	ua := fmt.Sprintf("Juno/%s Starknet Client", version)
 
 	services := make([]service.Service, 0)
+	if tracing != nil {
+		services = append(services, tracing)
+	}
 
 	chain := blockchain.New(database, &cfg.Network)
+	if cfg.IndexTransfers {
+		chain = chain.WithTransferIndex()
+	}
 
 	// Verify that cfg.Network is compatible with the database.
 	head, err := chain.Head()
@@ -142,7 +202,19 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 
 	client := feeder.NewClient(cfg.Network.FeederURL).WithUserAgent(ua).WithLogger(log).
 		WithTimeout(cfg.GatewayTimeout).WithAPIKey(cfg.GatewayAPIKey)
-	synchronizer := sync.New(chain, adaptfeeder.New(client), log, cfg.PendingPollInterval, dbIsRemote)
+	var starknetData starknetdata.StarknetData = adaptfeeder.New(client)
+	var blockArchive *archive.Archive
+	if cfg.Archive != "" {
+		if blockArchive, err = archive.Open(cfg.Archive); err != nil {
+			return nil, fmt.Errorf("open block archive: %w", err)
+		}
+		starknetData = blockArchive
+		log.Infow("Syncing from block archive", "path", cfg.Archive)
+	}
+	synchronizer := sync.New(chain, starknetData, log, cfg.PendingPollInterval, dbIsRemote)
+	if cfg.MaxReorgDepth > 0 {
+		synchronizer = synchronizer.WithMaxReorgDepth(uint64(cfg.MaxReorgDepth))
+	}
 	gatewayClient := gateway.NewClient(cfg.Network.GatewayURL, log).WithUserAgent(ua).WithAPIKey(cfg.GatewayAPIKey)
 
 	var p2pService *p2p.Service
@@ -166,6 +238,20 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 	if synchronizer != nil {
 		services = append(services, synchronizer)
 	}
+	if cfg.WebhooksFile != "" {
+		if synchronizer == nil {
+			return nil, errors.New("webhooks require the feeder synchronisation")
+		}
+		webhooks, webhooksErr := webhook.LoadConfig(cfg.WebhooksFile)
+		if webhooksErr != nil {
+			return nil, webhooksErr
+		}
+		webhookService, webhooksErr := webhook.New(webhooks, chain, database, synchronizer, log)
+		if webhooksErr != nil {
+			return nil, webhooksErr
+		}
+		services = append(services, webhookService)
+	}
 
 	throttledVM := NewThrottledVM(vm.New(log), cfg.MaxVMs, int32(cfg.MaxVMQueue))
 
@@ -176,6 +262,14 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 
 	rpcHandler := rpc.New(chain, syncReader, throttledVM, version, &cfg.Network, log).WithGateway(gatewayClient).WithFeeder(client)
 	rpcHandler = rpcHandler.WithFilterLimit(cfg.RPCMaxBlockScan).WithCallMaxSteps(uint64(cfg.RPCCallMaxSteps))
+	if cfg.RPCTraceStoreSize > 0 {
+		traceStore, tsErr := blockchain.NewTraceStore(database, uint64(cfg.RPCTraceStoreSize)*utils.Megabyte)
+		if tsErr != nil {
+			return nil, fmt.Errorf("create trace store: %w", tsErr)
+		}
+		chain.WithRevertHook(traceStore.RevertBlock)
+		rpcHandler = rpcHandler.WithTraceStore(traceStore, cfg.RPCTraceStoreEager)
+	}
 	services = append(services, rpcHandler)
 	// to improve RPC throughput we double GOMAXPROCS
 	maxGoroutines := 2 * runtime.GOMAXPROCS(0)
@@ -189,6 +283,14 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 	if err = jsonrpcServerLegacy.RegisterMethods(legacyMethods...); err != nil {
 		return nil, err
 	}
+	var responseCache, legacyResponseCache *jsonrpc.ResponseCache
+	if cfg.RPCResponseCacheSize > 0 {
+		responseCache = jsonrpc.NewResponseCache(int(cfg.RPCResponseCacheSize), rpcHandler.ResponseCachePolicy())
+		legacyResponseCache = jsonrpc.NewResponseCache(int(cfg.RPCResponseCacheSize), rpcHandler.ResponseCachePolicy())
+		chain.WithRevertHook(responseCache.InvalidateFrom).WithRevertHook(legacyResponseCache.InvalidateFrom)
+		jsonrpcServer.WithCache(responseCache)
+		jsonrpcServerLegacy.WithCache(legacyResponseCache)
+	}
 	rpcServers := map[string]*jsonrpc.Server{
 		"/":                 jsonrpcServer,
 		path:                jsonrpcServer,
@@ -197,23 +299,95 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 		"/rpc" + path:       jsonrpcServer,
 		"/rpc" + legacyPath: jsonrpcServerLegacy,
 	}
+	var l1Client *l1.Client
+	if cfg.EthNode == "" {
+		log.Warnw("Ethereum node address not found; will not verify against L1")
+	} else {
+		l1Client, err = newL1Client(cfg, chain, log)
+		if err != nil {
+			return nil, fmt.Errorf("create L1 client: %w", err)
+		}
+	}
+
+	healthChecker := makeHealth(database, chain, synchronizer, l1Client, cfg.ReadyMaxBlocksBehind, cfg.ReadyMaxHeadAge, log)
+	httpHandlers := map[string]extraHandler{
+		"/health": {handler: healthChecker.LivenessHandler()},
+		"/ready":  {handler: healthChecker.ReadinessHandler()},
+	}
+	if cfg.GraphQLPath != "" {
+		httpHandlers[cfg.GraphQLPath] = extraHandler{
+			handler: makeGraphQL(chain, cfg.GraphQLMaxDepth, cfg.GraphQLMaxComplexity, log),
+			method:  "graphql",
+		}
+	}
+	if cfg.FeederGatewayPath != "" {
+		gatewayPath, gatewayHandler, gatewayErr := makeFeederGateway(cfg.FeederGatewayPath, chain, log)
+		if gatewayErr != nil {
+			return nil, gatewayErr
+		}
+		httpHandlers[gatewayPath] = extraHandler{handler: gatewayHandler, method: "feedergateway"}
+	}
 	if cfg.HTTP {
-		services = append(services, makeRPCOverHTTP(cfg.HTTPHost, cfg.HTTPPort, rpcServers, log, cfg.Metrics, cfg.RPCCorsEnable))
+		limiter := makeRateLimiter("http", cfg.RPCRateLimit, cfg.RPCRateLimitBurst, cfg.Metrics)
+		auth, authErr := makeRPCAuth("http", cfg.RPCAPIKeys, cfg.RPCAPIKeysFile, cfg.RPCJWTSecret, cfg.Metrics)
+		if authErr != nil {
+			return nil, authErr
+		}
+		services = append(services, makeRPCOverHTTP(cfg.HTTPHost, cfg.HTTPPort, rpcServers, httpHandlers, log, cfg.Metrics,
+			cfg.RPCCorsEnable, limiter, auth))
 	}
 	if cfg.Websocket {
-		services = append(services, makeRPCOverWebsocket(cfg.WebsocketHost, cfg.WebsocketPort, rpcServers, log, cfg.Metrics, cfg.RPCCorsEnable))
+		limiter := makeRateLimiter("ws", cfg.RPCRateLimit, cfg.RPCRateLimitBurst, cfg.Metrics)
+		auth, authErr := makeRPCAuth("ws", cfg.RPCAPIKeys, cfg.RPCAPIKeysFile, cfg.RPCJWTSecret, cfg.Metrics)
+		if authErr != nil {
+			return nil, authErr
+		}
+		services = append(services, makeRPCOverWebsocket(cfg.WebsocketHost, cfg.WebsocketPort, rpcServers, log, cfg.Metrics,
+			cfg.RPCCorsEnable, limiter, auth))
+	}
+	if cfg.IPCPath != "" {
+		services = append(services, makeRPCOverIPC(cfg.IPCPath, jsonrpcServer, log, cfg.Metrics))
+	}
+	if cfg.Admin {
+		adminCfg, cfgErr := adminConfig(cfg)
+		if cfgErr != nil {
+			return nil, fmt.Errorf("decode config: %w", cfgErr)
+		}
+		adminHandler := admin.New(log, database, adminCfg, log).WithCache("traces", rpcHandler.PurgeTraceCache)
+		if synchronizer != nil {
+			adminHandler = adminHandler.WithSynchroniser(synchronizer)
+		}
+		if p2pService != nil {
+			adminHandler = adminHandler.WithP2P(p2pService)
+		}
+		if responseCache != nil {
+			adminHandler = adminHandler.WithCache("responses", responseCache.Purge).
+				WithCache("legacy-responses", legacyResponseCache.Purge)
+		}
+		adminService, adminErr := makeAdmin(cfg, adminHandler, log)
+		if adminErr != nil {
+			return nil, adminErr
+		}
+		services = append(services, adminService)
 	}
 	var metricsService service.Service
 	if cfg.Metrics {
 		makeJeMallocMetrics()
 		makeVMThrottlerMetrics(throttledVM)
-		makePebbleMetrics(database)
+		if bucketUsage := makePebbleMetrics(database); bucketUsage != nil {
+			services = append(services, bucketUsage)
+		}
 		chain.WithListener(makeBlockchainMetrics())
 		makeJunoMetrics(version)
 		database.WithListener(makeDBMetrics())
 		rpcMetrics, legacyRPCMetrics := makeRPCMetrics(path, legacyPath)
 		jsonrpcServer.WithListener(rpcMetrics)
 		jsonrpcServerLegacy.WithListener(legacyRPCMetrics)
+		if responseCache != nil {
+			cacheMetrics, legacyCacheMetrics := makeResponseCacheMetrics(path, legacyPath)
+			responseCache.WithListener(cacheMetrics)
+			legacyResponseCache.WithListener(legacyCacheMetrics)
+		}
 		client.WithListener(makeFeederMetrics())
 		gatewayClient.WithListener(makeGatewayMetrics())
 		metricsService = makeMetrics(cfg.MetricsHost, cfg.MetricsPort)
@@ -238,18 +412,12 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 		version:        version,
 		db:             database,
 		blockchain:     chain,
+		archive:        blockArchive,
 		services:       services,
 		metricsService: metricsService,
 	}
 
-	if n.cfg.EthNode == "" {
-		n.log.Warnw("Ethereum node address not found; will not verify against L1")
-	} else {
-		var l1Client *l1.Client
-		l1Client, err = newL1Client(cfg, n.blockchain, n.log)
-		if err != nil {
-			return nil, fmt.Errorf("create L1 client: %w", err)
-		}
+	if l1Client != nil {
 		n.services = append(n.services, l1Client)
 	}
 
@@ -302,6 +470,11 @@ func (n *Node) Run(ctx context.Context) {
 		if closeErr := n.db.Close(); closeErr != nil {
 			n.log.Errorw("Error while closing the DB", "err", closeErr)
 		}
+		if n.archive != nil {
+			if closeErr := n.archive.Close(); closeErr != nil {
+				n.log.Errorw("Error while closing the block archive", "err", closeErr)
+			}
+		}
 	}()
 
 	cfg := make(map[string]interface{})
@@ -2,6 +2,7 @@ package node_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -37,6 +38,12 @@ func TestNewNode(t *testing.T) {
 		P2P:                 true,
 		P2PAddr:             "",
 		P2PPeers:            "",
+		TraceExporter:       "file",
+		TraceFile:           filepath.Join(t.TempDir(), "traces.json"),
+		TraceDBSampleRatio:  1,
+		Admin:               true,
+		AdminPort:           0,
+		AdminJWTSecret:      "0x0123456789abcdef",
 	}
 
 	n, err := node.New(config, "v0.3")
@@ -88,3 +95,30 @@ func TestNetworkVerificationOnNonEmptyDB(t *testing.T) {
 		})
 	}
 }
+
+func TestTracingConfig(t *testing.T) {
+	tests := map[string]*node.Config{
+		"unknown exporter":      {TraceExporter: "jaeger"},
+		"missing trace file":    {TraceExporter: "file"},
+		"invalid sample ratio":  {TraceExporter: "stdout", TraceDBSampleRatio: 2},
+		"invalid OTLP endpoint": {TraceExporter: "otlp", TraceOTLPEndpoint: "localhost"},
+	}
+
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			config.DatabasePath = t.TempDir()
+			config.Network = utils.Sepolia
+			_, err := node.New(config, "v0.3")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAdminRequiresAuthentication(t *testing.T) {
+	_, err := node.New(&node.Config{
+		DatabasePath: t.TempDir(),
+		Network:      utils.Sepolia,
+		Admin:        true,
+	}, "v0.3")
+	require.ErrorContains(t, err, "the admin RPC requires an API keys file or a JWT secret")
+}
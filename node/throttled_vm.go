@@ -1,14 +1,20 @@
 package node
 
 import (
+	"context"
+
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/NethermindEth/juno/vm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 )
 
 var _ vm.VM = (*ThrottledVM)(nil)
 
+var vmTracer = otel.Tracer("github.com/NethermindEth/juno/node")
+
 type ThrottledVM struct {
 	*utils.Throttler[vm.VM]
 }
@@ -19,27 +25,45 @@ func NewThrottledVM(res vm.VM, concurrenyBudget uint, maxQueueLen int32) *Thrott
 	}
 }
 
-func (tvm *ThrottledVM) Call(callInfo *vm.CallInfo, blockInfo *vm.BlockInfo, state core.StateReader,
+func (tvm *ThrottledVM) Call(ctx context.Context, callInfo *vm.CallInfo, blockInfo *vm.BlockInfo, state core.StateReader,
 	network *utils.Network, maxSteps uint64, useBlobData bool,
 ) ([]*felt.Felt, error) {
 	var ret []*felt.Felt
-	return ret, tvm.Do(func(vm *vm.VM) error {
+	return ret, tvm.do(ctx, func(vm *vm.VM) error {
 		var err error
-		ret, err = (*vm).Call(callInfo, blockInfo, state, network, maxSteps, useBlobData)
+		ret, err = (*vm).Call(ctx, callInfo, blockInfo, state, network, maxSteps, useBlobData)
 		return err
 	})
 }
 
-func (tvm *ThrottledVM) Execute(txns []core.Transaction, declaredClasses []core.Class, paidFeesOnL1 []*felt.Felt,
-	blockInfo *vm.BlockInfo, state core.StateReader, network *utils.Network, skipChargeFee, skipValidate, errOnRevert, useBlobData bool,
+func (tvm *ThrottledVM) Execute(ctx context.Context, txns []core.Transaction, declaredClasses []core.Class, paidFeesOnL1 []*felt.Felt,
+	blockInfo *vm.BlockInfo, state core.StateReader, network *utils.Network, skipChargeFee, skipValidate, errOnRevert, useBlobData,
+	recordAccess bool,
 ) ([]*felt.Felt, []*felt.Felt, []vm.TransactionTrace, error) {
 	var ret []*felt.Felt
 	var traces []vm.TransactionTrace
 	var dataGasConsumed []*felt.Felt
-	return ret, dataGasConsumed, traces, tvm.Do(func(vm *vm.VM) error {
+	return ret, dataGasConsumed, traces, tvm.do(ctx, func(vm *vm.VM) error {
 		var err error
-		ret, dataGasConsumed, traces, err = (*vm).Execute(txns, declaredClasses, paidFeesOnL1, blockInfo, state, network,
-			skipChargeFee, skipValidate, errOnRevert, useBlobData)
+		ret, dataGasConsumed, traces, err = (*vm).Execute(ctx, txns, declaredClasses, paidFeesOnL1, blockInfo, state, network,
+			skipChargeFee, skipValidate, errOnRevert, useBlobData, recordAccess)
 		return err
 	})
 }
+
+// do runs doer once a VM is available, the time spent waiting in the queue is traced
+func (tvm *ThrottledVM) do(ctx context.Context, doer func(vm *vm.VM) error) error {
+	_, span := vmTracer.Start(ctx, "vm.Queue")
+	dequeued := false
+	err := tvm.Do(func(vm *vm.VM) error {
+		dequeued = true
+		span.End()
+		return doer(vm)
+	})
+	if !dequeued {
+		// the queue was full
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+	}
+	return err
+}
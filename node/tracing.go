@@ -0,0 +1,117 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/NethermindEth/juno/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+const (
+	traceExporterOTLP   = "otlp"
+	traceExporterStdout = "stdout"
+	traceExporterFile   = "file"
+
+	// tracingShutdownTimeout bounds the time spent flushing the buffered spans on shutdown
+	tracingShutdownTimeout = 5 * time.Second
+)
+
+// tracingService flushes the buffered spans when the node shuts down
+type tracingService struct {
+	provider *sdktrace.TracerProvider
+	closer   io.Closer
+}
+
+var _ service.Service = (*tracingService)(nil)
+
+func (t *tracingService) Run(ctx context.Context) error {
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	err := t.provider.Shutdown(shutdownCtx)
+	if t.closer != nil {
+		err = errors.Join(err, t.closer.Close())
+	}
+	return err
+}
+
+// makeTracing installs the global tracer provider, which exports the spans of all the instrumented packages, and the
+// propagator that continues the traces of incoming HTTP requests
+func makeTracing(exporterName, otlpEndpoint, filePath, version string) (*tracingService, error) {
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+	var err error
+	switch exporterName {
+	case traceExporterOTLP:
+		var options []otlptracehttp.Option
+		if options, err = otlpOptions(otlpEndpoint); err != nil {
+			return nil, err
+		}
+		// the exporter connects lazily, so this doesn't block on an unreachable collector
+		exporter, err = otlptracehttp.New(context.Background(), options...)
+	case traceExporterStdout:
+		exporter, err = stdouttrace.New()
+	case traceExporterFile:
+		if filePath == "" {
+			return nil, errors.New("the file trace exporter requires a trace file")
+		}
+		var file *os.File
+		if file, err = os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600); err != nil {
+			return nil, fmt.Errorf("open trace file: %w", err)
+		}
+		closer = file
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, options: %s, %s, %s", exporterName, traceExporterOTLP,
+			traceExporterStdout, traceExporterFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s trace exporter: %w", exporterName, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName("juno"),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return &tracingService{
+		provider: provider,
+		closer:   closer,
+	}, nil
+}
+
+func otlpOptions(endpoint string) ([]otlptracehttp.Option, error) {
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, expected a URL such as http://localhost:4318", endpoint)
+	}
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpointURL.Host)}
+	if endpointURL.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if endpointURL.Path != "" && endpointURL.Path != "/" {
+		options = append(options, otlptracehttp.WithURLPath(endpointURL.Path))
+	}
+	return options, nil
+}
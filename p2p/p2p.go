@@ -46,6 +46,7 @@ type Service struct {
 	topicsLock sync.RWMutex
 
 	synchroniser *syncService
+	banned       *bannedPeers
 
 	feederNode bool
 }
@@ -67,18 +68,26 @@ func New(addr, userAgent, peers, privKeyStr string, feederNode bool, bc *blockch
 		return nil, err
 	}
 
-	p2pHost, err := libp2p.New(libp2p.ListenAddrs(sourceMultiAddr), libp2p.Identity(prvKey), libp2p.UserAgent(userAgent))
+	banned := newBannedPeers()
+	p2pHost, err := libp2p.New(libp2p.ListenAddrs(sourceMultiAddr), libp2p.Identity(prvKey), libp2p.UserAgent(userAgent),
+		libp2p.ConnectionGater(banned))
 	if err != nil {
 		return nil, err
 	}
 	// Todo: try to understand what will happen if user passes a multiaddr with p2p public and a private key which doesn't match.
 	// For example, a user passes the following multiaddr: --p2p-addr=/ip4/0.0.0.0/tcp/7778/p2p/(SomePublicKey) and also passes a
 	// --p2p-private-key="SomePrivateKey". However, the private public key pair don't match, in this case what will happen?
-	return NewWithHost(p2pHost, peers, feederNode, bc, snNetwork, log)
+	return newWithHost(p2pHost, banned, peers, feederNode, bc, snNetwork, log)
 }
 
 func NewWithHost(p2phost host.Host, peers string, feederNode bool, bc *blockchain.Blockchain, snNetwork *utils.Network,
 	log utils.SimpleLogger,
+) (*Service, error) {
+	return newWithHost(p2phost, newBannedPeers(), peers, feederNode, bc, snNetwork, log)
+}
+
+func newWithHost(p2phost host.Host, banned *bannedPeers, peers string, feederNode bool, bc *blockchain.Blockchain,
+	snNetwork *utils.Network, log utils.SimpleLogger,
 ) (*Service, error) {
 	peersAddrInfoS := []peer.AddrInfo{}
 	if peers != "" {
@@ -103,6 +112,7 @@ func NewWithHost(p2phost host.Host, peers string, feederNode bool, bc *blockchai
 	synchroniser := newSyncService(bc, p2phost, snNetwork, log)
 	s := &Service{
 		synchroniser: synchroniser,
+		banned:       banned,
 		log:          log,
 		host:         p2phost,
 		network:      snNetwork,
@@ -160,3 +160,32 @@ func TestValidKey(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestPeers(t *testing.T) {
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	peerHosts := net.Hosts()
+
+	peerA, err := p2p.NewWithHost(peerHosts[0], "", false, nil, &utils.Integration, utils.NewNopZapLogger())
+	require.NoError(t, err)
+	peerB, err := p2p.NewWithHost(peerHosts[1], "", false, nil, &utils.Integration, utils.NewNopZapLogger())
+	require.NoError(t, err)
+
+	peerBAddrs, err := peerB.ListenAddrs()
+	require.NoError(t, err)
+	require.NotEmpty(t, peerBAddrs)
+	peerBID := peerHosts[1].ID().String()
+
+	require.Empty(t, peerA.Peers())
+	require.NoError(t, peerA.AddPeer(context.Background(), peerBAddrs[0].String()))
+	peers := peerA.Peers()
+	require.Len(t, peers, 1)
+	require.Equal(t, peerBID, peers[0].ID)
+
+	require.Error(t, peerA.BanPeer("not a peer ID"))
+	require.Error(t, peerA.BanPeer(peerHosts[0].ID().String()))
+	require.NoError(t, peerA.BanPeer(peerBID))
+	require.Empty(t, peerA.Peers())
+	require.Equal(t, []string{peerBID}, peerA.BannedPeers())
+	require.ErrorIs(t, peerA.AddPeer(context.Background(), peerBAddrs[0].String()), p2p.ErrPeerBanned)
+}
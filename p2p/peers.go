@@ -0,0 +1,153 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+)
+
+var ErrPeerBanned = errors.New("peer is banned")
+
+// Peer is a peer the node is connected to
+type Peer struct {
+	ID    string   `json:"id"`
+	Addrs []string `json:"addrs"`
+}
+
+// bannedPeers refuses the connections to and from the banned peers when installed as the connection gater of the host
+type bannedPeers struct {
+	mu    sync.RWMutex
+	peers map[peer.ID]struct{}
+}
+
+var _ connmgr.ConnectionGater = (*bannedPeers)(nil)
+
+func newBannedPeers() *bannedPeers {
+	return &bannedPeers{
+		peers: make(map[peer.ID]struct{}),
+	}
+}
+
+func (b *bannedPeers) add(id peer.ID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.peers[id] = struct{}{}
+}
+
+func (b *bannedPeers) banned(id peer.ID) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, found := b.peers[id]
+	return found
+}
+
+func (b *bannedPeers) list() []peer.ID {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	ids := make([]peer.ID, 0, len(b.peers))
+	for id := range b.peers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (b *bannedPeers) InterceptPeerDial(id peer.ID) bool {
+	return !b.banned(id)
+}
+
+func (b *bannedPeers) InterceptAddrDial(id peer.ID, _ multiaddr.Multiaddr) bool {
+	return !b.banned(id)
+}
+
+func (b *bannedPeers) InterceptAccept(network.ConnMultiaddrs) bool {
+	// the peer is only known once the connection is secured
+	return true
+}
+
+func (b *bannedPeers) InterceptSecured(_ network.Direction, id peer.ID, _ network.ConnMultiaddrs) bool {
+	return !b.banned(id)
+}
+
+func (b *bannedPeers) InterceptUpgraded(conn network.Conn) (bool, control.DisconnectReason) {
+	return !b.banned(conn.RemotePeer()), 0
+}
+
+// Peers returns the peers the node is connected to, sorted by ID
+func (s *Service) Peers() []Peer {
+	ids := s.host.Network().Peers()
+	peers := make([]Peer, 0, len(ids))
+	for _, id := range ids {
+		var addrs []string
+		for _, conn := range s.host.Network().ConnsToPeer(id) {
+			addrs = append(addrs, conn.RemoteMultiaddr().String())
+		}
+		peers = append(peers, Peer{
+			ID:    id.String(),
+			Addrs: addrs,
+		})
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].ID < peers[j].ID
+	})
+	return peers
+}
+
+// BannedPeers returns the IDs of the banned peers, sorted
+func (s *Service) BannedPeers() []string {
+	ids := s.banned.list()
+	banned := make([]string, 0, len(ids))
+	for _, id := range ids {
+		banned = append(banned, id.String())
+	}
+	sort.Strings(banned)
+	return banned
+}
+
+// AddPeer connects to the peer at addr, a multiaddr that ends with the ID of the peer, and keeps its address
+func (s *Service) AddPeer(ctx context.Context, addr string) error {
+	addrInfo, err := peer.AddrInfoFromString(addr)
+	if err != nil {
+		return fmt.Errorf("addr info from %q: %w", addr, err)
+	}
+	if s.banned.banned(addrInfo.ID) {
+		return ErrPeerBanned
+	}
+
+	s.host.Peerstore().AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.PermanentAddrTTL)
+	if err = s.host.Connect(ctx, *addrInfo); err != nil {
+		return fmt.Errorf("connect to %s: %w", addrInfo.ID, err)
+	}
+	s.log.Infow("Added peer", "peer", addrInfo.ID)
+	return nil
+}
+
+// BanPeer disconnects from the peer and forgets it. Banned peers are refused further connections if the host was
+// created by New, hosts passed to NewWithHost have to install their own gater.
+func (s *Service) BanPeer(id string) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return fmt.Errorf("decode peer ID %q: %w", id, err)
+	}
+	if peerID == s.host.ID() {
+		return errors.New("cannot ban the node itself")
+	}
+
+	s.banned.add(peerID)
+	s.dht.RoutingTable().RemovePeer(peerID)
+	s.host.Peerstore().RemovePeer(peerID)
+	s.host.Peerstore().ClearAddrs(peerID)
+	if err = s.host.Network().ClosePeer(peerID); err != nil {
+		return fmt.Errorf("disconnect from %s: %w", peerID, err)
+	}
+	s.log.Infow("Banned peer", "peer", peerID)
+	return nil
+}
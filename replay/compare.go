@@ -0,0 +1,254 @@
+package replay
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/NethermindEth/juno/adapters/vm2core"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/vm"
+)
+
+// compareReceipt compares the stored receipt of a transaction with the result of its execution.
+// Traces only record the order of events and messages within a call, so they are compared irrespective of their order.
+func compareReceipt(receipt *core.TransactionReceipt, fee *felt.Felt, trace *vm.TransactionTrace) []Mismatch {
+	var mismatches []Mismatch
+	addMismatch := func(field string, expected, actual any) {
+		mismatches = append(mismatches, Mismatch{
+			TransactionHash: receipt.TransactionHash,
+			Field:           field,
+			Expected:        expected,
+			Actual:          actual,
+		})
+	}
+
+	if receipt.Fee == nil || fee == nil || !receipt.Fee.Equal(fee) {
+		addMismatch("fee", receipt.Fee, fee)
+	}
+
+	reverted := trace.RevertReason() != ""
+	if receipt.Reverted != reverted {
+		addMismatch("reverted", receipt.Reverted, reverted)
+	}
+
+	var (
+		events   []*core.Event
+		messages []*core.L2ToL1Message
+	)
+	for _, invocation := range invocations(trace) {
+		events = append(events, invocationEvents(invocation)...)
+		messages = append(messages, invocationMessages(invocation)...)
+	}
+
+	expectedEvents := sortedEvents(receipt.Events)
+	if actualEvents := sortedEvents(events); !slices.EqualFunc(expectedEvents, actualEvents, func(a, b *core.Event) bool {
+		return eventKey(a) == eventKey(b)
+	}) {
+		addMismatch("events", expectedEvents, actualEvents)
+	}
+
+	expectedMessages := sortedMessages(receipt.L2ToL1Message)
+	if actualMessages := sortedMessages(messages); !slices.EqualFunc(expectedMessages, actualMessages,
+		func(a, b *core.L2ToL1Message) bool {
+			return messageKey(a) == messageKey(b)
+		}) {
+		addMismatch("messages_sent", expectedMessages, actualMessages)
+	}
+	return mismatches
+}
+
+func invocations(trace *vm.TransactionTrace) []*vm.FunctionInvocation {
+	var executeInvocation *vm.FunctionInvocation
+	if trace.ExecuteInvocation != nil {
+		executeInvocation = trace.ExecuteInvocation.FunctionInvocation
+	}
+	return slices.DeleteFunc([]*vm.FunctionInvocation{
+		trace.ValidateInvocation,
+		trace.ConstructorInvocation,
+		executeInvocation,
+		trace.FunctionInvocation,
+		trace.FeeTransferInvocation,
+	}, func(i *vm.FunctionInvocation) bool { return i == nil })
+}
+
+// invocationEvents returns the events emitted by the invocation and its inner calls.
+// Events are emitted by the contract of the call they belong to.
+func invocationEvents(invocation *vm.FunctionInvocation) []*core.Event {
+	var events []*core.Event
+	for i := range invocation.Calls {
+		events = append(events, invocationEvents(&invocation.Calls[i])...)
+	}
+	for _, event := range invocation.Events {
+		if event.From == nil {
+			event.From = &invocation.ContractAddress
+		}
+		events = append(events, vm2core.AdaptOrderedEvent(event))
+	}
+	return events
+}
+
+func invocationMessages(invocation *vm.FunctionInvocation) []*core.L2ToL1Message {
+	var messages []*core.L2ToL1Message
+	for i := range invocation.Calls {
+		messages = append(messages, invocationMessages(&invocation.Calls[i])...)
+	}
+	for _, message := range invocation.Messages {
+		if message.From == nil {
+			message.From = &invocation.ContractAddress
+		}
+		messages = append(messages, vm2core.AdaptOrderedMessageToL1(message))
+	}
+	return messages
+}
+
+func sortedEvents(events []*core.Event) []*core.Event {
+	events = slices.Clone(events)
+	slices.SortStableFunc(events, func(a, b *core.Event) int {
+		return cmp.Compare(eventKey(a), eventKey(b))
+	})
+	return events
+}
+
+func eventKey(event *core.Event) string {
+	return fmt.Sprintf("%s|%s|%s", event.From, joinFelts(event.Keys), joinFelts(event.Data))
+}
+
+func sortedMessages(messages []*core.L2ToL1Message) []*core.L2ToL1Message {
+	messages = slices.Clone(messages)
+	slices.SortStableFunc(messages, func(a, b *core.L2ToL1Message) int {
+		return cmp.Compare(messageKey(a), messageKey(b))
+	})
+	return messages
+}
+
+func messageKey(message *core.L2ToL1Message) string {
+	return fmt.Sprintf("%s|%s|%s", message.From, message.To.Hex(), joinFelts(message.Payload))
+}
+
+func joinFelts(felts []*felt.Felt) string {
+	strs := make([]string, 0, len(felts))
+	for _, f := range felts {
+		strs = append(strs, f.String())
+	}
+	return strings.Join(strs, ",")
+}
+
+// compareStateDiff compares the stored state diff of a block with the combined state diffs of its transactions.
+// The write of the revealed block hash is made by the block rather than by any of its transactions, so it is applied
+// to the actual state diff first if blockHashToBeRevealed is set.
+func compareStateDiff(expected *core.StateDiff, blockNumber uint64, blockHashToBeRevealed *felt.Felt,
+	traces []vm.TransactionTrace,
+) []Mismatch {
+	actual := core.EmptyStateDiff()
+	if blockHashToBeRevealed != nil {
+		revealedNumber := new(felt.Felt).SetUint64(blockNumber - blockHashLag)
+		actual.StorageDiffs[*blockHashContract] = map[felt.Felt]*felt.Felt{
+			*revealedNumber: blockHashToBeRevealed,
+		}
+	}
+	for i := range traces {
+		diff := traces[i].StateDiff
+		if diff == nil {
+			continue
+		}
+
+		for _, storageDiff := range diff.StorageDiffs {
+			storage, ok := actual.StorageDiffs[storageDiff.Address]
+			if !ok {
+				storage = make(map[felt.Felt]*felt.Felt)
+				actual.StorageDiffs[storageDiff.Address] = storage
+			}
+			for _, entry := range storageDiff.StorageEntries {
+				storage[entry.Key] = &entry.Value
+			}
+		}
+		for _, nonce := range diff.Nonces {
+			actual.Nonces[nonce.ContractAddress] = &nonce.Nonce
+		}
+		for _, deployed := range diff.DeployedContracts {
+			actual.DeployedContracts[deployed.Address] = &deployed.ClassHash
+		}
+		actual.DeclaredV0Classes = append(actual.DeclaredV0Classes, diff.DeprecatedDeclaredClasses...)
+		for _, declared := range diff.DeclaredClasses {
+			actual.DeclaredV1Classes[declared.ClassHash] = &declared.CompiledClassHash
+		}
+		for _, replaced := range diff.ReplacedClasses {
+			actual.ReplacedClasses[replaced.ContractAddress] = &replaced.ClassHash
+		}
+	}
+
+	if expected == nil {
+		expected = core.EmptyStateDiff()
+	}
+
+	var mismatches []Mismatch
+	mismatches = append(mismatches, compareEntries("storage_diffs", flattenStorage(expected), flattenStorage(actual))...)
+	mismatches = append(mismatches, compareEntries("nonces", flatten(expected.Nonces), flatten(actual.Nonces))...)
+	mismatches = append(mismatches, compareEntries("deployed_contracts", flatten(expected.DeployedContracts),
+		flatten(actual.DeployedContracts))...)
+	mismatches = append(mismatches, compareEntries("deprecated_declared_classes", flattenSet(expected.DeclaredV0Classes),
+		flattenSet(actual.DeclaredV0Classes))...)
+	mismatches = append(mismatches, compareEntries("declared_classes", flatten(expected.DeclaredV1Classes),
+		flatten(actual.DeclaredV1Classes))...)
+	mismatches = append(mismatches, compareEntries("replaced_classes", flatten(expected.ReplacedClasses),
+		flatten(actual.ReplacedClasses))...)
+	return mismatches
+}
+
+// compareEntries reports the keys that are missing from either side or have a different value, sorted by key
+func compareEntries(field string, expected, actual map[string]*felt.Felt) []Mismatch {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	for key := range actual {
+		if _, ok := expected[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var mismatches []Mismatch
+	for _, key := range keys {
+		expectedValue, actualValue := expected[key], actual[key]
+		if expectedValue != nil && actualValue != nil && expectedValue.Equal(actualValue) {
+			continue
+		}
+		mismatches = append(mismatches, Mismatch{
+			Field:    field,
+			Key:      key,
+			Expected: expectedValue,
+			Actual:   actualValue,
+		})
+	}
+	return mismatches
+}
+
+func flatten(m map[felt.Felt]*felt.Felt) map[string]*felt.Felt {
+	flat := make(map[string]*felt.Felt, len(m))
+	for key, value := range m {
+		flat[key.String()] = value
+	}
+	return flat
+}
+
+func flattenStorage(diff *core.StateDiff) map[string]*felt.Felt {
+	flat := make(map[string]*felt.Felt)
+	for address, storage := range diff.StorageDiffs {
+		for key, value := range storage {
+			flat[address.String()+"/"+key.String()] = value
+		}
+	}
+	return flat
+}
+
+func flattenSet(felts []*felt.Felt) map[string]*felt.Felt {
+	flat := make(map[string]*felt.Felt, len(felts))
+	for _, f := range felts {
+		flat[f.String()] = f
+	}
+	return flat
+}
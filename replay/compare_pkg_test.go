@@ -0,0 +1,129 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareReceipt(t *testing.T) {
+	one := new(felt.Felt).SetUint64(1)
+	two := new(felt.Felt).SetUint64(2)
+	three := new(felt.Felt).SetUint64(3)
+	txHash := new(felt.Felt).SetUint64(42)
+
+	receipt := &core.TransactionReceipt{
+		TransactionHash: txHash,
+		Fee:             one,
+		Events: []*core.Event{
+			{From: two, Keys: []*felt.Felt{one}, Data: []*felt.Felt{}},
+			{From: three, Keys: []*felt.Felt{two}, Data: []*felt.Felt{three}},
+		},
+		L2ToL1Message: []*core.L2ToL1Message{
+			{From: two, To: common.HexToAddress("0x1"), Payload: []*felt.Felt{three}},
+		},
+	}
+
+	trace := &vm.TransactionTrace{
+		ExecuteInvocation: &vm.ExecuteInvocation{
+			FunctionInvocation: &vm.FunctionInvocation{
+				ContractAddress: *two,
+				Calls: []vm.FunctionInvocation{{
+					ContractAddress: *three,
+					Events:          []vm.OrderedEvent{{Order: 0, Keys: []*felt.Felt{two}, Data: []*felt.Felt{three}}},
+				}},
+				Events:   []vm.OrderedEvent{{Order: 0, Keys: []*felt.Felt{one}, Data: []*felt.Felt{}}},
+				Messages: []vm.OrderedL2toL1Message{{Order: 0, To: "0x1", Payload: []*felt.Felt{three}}},
+			},
+		},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		assert.Empty(t, compareReceipt(receipt, one, trace))
+	})
+
+	t.Run("fee", func(t *testing.T) {
+		assert.Equal(t, []Mismatch{{
+			TransactionHash: txHash,
+			Field:           "fee",
+			Expected:        one,
+			Actual:          two,
+		}}, compareReceipt(receipt, two, trace))
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		revertedTrace := &vm.TransactionTrace{
+			ExecuteInvocation: &vm.ExecuteInvocation{RevertReason: "oops"},
+		}
+		mismatches := compareReceipt(receipt, one, revertedTrace)
+		fields := make([]string, 0, len(mismatches))
+		for _, mismatch := range mismatches {
+			fields = append(fields, mismatch.Field)
+		}
+		assert.Equal(t, []string{"reverted", "events", "messages_sent"}, fields)
+	})
+}
+
+func TestCompareStateDiff(t *testing.T) {
+	one := new(felt.Felt).SetUint64(1)
+	two := new(felt.Felt).SetUint64(2)
+	three := new(felt.Felt).SetUint64(3)
+
+	expected := &core.StateDiff{
+		StorageDiffs: map[felt.Felt]map[felt.Felt]*felt.Felt{
+			*one: {*two: three},
+		},
+		Nonces:            map[felt.Felt]*felt.Felt{*one: two},
+		DeployedContracts: map[felt.Felt]*felt.Felt{},
+		DeclaredV0Classes: []*felt.Felt{three},
+		DeclaredV1Classes: map[felt.Felt]*felt.Felt{},
+		ReplacedClasses:   map[felt.Felt]*felt.Felt{},
+	}
+
+	traces := []vm.TransactionTrace{
+		{StateDiff: &vm.StateDiff{
+			StorageDiffs: []vm.StorageDiff{{Address: *one, StorageEntries: []vm.Entry{{Key: *two, Value: *one}}}},
+			Nonces:       []vm.Nonce{{ContractAddress: *one, Nonce: *one}},
+		}},
+		{StateDiff: &vm.StateDiff{
+			// later transactions override earlier writes
+			StorageDiffs:              []vm.StorageDiff{{Address: *one, StorageEntries: []vm.Entry{{Key: *two, Value: *three}}}},
+			Nonces:                    []vm.Nonce{{ContractAddress: *one, Nonce: *two}},
+			DeprecatedDeclaredClasses: []*felt.Felt{three},
+		}},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		assert.Empty(t, compareStateDiff(expected, 0, nil, traces))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		traces := append(traces, vm.TransactionTrace{StateDiff: &vm.StateDiff{
+			StorageDiffs:      []vm.StorageDiff{{Address: *two, StorageEntries: []vm.Entry{{Key: *one, Value: *one}}}},
+			DeployedContracts: []vm.DeployedContract{{Address: *three, ClassHash: *one}},
+		}})
+
+		var missing *felt.Felt
+		assert.Equal(t, []Mismatch{
+			{Field: "storage_diffs", Key: two.String() + "/" + one.String(), Expected: missing, Actual: one},
+			{Field: "deployed_contracts", Key: three.String(), Expected: missing, Actual: one},
+		}, compareStateDiff(expected, 0, nil, traces))
+	})
+
+	t.Run("revealed block hash", func(t *testing.T) {
+		blockHash := new(felt.Felt).SetUint64(42)
+		expected := core.EmptyStateDiff()
+		expected.StorageDiffs[*one] = map[felt.Felt]*felt.Felt{*three: blockHash}
+
+		assert.Empty(t, compareStateDiff(expected, 13, blockHash, nil))
+
+		var missing *felt.Felt
+		assert.Equal(t, []Mismatch{
+			{Field: "storage_diffs", Key: one.String() + "/" + three.String(), Expected: blockHash, Actual: missing},
+		}, compareStateDiff(expected, 13, nil, nil))
+	})
+}
@@ -0,0 +1,252 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/sourcegraph/conc/pool"
+)
+
+// Blocks up to this version were not executed by the blockifier and can't be reproduced by our VM
+var minReplayableVersion = semver.MustParse("0.12.3")
+
+// Before executing the transactions of a block, the sequencer writes the hash of the block blockHashLag blocks
+// before it to the storage of blockHashContract, the key is the number of that block
+const blockHashLag = 10
+
+var blockHashContract = new(felt.Felt).SetUint64(1)
+
+// Mismatch is a value produced by re-executing a block that differs from what is stored in the database.
+// TransactionHash is nil for mismatches in the state diff of the block.
+type Mismatch struct {
+	TransactionHash *felt.Felt `json:"transaction_hash,omitempty"`
+	Field           string     `json:"field"`
+	Key             string     `json:"key,omitempty"`
+	Expected        any        `json:"expected"`
+	Actual          any        `json:"actual"`
+}
+
+type BlockResult struct {
+	Number     uint64     `json:"block_number"`
+	Hash       *felt.Felt `json:"block_hash,omitempty"`
+	Skipped    bool       `json:"skipped,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Mismatches []Mismatch `json:"mismatches,omitempty"`
+}
+
+func (r *BlockResult) ok() bool {
+	return r.Error == "" && len(r.Mismatches) == 0
+}
+
+// Report summarises a replay run. Blocks only contains the blocks that failed to execute or didn't match.
+type Report struct {
+	From       uint64        `json:"from"`
+	To         uint64        `json:"to"`
+	Matched    uint64        `json:"matched"`
+	Mismatched uint64        `json:"mismatched"`
+	Failed     uint64        `json:"failed"`
+	Skipped    uint64        `json:"skipped"`
+	Blocks     []BlockResult `json:"blocks"`
+}
+
+// OK returns true if every replayed block matched the stored data
+func (r *Report) OK() bool {
+	return r.Mismatched == 0 && r.Failed == 0
+}
+
+type Replayer struct {
+	bcReader blockchain.Reader
+	vm       vm.VM
+	log      utils.SimpleLogger
+}
+
+func New(bcReader blockchain.Reader, virtualMachine vm.VM, log utils.SimpleLogger) *Replayer {
+	return &Replayer{
+		bcReader: bcReader,
+		vm:       virtualMachine,
+		log:      log,
+	}
+}
+
+// Run replays the blocks in [from, to] using up to workers blocks in parallel
+func (r *Replayer) Run(ctx context.Context, from, to uint64, workers int) (*Report, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: %d > %d", from, to)
+	}
+	if workers < 1 {
+		return nil, errors.New("at least one worker is required")
+	}
+
+	height, err := r.bcReader.Height()
+	if err != nil {
+		return nil, err
+	}
+	if to > height {
+		return nil, fmt.Errorf("block %d is above the chain height %d", to, height)
+	}
+
+	results := make([]*BlockResult, to-from+1)
+	workerPool := pool.New().WithMaxGoroutines(workers)
+	for number := from; number <= to && ctx.Err() == nil; number++ {
+		workerPool.Go(func() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			result, replayErr := r.ReplayBlock(ctx, number)
+			if replayErr != nil {
+				result = &BlockResult{Number: number, Error: replayErr.Error()}
+			}
+			if !result.ok() {
+				r.log.Warnw("Replayed block does not match", "number", number, "error", result.Error,
+					"mismatches", len(result.Mismatches))
+			} else {
+				r.log.Debugw("Replayed block", "number", number, "skipped", result.Skipped)
+			}
+			results[number-from] = result
+		})
+	}
+	workerPool.Wait()
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		From:   from,
+		To:     to,
+		Blocks: []BlockResult{},
+	}
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			report.Failed++
+		case len(result.Mismatches) > 0:
+			report.Mismatched++
+		case result.Skipped:
+			report.Skipped++
+			continue
+		default:
+			report.Matched++
+			continue
+		}
+		report.Blocks = append(report.Blocks, *result)
+	}
+	return report, nil
+}
+
+// ReplayBlock executes the transactions of the given block on top of the state of its parent and compares the
+// fees, receipts and state diff with the stored ones.
+// Blocks that can't be executed by the VM are reported as skipped.
+func (r *Replayer) ReplayBlock(ctx context.Context, number uint64) (*BlockResult, error) {
+	block, err := r.bcReader.BlockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BlockResult{
+		Number: block.Number,
+		Hash:   block.Hash,
+	}
+
+	blockVer, err := core.ParseBlockVersion(block.ProtocolVersion)
+	if err != nil {
+		return nil, err
+	}
+	if blockVer.Compare(minReplayableVersion) != 1 {
+		result.Skipped = true
+		return result, nil
+	}
+
+	stateUpdate, err := r.bcReader.StateUpdateByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHashToBeRevealed, err := r.revealedBlockHash(block.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	fees, traces, err := r.execute(ctx, block, blockHashToBeRevealed)
+	if err != nil {
+		// the block is part of the chain, so any error during execution is a regression
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	if len(fees) != len(block.Transactions) || len(traces) != len(block.Transactions) {
+		result.Error = fmt.Sprintf("expected %d results, got %d fees and %d traces",
+			len(block.Transactions), len(fees), len(traces))
+		return result, nil
+	}
+
+	for i, receipt := range block.Receipts {
+		result.Mismatches = append(result.Mismatches, compareReceipt(receipt, fees[i], &traces[i])...)
+	}
+	result.Mismatches = append(result.Mismatches, compareStateDiff(stateUpdate.StateDiff, block.Number,
+		blockHashToBeRevealed, traces)...)
+	return result, nil
+}
+
+func (r *Replayer) execute(ctx context.Context, block *core.Block, blockHashToBeRevealed *felt.Felt,
+) (_ []*felt.Felt, _ []vm.TransactionTrace, err error) {
+	state, closer, err := r.bcReader.StateAtBlockHash(block.ParentHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		err = errors.Join(err, closer())
+	}()
+
+	// classes declared in the block are only available in its own state
+	blockState, blockStateCloser, err := r.bcReader.StateAtBlockNumber(block.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		err = errors.Join(err, blockStateCloser())
+	}()
+
+	var classes []core.Class
+	paidFeesOnL1 := []*felt.Felt{}
+	for _, transaction := range block.Transactions {
+		switch tx := transaction.(type) {
+		case *core.DeclareTransaction:
+			class, stateErr := blockState.Class(tx.ClassHash)
+			if stateErr != nil {
+				return nil, nil, stateErr
+			}
+			classes = append(classes, class.Class)
+		case *core.L1HandlerTransaction:
+			var fee felt.Felt
+			paidFeesOnL1 = append(paidFeesOnL1, fee.SetUint64(1))
+		}
+	}
+
+	blockInfo := vm.BlockInfo{
+		Header:                block.Header,
+		BlockHashToBeRevealed: blockHashToBeRevealed,
+	}
+	fees, _, traces, err := r.vm.Execute(ctx, block.Transactions, classes, paidFeesOnL1, &blockInfo, state,
+		r.bcReader.Network(), false, false, false, true, false)
+	return fees, traces, err
+}
+
+func (r *Replayer) revealedBlockHash(blockNumber uint64) (*felt.Felt, error) {
+	if blockNumber < blockHashLag {
+		return nil, nil
+	}
+
+	header, err := r.bcReader.BlockHeaderByNumber(blockNumber - blockHashLag)
+	if err != nil {
+		return nil, err
+	}
+	return header.Hash, nil
+}
@@ -0,0 +1,167 @@
+package replay_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/replay"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReplay(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	client := feeder.NewTestClient(t, &utils.Mainnet)
+	gw := adaptfeeder.New(client)
+
+	chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+	for i := uint64(0); i < 3; i++ {
+		block, err := gw.BlockByNumber(context.Background(), i)
+		require.NoError(t, err)
+		stateUpdate, err := gw.StateUpdate(context.Background(), i)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(block, &core.BlockCommitments{}, stateUpdate, nil))
+	}
+
+	// the first mainnet blocks predate the blockifier, the VM must not be called
+	replayer := replay.New(chain, mocks.NewMockVM(mockCtrl), utils.NewNopZapLogger())
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := replayer.Run(context.Background(), 2, 1, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("no workers", func(t *testing.T) {
+		_, err := replayer.Run(context.Background(), 0, 1, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("above chain height", func(t *testing.T) {
+		_, err := replayer.Run(context.Background(), 0, 3, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported blocks are skipped", func(t *testing.T) {
+		report, err := replayer.Run(context.Background(), 0, 2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, &replay.Report{
+			From:    0,
+			To:      2,
+			Skipped: 3,
+			Blocks:  []replay.BlockResult{},
+		}, report)
+		assert.True(t, report.OK())
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := replayer.Run(ctx, 0, 2, 1)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestReplayExecutedBlock(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	f := func(v uint64) *felt.Felt {
+		return new(felt.Felt).SetUint64(v)
+	}
+	account, key, blockHash := f(0xabc), f(7), f(0xb10c)
+
+	// block 10 reveals the hash of block 0 by writing it to the storage of contract 0x1
+	revealedHeader := &core.Header{Number: 0, Hash: blockHash}
+	block := &core.Block{
+		Header: &core.Header{Number: 10, Hash: f(10), ParentHash: f(9), ProtocolVersion: "0.13.1"},
+		Transactions: []core.Transaction{
+			&core.InvokeTransaction{TransactionHash: f(1), SenderAddress: account},
+		},
+		Receipts: []*core.TransactionReceipt{{
+			TransactionHash: f(1),
+			Fee:             f(5),
+			Events:          []*core.Event{{From: account, Keys: []*felt.Felt{key}, Data: []*felt.Felt{}}},
+		}},
+	}
+	stateUpdate := &core.StateUpdate{StateDiff: core.EmptyStateDiff()}
+	stateUpdate.StateDiff.StorageDiffs[*f(1)] = map[felt.Felt]*felt.Felt{*f(0): blockHash}
+	stateUpdate.StateDiff.StorageDiffs[*account] = map[felt.Felt]*felt.Felt{*key: f(100)}
+	stateUpdate.StateDiff.Nonces[*account] = f(1)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockReader.EXPECT().Height().Return(block.Number, nil).AnyTimes()
+	mockReader.EXPECT().Network().Return(&utils.Mainnet).AnyTimes()
+	mockReader.EXPECT().BlockByNumber(block.Number).Return(block, nil).AnyTimes()
+	mockReader.EXPECT().StateUpdateByNumber(block.Number).Return(stateUpdate, nil).AnyTimes()
+	mockReader.EXPECT().BlockHeaderByNumber(revealedHeader.Number).Return(revealedHeader, nil).AnyTimes()
+	closer := func() error { return nil }
+	mockReader.EXPECT().StateAtBlockHash(block.ParentHash).Return(nil, closer, nil).AnyTimes()
+	mockReader.EXPECT().StateAtBlockNumber(block.Number).Return(nil, closer, nil).AnyTimes()
+
+	trace := func(value *felt.Felt) vm.TransactionTrace {
+		return vm.TransactionTrace{
+			Type: vm.TxnInvoke,
+			ExecuteInvocation: &vm.ExecuteInvocation{FunctionInvocation: &vm.FunctionInvocation{
+				ContractAddress: *account,
+				Events:          []vm.OrderedEvent{{Keys: []*felt.Felt{key}, Data: []*felt.Felt{}}},
+			}},
+			StateDiff: &vm.StateDiff{
+				StorageDiffs: []vm.StorageDiff{{Address: *account, StorageEntries: []vm.Entry{{Key: *key, Value: *value}}}},
+				Nonces:       []vm.Nonce{{ContractAddress: *account, Nonce: *f(1)}},
+			},
+		}
+	}
+	mockVM := mocks.NewMockVM(mockCtrl)
+	expectExecute := func(fee, value *felt.Felt) {
+		mockVM.EXPECT().Execute(gomock.Any(), block.Transactions, nil, []*felt.Felt{},
+			&vm.BlockInfo{Header: block.Header, BlockHashToBeRevealed: blockHash}, nil, &utils.Mainnet,
+			false, false, false, true, false).Return([]*felt.Felt{fee}, []*felt.Felt{&felt.Zero},
+			[]vm.TransactionTrace{trace(value)}, nil)
+	}
+	replayer := replay.New(mockReader, mockVM, utils.NewNopZapLogger())
+
+	t.Run("match", func(t *testing.T) {
+		expectExecute(f(5), f(100))
+		report, err := replayer.Run(context.Background(), block.Number, block.Number, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &replay.Report{
+			From:    block.Number,
+			To:      block.Number,
+			Matched: 1,
+			Blocks:  []replay.BlockResult{},
+		}, report)
+		assert.True(t, report.OK())
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		expectExecute(f(6), f(101))
+		report, err := replayer.Run(context.Background(), block.Number, block.Number, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &replay.Report{
+			From:       block.Number,
+			To:         block.Number,
+			Mismatched: 1,
+			Blocks: []replay.BlockResult{{
+				Number: block.Number,
+				Hash:   block.Hash,
+				Mismatches: []replay.Mismatch{
+					{TransactionHash: f(1), Field: "fee", Expected: f(5), Actual: f(6)},
+					{Field: "storage_diffs", Key: account.String() + "/" + key.String(), Expected: f(100), Actual: f(101)},
+				},
+			}},
+		}, report)
+		assert.False(t, report.OK())
+	})
+}
@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+// AccountTransactionsArg filters the transactions of an account by nonce and block number, both bounds are inclusive
+type AccountTransactionsArg struct {
+	Address   felt.Felt  `json:"address"`
+	FromNonce *felt.Felt `json:"from_nonce"`
+	ToNonce   *felt.Felt `json:"to_nonce"`
+	FromBlock *uint64    `json:"from_block"`
+	ToBlock   *uint64    `json:"to_block"`
+	ResultPageRequest
+}
+
+type AccountTransaction struct {
+	TransactionHash  *felt.Felt         `json:"transaction_hash"`
+	Nonce            *felt.Felt         `json:"nonce"`
+	BlockNumber      uint64             `json:"block_number"`
+	TransactionIndex uint64             `json:"transaction_index"`
+	ExecutionStatus  TxnExecutionStatus `json:"execution_status"`
+}
+
+type AccountTransactionsChunk struct {
+	Transactions      []*AccountTransaction `json:"transactions"`
+	ContinuationToken string                `json:"continuation_token,omitempty"`
+}
+
+// AccountTransactions returns the invoke, declare and deploy account transactions of an account ordered by nonce.
+// The block filters are checked against every transaction of the account in nonce order, the number of transactions
+// they skip in a call is bounded by the filter limit. Once it is reached a chunk, possibly empty, is returned with a
+// continuation token to resume from.
+func (h *Handler) AccountTransactions(args AccountTransactionsArg) (*AccountTransactionsChunk, *jsonrpc.Error) {
+	start, rpcErr := chunkStart(args.ChunkSize, args.ContinuationToken,
+		func(token string) (*blockchain.SenderTransactionID, error) {
+			id := new(blockchain.SenderTransactionID)
+			return id, id.FromString(token)
+		})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	filter := &blockchain.SenderTransactionsFilter{
+		FromNonce: args.FromNonce,
+		ToNonce:   args.ToNonce,
+		FromBlock: args.FromBlock,
+		ToBlock:   args.ToBlock,
+		ScanLimit: uint64(h.filterLimit),
+	}
+	transactions, next, err := h.bcReader.TransactionsBySender(&args.Address, filter, start, args.ChunkSize)
+	if err != nil {
+		return nil, ErrInternal.CloneWithData(err)
+	}
+
+	chunk := &AccountTransactionsChunk{Transactions: make([]*AccountTransaction, 0, len(transactions))}
+	for _, transaction := range transactions {
+		status := TxnSuccess
+		if transaction.Reverted {
+			status = TxnFailure
+		}
+		chunk.Transactions = append(chunk.Transactions, &AccountTransaction{
+			TransactionHash:  transaction.TransactionHash,
+			Nonce:            transaction.Nonce,
+			BlockNumber:      transaction.BlockNumber,
+			TransactionIndex: transaction.Index,
+			ExecutionStatus:  status,
+		})
+	}
+	if next != nil {
+		chunk.ContinuationToken = next.String()
+	}
+	return chunk, nil
+}
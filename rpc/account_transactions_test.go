@@ -0,0 +1,85 @@
+package rpc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountTransactions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	handler := rpc.New(mockReader, nil, nil, "", &utils.Mainnet, utils.NewNopZapLogger())
+	address := new(felt.Felt).SetUint64(1)
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		_, rpcErr := handler.AccountTransactions(rpc.AccountTransactionsArg{Address: *address})
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+		_, rpcErr = handler.AccountTransactions(rpc.AccountTransactionsArg{
+			Address:           *address,
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 10241},
+		})
+		assert.Equal(t, rpc.ErrPageSizeTooBig, rpcErr)
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, rpcErr := handler.AccountTransactions(rpc.AccountTransactionsArg{
+			Address:           *address,
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 10, ContinuationToken: "0x1"},
+		})
+		assert.Equal(t, rpc.ErrInvalidContinuationToken, rpcErr)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		fromBlock := uint64(3)
+		filter := &blockchain.SenderTransactionsFilter{
+			FromNonce: new(felt.Felt).SetUint64(2),
+			FromBlock: &fromBlock,
+			ScanLimit: math.MaxUint,
+		}
+		start := &blockchain.SenderTransactionID{Nonce: *new(felt.Felt).SetUint64(4), BlockNumber: 5, Index: 6}
+		next := &blockchain.SenderTransactionID{Nonce: *new(felt.Felt).SetUint64(7), BlockNumber: 8}
+		txs := []*blockchain.SenderTransaction{
+			{Nonce: &start.Nonce, BlockNumber: 5, Index: 6, TransactionHash: new(felt.Felt).SetUint64(9)},
+			{Nonce: new(felt.Felt).SetUint64(5), BlockNumber: 6, TransactionHash: new(felt.Felt).SetUint64(10), Reverted: true},
+		}
+		mockReader.EXPECT().TransactionsBySender(address, filter, start, uint64(2)).Return(txs, next, nil)
+
+		chunk, rpcErr := handler.AccountTransactions(rpc.AccountTransactionsArg{
+			Address:           *address,
+			FromNonce:         filter.FromNonce,
+			FromBlock:         &fromBlock,
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 2, ContinuationToken: "0x4-5-6"},
+		})
+		require.Nil(t, rpcErr)
+		assert.Equal(t, &rpc.AccountTransactionsChunk{
+			Transactions: []*rpc.AccountTransaction{
+				{
+					TransactionHash:  txs[0].TransactionHash,
+					Nonce:            txs[0].Nonce,
+					BlockNumber:      5,
+					TransactionIndex: 6,
+					ExecutionStatus:  rpc.TxnSuccess,
+				},
+				{
+					TransactionHash: txs[1].TransactionHash,
+					Nonce:           txs[1].Nonce,
+					BlockNumber:     6,
+					ExecutionStatus: rpc.TxnFailure,
+				},
+			},
+			ContinuationToken: "0x7-8-0",
+		}, chunk)
+	})
+}
@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+// ClassInstance is a contract that instantiates a class. Replaced is set if the contract was upgraded to another class
+// since.
+type ClassInstance struct {
+	Address    *felt.Felt `json:"address"`
+	DeployedAt uint64     `json:"deployed_at"`
+	Replaced   bool       `json:"replaced"`
+}
+
+type ClassInstancesChunk struct {
+	Contracts         []*ClassInstance `json:"contracts"`
+	ContinuationToken string           `json:"continuation_token,omitempty"`
+}
+
+// ContractsByClassHash returns the contracts that instantiate a class, including the ones that were upgraded away from
+// it, along with the block they were deployed at
+func (h *Handler) ContractsByClassHash(classHash felt.Felt, continuationToken string, chunkSize uint64) (
+	*ClassInstancesChunk, *jsonrpc.Error,
+) {
+	start, rpcErr := chunkStart(chunkSize, continuationToken, new(felt.Felt).SetString)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	instances, next, err := h.bcReader.ContractsByClassHash(&classHash, start, chunkSize)
+	if err != nil {
+		return nil, ErrInternal.CloneWithData(err)
+	}
+
+	chunk := &ClassInstancesChunk{Contracts: make([]*ClassInstance, 0, len(instances))}
+	for _, instance := range instances {
+		chunk.Contracts = append(chunk.Contracts, &ClassInstance{
+			Address:    instance.Address,
+			DeployedAt: instance.DeployedAt,
+			Replaced:   instance.Replaced,
+		})
+	}
+	if next != nil {
+		chunk.ContinuationToken = next.String()
+	}
+	return chunk, nil
+}
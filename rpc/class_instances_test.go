@@ -0,0 +1,55 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestContractsByClassHash(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	handler := rpc.New(mockReader, nil, nil, "", &utils.Mainnet, utils.NewNopZapLogger())
+	classHash := new(felt.Felt).SetUint64(1)
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		_, rpcErr := handler.ContractsByClassHash(*classHash, "", 0)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+		_, rpcErr = handler.ContractsByClassHash(*classHash, "", 10241)
+		assert.Equal(t, rpc.ErrPageSizeTooBig, rpcErr)
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, rpcErr := handler.ContractsByClassHash(*classHash, "x", 10)
+		assert.Equal(t, rpc.ErrInvalidContinuationToken, rpcErr)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		start, next := new(felt.Felt).SetUint64(2), new(felt.Felt).SetUint64(4)
+		instances := []*core.ClassInstance{
+			{Address: start, DeployedAt: 5},
+			{Address: new(felt.Felt).SetUint64(3), DeployedAt: 1, Replaced: true},
+		}
+		mockReader.EXPECT().ContractsByClassHash(classHash, start, uint64(2)).Return(instances, next, nil)
+
+		chunk, rpcErr := handler.ContractsByClassHash(*classHash, "0x2", 2)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, &rpc.ClassInstancesChunk{
+			Contracts: []*rpc.ClassInstance{
+				{Address: start, DeployedAt: 5},
+				{Address: instances[1].Address, DeployedAt: 1, Replaced: true},
+			},
+			ContinuationToken: "0x4",
+		}, chunk)
+	})
+}
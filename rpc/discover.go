@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"reflect"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+var feltSchema = &jsonrpc.Schema{
+	Type:    "string",
+	Pattern: "^0x(0|[a-fA-F1-9][a-fA-F0-9]{0,63})$",
+}
+
+// discoverySchemas are the schemas of the types whose JSON representation doesn't follow from their Go type
+var discoverySchemas = map[reflect.Type]*jsonrpc.Schema{
+	reflect.TypeOf(felt.Felt{}): feltSchema,
+	reflect.TypeOf(BlockID{}): {
+		OneOf: []*jsonrpc.Schema{
+			{Type: "string", Enum: []any{"latest", "pending"}},
+			{
+				Type:       "object",
+				Properties: map[string]*jsonrpc.Schema{"block_hash": feltSchema},
+				Required:   []string{"block_hash"},
+			},
+			{
+				Type:       "object",
+				Properties: map[string]*jsonrpc.Schema{"block_number": {Type: "integer"}},
+				Required:   []string{"block_number"},
+			},
+		},
+	},
+	reflect.TypeOf(TransactionType(0)): {
+		Type: "string",
+		Enum: []any{"DECLARE", "DEPLOY", "DEPLOY_ACCOUNT", "INVOKE", "L1_HANDLER"},
+	},
+	reflect.TypeOf(SimulationFlag(0)): {
+		Type: "string",
+		Enum: []any{"SKIP_VALIDATE", "SKIP_FEE_CHARGE", "RETURN_ACCESS_LIST"},
+	},
+}
+
+// Discover returns the OpenRPC document of the methods served on the v0.7 path
+func (h *Handler) Discover() (*jsonrpc.OpenRPCDocument, *jsonrpc.Error) {
+	methods, _ := h.Methods()
+	specVersion, _ := h.SpecVersion()
+	return h.discover(methods, specVersion)
+}
+
+// DiscoverV0_6 returns the OpenRPC document of the methods served on the v0.6 path
+func (h *Handler) DiscoverV0_6() (*jsonrpc.OpenRPCDocument, *jsonrpc.Error) {
+	methods, _ := h.MethodsV0_6()
+	specVersion, _ := h.SpecVersionV0_6()
+	return h.discover(methods, specVersion)
+}
+
+func (h *Handler) discover(methods []jsonrpc.Method, specVersion string) (*jsonrpc.OpenRPCDocument, *jsonrpc.Error) {
+	doc, err := jsonrpc.OpenRPC(jsonrpc.OpenRPCInfo{
+		Title:       "Juno Starknet API",
+		Description: "Juno " + h.version,
+		Version:     specVersion,
+	}, methods, discoverySchemas)
+	if err != nil {
+		return nil, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+	}
+	return doc, nil
+}
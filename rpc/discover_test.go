@@ -0,0 +1,94 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	handler := rpc.New(nil, nil, nil, "1.2.3", utils.Ptr(utils.Mainnet), nil)
+
+	tests := map[string]struct {
+		discover    func() (*jsonrpc.OpenRPCDocument, *jsonrpc.Error)
+		methods     func() ([]jsonrpc.Method, string)
+		specVersion string
+	}{
+		"v0_7": {discover: handler.Discover, methods: handler.Methods, specVersion: "0.7.1"},
+		"v0_6": {discover: handler.DiscoverV0_6, methods: handler.MethodsV0_6, specVersion: "0.6.0"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, rpcErr := test.discover()
+			require.Nil(t, rpcErr)
+			assert.Equal(t, test.specVersion, doc.Info.Version)
+			assert.Equal(t, "Juno 1.2.3", doc.Info.Description)
+
+			methods, _ := test.methods()
+			require.Len(t, doc.Methods, len(methods))
+			byName := make(map[string]jsonrpc.OpenRPCMethod, len(doc.Methods))
+			for _, method := range doc.Methods {
+				byName[method.Name] = method
+			}
+			for _, method := range methods {
+				require.Contains(t, byName, method.Name)
+				require.Len(t, byName[method.Name].Params, len(method.Params))
+				assert.Equal(t, method.Errors, byName[method.Name].Errors, method.Name)
+			}
+
+			getBlock := byName["starknet_getBlockWithTxHashes"]
+			assert.Equal(t, "block_id", getBlock.Params[0].Name)
+			assert.True(t, getBlock.Params[0].Required)
+			assert.Len(t, getBlock.Params[0].Schema.OneOf, 3)
+			assert.Equal(t, []*jsonrpc.Error{rpc.ErrBlockNotFound}, getBlock.Errors)
+			assert.NotEmpty(t, getBlock.Result.Schema.Ref)
+
+			traceTxn := byName["starknet_traceTransaction"]
+			assert.Equal(t, "transaction_hash", traceTxn.Params[0].Name)
+			assert.Equal(t, "string", traceTxn.Params[0].Schema.Type)
+			assert.NotEmpty(t, traceTxn.Params[0].Schema.Pattern)
+
+			assert.Contains(t, byName, jsonrpc.DiscoverMethod)
+
+			// every referenced schema is defined
+			docJSON, err := json.Marshal(doc)
+			require.NoError(t, err)
+			for _, ref := range refs(t, docJSON) {
+				assert.Contains(t, doc.Components.Schemas, ref[len("#/components/schemas/"):])
+			}
+		})
+	}
+}
+
+func refs(t *testing.T, docJSON []byte) []string {
+	t.Helper()
+
+	var doc any
+	require.NoError(t, json.Unmarshal(docJSON, &doc))
+
+	var found []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, value := range v {
+				if ref, ok := value.(string); ok && key == "$ref" {
+					found = append(found, ref)
+				}
+				walk(value)
+			}
+		case []any:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+	walk(doc)
+	return found
+}
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 
 	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/feed"
 	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/sync"
 )
 
 type EventsArg struct {
@@ -44,11 +47,36 @@ type EventsChunk struct {
 	ContinuationToken string          `json:"continuation_token,omitempty"`
 }
 
+// Reorg is a notification of juno_subscribeReorgs. CommonAncestor is null if the whole chain was reverted.
+type Reorg struct {
+	OldHead        BlockHeader  `json:"old_head"`
+	NewHead        BlockHeader  `json:"new_head"`
+	CommonAncestor *BlockHeader `json:"common_ancestor"`
+	Depth          uint64       `json:"depth"`
+}
+
 /****************************************************
 		Events Handlers
 *****************************************************/
 
 func (h *Handler) SubscribeNewHeads(ctx context.Context) (uint64, *jsonrpc.Error) {
+	return subscribe(ctx, h, "juno_subscribeNewHeads", h.newHeads, func(header *core.Header) any {
+		return adaptBlockHeader(header)
+	})
+}
+
+// SubscribeReorgs notifies the subscriber of every reorg once the replaced blocks are reverted
+func (h *Handler) SubscribeReorgs(ctx context.Context) (uint64, *jsonrpc.Error) {
+	return subscribe(ctx, h, "juno_subscribeReorgs", h.reorgs, func(reorg *sync.Reorg) any {
+		return adaptReorg(reorg)
+	})
+}
+
+// subscribe sends the values of f to the connection of ctx, adapted to their JSON representation, until the
+// subscription is cancelled
+func subscribe[T any](ctx context.Context, h *Handler, method string, f *feed.Feed[T], adapt func(T) any) (uint64,
+	*jsonrpc.Error,
+) {
 	w, ok := jsonrpc.ConnFromContext(ctx)
 	if !ok {
 		return 0, jsonrpc.Err(jsonrpc.MethodNotFound, nil)
@@ -63,22 +91,22 @@ func (h *Handler) SubscribeNewHeads(ctx context.Context) (uint64, *jsonrpc.Error
 	h.mu.Lock()
 	h.subscriptions[id] = sub
 	h.mu.Unlock()
-	headerSub := h.newHeads.Subscribe()
+	feedSub := f.Subscribe()
 	sub.wg.Go(func() {
 		defer func() {
-			headerSub.Unsubscribe()
+			feedSub.Unsubscribe()
 			h.unsubscribe(sub, id)
 		}()
 		for {
 			select {
 			case <-subscriptionCtx.Done():
 				return
-			case header := <-headerSub.Recv():
+			case v := <-feedSub.Recv():
 				resp, err := json.Marshal(jsonrpc.Request{
 					Version: "2.0",
-					Method:  "juno_subscribeNewHeads",
+					Method:  method,
 					Params: map[string]any{
-						"result":       adaptBlockHeader(header),
+						"result":       adapt(v),
 						"subscription": id,
 					},
 				})
@@ -183,6 +211,19 @@ func (h *Handler) Events(args EventsArg) (*EventsChunk, *jsonrpc.Error) {
 	return &EventsChunk{Events: emittedEvents, ContinuationToken: cTokenStr}, nil
 }
 
+func adaptReorg(reorg *sync.Reorg) *Reorg {
+	adapted := &Reorg{
+		OldHead: adaptBlockHeader(reorg.OldHead),
+		NewHead: adaptBlockHeader(reorg.NewHead),
+		Depth:   reorg.Depth,
+	}
+	if reorg.CommonAncestor != nil {
+		ancestor := adaptBlockHeader(reorg.CommonAncestor)
+		adapted.CommonAncestor = &ancestor
+	}
+	return adapted
+}
+
 // unsubscribe assumes h.mu is unlocked. It releases all subscription resources.
 func (h *Handler) unsubscribe(sub *subscription, id uint64) {
 	sub.cancel()
@@ -2,6 +2,7 @@ package rpc_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -14,13 +15,16 @@ import (
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/feed"
 	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/mocks"
 	"github.com/NethermindEth/juno/rpc"
 	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
 	"github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 	"nhooyr.io/websocket"
 )
 
@@ -391,3 +395,77 @@ func TestMultipleSubscribeNewHeadsAndUnsubscribe(t *testing.T) {
 	require.NoError(t, conn1.Write(ctx, websocket.MessageBinary, []byte(fmt.Sprintf(unsubMsg, firstID))))
 	require.NoError(t, conn2.Write(ctx, websocket.MessageBinary, []byte(fmt.Sprintf(unsubMsg, secondID))))
 }
+
+func TestSubscribeReorgs(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	reorgs := feed.New[*sync.Reorg]()
+	mockSyncReader := mocks.NewMockSyncReader(mockCtrl)
+	mockSyncReader.EXPECT().SubscribeNewHeads().Return(sync.HeaderSubscription{Subscription: feed.New[*core.Header]().Subscribe()})
+	mockSyncReader.EXPECT().SubscribeReorgs().Return(sync.ReorgSubscription{Subscription: reorgs.Subscribe()})
+
+	log := utils.NewNopZapLogger()
+	handler := rpc.New(nil, mockSyncReader, nil, "", &utils.Mainnet, log)
+
+	serverConn, clientConn := net.Pipe()
+	t.Cleanup(func() {
+		require.NoError(t, serverConn.Close())
+		require.NoError(t, clientConn.Close())
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// Subscribe without setting the connection on the context.
+	_, rpcErr := handler.SubscribeReorgs(ctx)
+	require.Equal(t, jsonrpc.MethodNotFound, rpcErr.Code)
+
+	subCtx := context.WithValue(ctx, jsonrpc.ConnKey{}, &fakeConn{w: serverConn})
+	id, rpcErr := handler.SubscribeReorgs(subCtx)
+	require.Nil(t, rpcErr)
+	go func() {
+		require.NoError(t, handler.Run(ctx))
+	}()
+
+	header := func(number uint64) *core.Header {
+		return &core.Header{
+			Number:          number,
+			Hash:            new(felt.Felt).SetUint64(100 + number),
+			ParentHash:      new(felt.Felt).SetUint64(99 + number),
+			GlobalStateRoot: &felt.Zero,
+		}
+	}
+	reorgs.Send(&sync.Reorg{OldHead: header(5), NewHead: header(6), CommonAncestor: header(3), Depth: 2})
+
+	got := make([]byte, 4096)
+	n, err := clientConn.Read(got)
+	require.NoError(t, err)
+
+	var notification struct {
+		Method string `json:"method"`
+		Params struct {
+			Result struct {
+				OldHead        map[string]any `json:"old_head"`
+				NewHead        map[string]any `json:"new_head"`
+				CommonAncestor map[string]any `json:"common_ancestor"`
+				Depth          uint64         `json:"depth"`
+			} `json:"result"`
+			Subscription uint64 `json:"subscription"`
+		} `json:"params"`
+	}
+	require.NoError(t, json.Unmarshal(got[:n], &notification))
+	assert.Equal(t, "juno_subscribeReorgs", notification.Method)
+	assert.Equal(t, id, notification.Params.Subscription)
+
+	reorg := notification.Params.Result
+	assert.Equal(t, uint64(2), reorg.Depth)
+	assert.Equal(t, float64(5), reorg.OldHead["block_number"])
+	assert.Equal(t, float64(6), reorg.NewHead["block_number"])
+	assert.Equal(t, "0x67", reorg.CommonAncestor["block_hash"])
+
+	ok, rpcErr := handler.Unsubscribe(subCtx, id)
+	require.Nil(t, rpcErr)
+	require.True(t, ok)
+}
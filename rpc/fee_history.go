@@ -0,0 +1,232 @@
+package rpc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+const (
+	maxFeeHistoryBlocks = 1024
+	// SuggestResourceBounds reads the receipts of every block in its range, so it accepts fewer blocks than FeeHistory
+	maxSuggestionBlocks = 100
+	// number of blocks considered by SuggestResourceBounds when block_count isn't provided
+	defaultSuggestionBlocks = 10
+	// percentile of the L1 gas consumed by recent transactions that is suggested as max_amount
+	suggestedAmountPercentile = 90
+)
+
+type FeeHistory struct {
+	OldestBlock    uint64          `json:"oldest_block"`
+	L1GasPrice     []ResourcePrice `json:"l1_gas_price"`
+	L1DataGasPrice []ResourcePrice `json:"l1_data_gas_price"`
+	Reward         []FeeReward     `json:"reward,omitempty"`
+}
+
+// FeeReward holds the requested percentiles of the actual fees paid by the transactions of a block, split by fee unit.
+// Percentiles of a unit without any transactions in the block are zero.
+type FeeReward struct {
+	InWei []*felt.Felt `json:"fee_in_wei"`
+	InFri []*felt.Felt `json:"fee_in_fri"`
+}
+
+// FeeHistory returns the L1 gas and data gas prices of up to blockCount blocks ending at newestBlock, oldest first.
+// If rewardPercentiles is not empty, the percentiles of the actual fees paid by the transactions of each block
+// are returned as well. Percentiles need to be in [0, 100] and in ascending order.
+func (h *Handler) FeeHistory(blockCount uint64, newestBlock BlockID, rewardPercentiles []float64) (*FeeHistory, *jsonrpc.Error) {
+	if blockCount == 0 || blockCount > maxFeeHistoryBlocks {
+		return nil, jsonrpc.Err(jsonrpc.InvalidParams, fmt.Sprintf("block_count must be between 1 and %d", maxFeeHistoryBlocks))
+	}
+	for i, percentile := range rewardPercentiles {
+		if percentile < 0 || percentile > 100 || (i > 0 && percentile < rewardPercentiles[i-1]) {
+			return nil, jsonrpc.Err(jsonrpc.InvalidParams, "reward_percentiles must be in [0, 100] and in ascending order")
+		}
+	}
+
+	headers, rpcErr := h.feeHistoryHeaders(blockCount, newestBlock)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	history := &FeeHistory{
+		OldestBlock:    headers[0].Number,
+		L1GasPrice:     make([]ResourcePrice, 0, len(headers)),
+		L1DataGasPrice: make([]ResourcePrice, 0, len(headers)),
+	}
+	for i, header := range headers {
+		adaptedHeader := adaptBlockHeader(header)
+		history.L1GasPrice = append(history.L1GasPrice, *adaptedHeader.L1GasPrice)
+		history.L1DataGasPrice = append(history.L1DataGasPrice, *adaptedHeader.L1DataGasPrice)
+
+		if len(rewardPercentiles) > 0 {
+			receipts, receiptsErr := h.feeHistoryReceipts(header, newestBlock.Pending && i == len(headers)-1)
+			if receiptsErr != nil {
+				return nil, receiptsErr
+			}
+
+			var feesInWei, feesInFri []*felt.Felt
+			for _, receipt := range receipts {
+				if receipt.FeeUnit == core.STRK {
+					feesInFri = append(feesInFri, receipt.Fee)
+				} else {
+					feesInWei = append(feesInWei, receipt.Fee)
+				}
+			}
+			history.Reward = append(history.Reward, FeeReward{
+				InWei: percentiles(feesInWei, rewardPercentiles),
+				InFri: percentiles(feesInFri, rewardPercentiles),
+			})
+		}
+	}
+	return history, nil
+}
+
+// SuggestResourceBounds recommends resource bounds for V3 transactions based on the last blockCount blocks.
+//
+// The suggested max_price_per_unit for L1 gas is the highest L1 gas price in FRI over these blocks with a 50% margin.
+// The suggested max_amount is the 90th percentile of the L1 gas consumed by their transactions, which is derived from
+// the actual fees as receipts of blocks that publish their data in blobs don't report it. L2 gas is not charged yet,
+// so its bounds are zero.
+func (h *Handler) SuggestResourceBounds(blockCount uint64) (map[Resource]ResourceBounds, *jsonrpc.Error) {
+	if blockCount == 0 {
+		blockCount = defaultSuggestionBlocks
+	}
+	if blockCount > maxSuggestionBlocks {
+		return nil, jsonrpc.Err(jsonrpc.InvalidParams, fmt.Sprintf("block_count must be at most %d", maxSuggestionBlocks))
+	}
+
+	headers, rpcErr := h.feeHistoryHeaders(blockCount, BlockID{Latest: true})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	maxPrice := new(big.Int)
+	var amounts []*felt.Felt
+	for _, header := range headers {
+		priceInFri := nilToZero(header.GasPriceSTRK).BigInt(new(big.Int))
+		if priceInFri.Cmp(maxPrice) > 0 {
+			maxPrice = priceInFri
+		}
+
+		receipts, receiptsErr := h.feeHistoryReceipts(header, false)
+		if receiptsErr != nil {
+			return nil, receiptsErr
+		}
+		for _, receipt := range receipts {
+			if amount := l1GasConsumed(header, receipt); amount != nil {
+				amounts = append(amounts, amount)
+			}
+		}
+	}
+
+	// 50% margin on top of the highest price
+	maxPrice.Mul(maxPrice, big.NewInt(3))
+	maxPrice.Div(maxPrice, big.NewInt(2))
+
+	maxAmount := &felt.Zero
+	if len(amounts) > 0 {
+		maxAmount = percentiles(amounts, []float64{suggestedAmountPercentile})[0]
+	}
+
+	return map[Resource]ResourceBounds{
+		ResourceL1Gas: {
+			MaxAmount:       maxAmount,
+			MaxPricePerUnit: new(felt.Felt).SetBigInt(maxPrice),
+		},
+		ResourceL2Gas: {
+			MaxAmount:       &felt.Zero,
+			MaxPricePerUnit: &felt.Zero,
+		},
+	}, nil
+}
+
+// l1GasConsumed returns the L1 gas paid for by the transaction with the given receipt, which is its actual fee without
+// the L1 data gas fee divided by the L1 gas price of the block in the fee unit of the transaction.
+// It returns nil if the block doesn't have an L1 gas price in that unit.
+func l1GasConsumed(header *core.Header, receipt *core.TransactionReceipt) *felt.Felt {
+	gasPrice, dataGasPrice := header.GasPrice, &felt.Zero
+	if header.L1DataGasPrice != nil {
+		dataGasPrice = nilToZero(header.L1DataGasPrice.PriceInWei)
+	}
+	if receipt.FeeUnit == core.STRK {
+		gasPrice = header.GasPriceSTRK
+		if header.L1DataGasPrice != nil {
+			dataGasPrice = nilToZero(header.L1DataGasPrice.PriceInFri)
+		}
+	}
+	if receipt.Fee == nil || gasPrice == nil || gasPrice.IsZero() {
+		return nil
+	}
+
+	fee := receipt.Fee.BigInt(new(big.Int))
+	if receipt.ExecutionResources != nil && receipt.ExecutionResources.DataAvailability != nil {
+		dataGasFee := dataGasPrice.BigInt(new(big.Int))
+		dataGasFee.Mul(dataGasFee, new(big.Int).SetUint64(receipt.ExecutionResources.DataAvailability.L1DataGas))
+		if fee.Sub(fee, dataGasFee).Sign() < 0 {
+			fee.SetUint64(0)
+		}
+	}
+	return new(felt.Felt).SetBigInt(fee.Div(fee, gasPrice.BigInt(new(big.Int))))
+}
+
+// feeHistoryHeaders returns the headers of up to count blocks ending at newest, oldest first
+func (h *Handler) feeHistoryHeaders(count uint64, newest BlockID) ([]*core.Header, *jsonrpc.Error) {
+	newestHeader, rpcErr := h.blockHeaderByID(&newest)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	count = min(count, newestHeader.Number+1)
+	headers := make([]*core.Header, count)
+	headers[count-1] = newestHeader
+	for i := uint64(1); i < count; i++ {
+		headers[count-1-i], rpcErr = h.blockHeaderByID(&BlockID{Number: newestHeader.Number - i})
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+	return headers, nil
+}
+
+// feeHistoryReceipts returns the receipts of the block with the given header, which is the pending block if pending is set
+func (h *Handler) feeHistoryReceipts(header *core.Header, pending bool) ([]*core.TransactionReceipt, *jsonrpc.Error) {
+	if pending {
+		pendingBlock, rpcErr := h.blockByID(&BlockID{Pending: true})
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return pendingBlock.Receipts, nil
+	}
+
+	receipts, err := h.bcReader.ReceiptsByBlockNumber(header.Number)
+	if err != nil {
+		return nil, ErrInternal.CloneWithData(err)
+	}
+	return receipts, nil
+}
+
+// percentiles returns the given percentiles of values using the nearest-rank method
+func percentiles(values []*felt.Felt, ps []float64) []*felt.Felt {
+	result := make([]*felt.Felt, 0, len(ps))
+	if len(values) == 0 {
+		for range ps {
+			result = append(result, &felt.Zero)
+		}
+		return result
+	}
+
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a, b *felt.Felt) int {
+		return a.Cmp(b)
+	})
+	for _, p := range ps {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		result = append(result, sorted[max(rank-1, 0)])
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFeeHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	handler := rpc.New(mockReader, nil, nil, "", utils.Ptr(utils.Mainnet), nil)
+
+	f := func(v uint64) *felt.Felt {
+		return new(felt.Felt).SetUint64(v)
+	}
+	l1Gas := func(v uint64) *core.ExecutionResources {
+		return &core.ExecutionResources{DataAvailability: &core.DataAvailability{L1Gas: v}}
+	}
+	blocks := []*core.Block{
+		{
+			Header: &core.Header{Number: 0, GasPrice: f(10), GasPriceSTRK: f(20)},
+			Receipts: []*core.TransactionReceipt{
+				{Fee: f(100), FeeUnit: core.WEI, ExecutionResources: l1Gas(10)},
+				{Fee: f(300), FeeUnit: core.WEI, ExecutionResources: l1Gas(30)},
+				{Fee: f(200), FeeUnit: core.WEI},
+			},
+		},
+		{
+			Header: &core.Header{
+				Number:         1,
+				GasPrice:       f(11),
+				GasPriceSTRK:   f(40),
+				L1DataGasPrice: &core.GasPrice{PriceInWei: f(1), PriceInFri: f(2)},
+			},
+			Receipts: []*core.TransactionReceipt{
+				{Fee: f(400), FeeUnit: core.STRK, ExecutionResources: l1Gas(20)},
+			},
+		},
+	}
+	for _, block := range blocks {
+		mockReader.EXPECT().BlockHeaderByNumber(block.Number).Return(block.Header, nil).AnyTimes()
+		mockReader.EXPECT().ReceiptsByBlockNumber(block.Number).Return(block.Receipts, nil).AnyTimes()
+	}
+	mockReader.EXPECT().HeadsHeader().Return(blocks[1].Header, nil).AnyTimes()
+
+	t.Run("invalid block count", func(t *testing.T) {
+		_, rpcErr := handler.FeeHistory(0, rpc.BlockID{Latest: true}, nil)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+	})
+
+	t.Run("invalid percentiles", func(t *testing.T) {
+		_, rpcErr := handler.FeeHistory(1, rpc.BlockID{Latest: true}, []float64{50, 10})
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+
+		_, rpcErr = handler.FeeHistory(1, rpc.BlockID{Latest: true}, []float64{101})
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+	})
+
+	t.Run("block not found", func(t *testing.T) {
+		mockReader.EXPECT().BlockHeaderByNumber(uint64(5)).Return(nil, db.ErrKeyNotFound)
+		_, rpcErr := handler.FeeHistory(1, rpc.BlockID{Number: 5}, nil)
+		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
+	})
+
+	t.Run("prices", func(t *testing.T) {
+		history, rpcErr := handler.FeeHistory(10, rpc.BlockID{Latest: true}, nil)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, &rpc.FeeHistory{
+			OldestBlock: 0,
+			L1GasPrice: []rpc.ResourcePrice{
+				{InWei: f(10), InFri: f(20)},
+				{InWei: f(11), InFri: f(40)},
+			},
+			L1DataGasPrice: []rpc.ResourcePrice{
+				{InWei: &felt.Zero, InFri: &felt.Zero},
+				{InWei: f(1), InFri: f(2)},
+			},
+		}, history)
+	})
+
+	t.Run("rewards", func(t *testing.T) {
+		history, rpcErr := handler.FeeHistory(1, rpc.BlockID{Number: 0}, []float64{0, 50, 100})
+		require.Nil(t, rpcErr)
+		assert.Equal(t, uint64(0), history.OldestBlock)
+		assert.Equal(t, []rpc.FeeReward{{
+			InWei: []*felt.Felt{f(100), f(200), f(300)},
+			InFri: []*felt.Felt{&felt.Zero, &felt.Zero, &felt.Zero},
+		}}, history.Reward)
+	})
+
+	t.Run("suggest resource bounds", func(t *testing.T) {
+		_, rpcErr := handler.SuggestResourceBounds(101)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+
+		bounds, rpcErr := handler.SuggestResourceBounds(0)
+		require.Nil(t, rpcErr)
+		// gas amounts are the fees divided by the gas prices: 10, 30, 20 and 10, max price is 40 with a 50% margin
+		assert.Equal(t, map[rpc.Resource]rpc.ResourceBounds{
+			rpc.ResourceL1Gas: {MaxAmount: f(30), MaxPricePerUnit: f(60)},
+			rpc.ResourceL2Gas: {MaxAmount: &felt.Zero, MaxPricePerUnit: &felt.Zero},
+		}, bounds)
+	})
+
+	t.Run("suggest resource bounds in blob mode", func(t *testing.T) {
+		blobReader := mocks.NewMockReader(mockCtrl)
+		blobHandler := rpc.New(blobReader, nil, nil, "", utils.Ptr(utils.Mainnet), nil)
+
+		// receipts of blocks that publish their data in blobs report the data gas but no L1 gas
+		blobGas := func(v uint64) *core.ExecutionResources {
+			return &core.ExecutionResources{DataAvailability: &core.DataAvailability{L1DataGas: v}}
+		}
+		header := &core.Header{
+			Number:         0,
+			GasPrice:       f(10),
+			GasPriceSTRK:   f(20),
+			L1DataGasPrice: &core.GasPrice{PriceInWei: f(1), PriceInFri: f(2)},
+			L1DAMode:       core.Blob,
+		}
+		receipts := []*core.TransactionReceipt{
+			{Fee: f(5*20 + 128*2), FeeUnit: core.STRK, ExecutionResources: blobGas(128)},
+			{Fee: f(7*10 + 64*1), FeeUnit: core.WEI, ExecutionResources: blobGas(64)},
+		}
+		blobReader.EXPECT().HeadsHeader().Return(header, nil)
+		blobReader.EXPECT().ReceiptsByBlockNumber(header.Number).Return(receipts, nil)
+
+		bounds, rpcErr := blobHandler.SuggestResourceBounds(1)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, map[rpc.Resource]rpc.ResourceBounds{
+			rpc.ResourceL1Gas: {MaxAmount: f(7), MaxPricePerUnit: f(30)},
+			rpc.ResourceL2Gas: {MaxAmount: &felt.Zero, MaxPricePerUnit: &felt.Zero},
+		}, bounds)
+	})
+}
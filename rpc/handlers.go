@@ -58,7 +58,8 @@ var (
 	ErrUnexpectedError                 = &jsonrpc.Error{Code: 63, Message: "An unexpected error occurred"}
 
 	// These errors can be only be returned by Juno-specific methods.
-	ErrSubscriptionNotFound = &jsonrpc.Error{Code: 100, Message: "Subscription not found"}
+	ErrSubscriptionNotFound  = &jsonrpc.Error{Code: 100, Message: "Subscription not found"}
+	ErrTransferIndexDisabled = &jsonrpc.Error{Code: 101, Message: "Token transfer index is disabled"}
 )
 
 const (
@@ -71,6 +72,7 @@ const (
 type traceCacheKey struct {
 	blockHash    felt.Felt
 	v0_6Response bool
+	accessList   bool
 }
 
 type Handler struct {
@@ -85,12 +87,15 @@ type Handler struct {
 	forceFeederTracesForBlocks *set.Set[uint64]
 
 	newHeads *feed.Feed[*core.Header]
+	reorgs   *feed.Feed[*sync.Reorg]
 
 	idgen         func() uint64
 	mu            stdsync.Mutex // protects subscriptions.
 	subscriptions map[uint64]*subscription
 
 	blockTraceCache *lru.Cache[traceCacheKey, []TracedBlockTransaction]
+	traceStore      *blockchain.TraceStore
+	eagerTracing    bool
 
 	filterLimit  uint
 	callMaxSteps uint64
@@ -119,6 +124,7 @@ func New(bcReader blockchain.Reader, syncReader sync.Reader, virtualMachine vm.V
 		version:                    version,
 		forceFeederTracesForBlocks: set.From(network.BlockHashMetaInfo.ForceFetchingTracesForBlocks),
 		newHeads:                   feed.New[*core.Header](),
+		reorgs:                     feed.New[*sync.Reorg](),
 		subscriptions:              make(map[uint64]*subscription),
 
 		blockTraceCache: lru.NewCache[traceCacheKey, []TracedBlockTransaction](traceCacheSize),
@@ -126,6 +132,11 @@ func New(bcReader blockchain.Reader, syncReader sync.Reader, virtualMachine vm.V
 	}
 }
 
+// PurgeTraceCache removes the block traces kept in memory, the traces in the trace store are kept
+func (h *Handler) PurgeTraceCache() {
+	h.blockTraceCache.Purge()
+}
+
 // WithFilterLimit sets the maximum number of blocks to scan in a single call for event filtering.
 func (h *Handler) WithFilterLimit(limit uint) *Handler {
 	h.filterLimit = limit
@@ -152,10 +163,26 @@ func (h *Handler) WithGateway(gatewayClient Gateway) *Handler {
 	return h
 }
 
+// WithTraceStore sets the persistent store for block traces. If eager is set, the traces of new blocks
+// are stored as soon as they are synced instead of on the first request.
+func (h *Handler) WithTraceStore(store *blockchain.TraceStore, eager bool) *Handler {
+	h.traceStore = store
+	h.eagerTracing = eager
+	return h
+}
+
 func (h *Handler) Run(ctx context.Context) error {
 	newHeadsSub := h.syncReader.SubscribeNewHeads().Subscription
 	defer newHeadsSub.Unsubscribe()
 	feed.Tee[*core.Header](newHeadsSub, h.newHeads)
+	reorgsSub := h.syncReader.SubscribeReorgs().Subscription
+	defer reorgsSub.Unsubscribe()
+	feed.Tee[*sync.Reorg](reorgsSub, h.reorgs)
+	if h.traceStore != nil && h.eagerTracing {
+		tracingSub := h.newHeads.Subscribe()
+		defer tracingSub.Unsubscribe()
+		go h.traceNewHeads(ctx, tracingSub)
+	}
 	<-ctx.Done()
 	for _, sub := range h.subscriptions {
 		sub.wg.Wait()
@@ -175,6 +202,18 @@ func (h *Handler) SpecVersionV0_6() (string, *jsonrpc.Error) {
 	return "0.6.0", nil
 }
 
+// addTransactionErrors are the errors of every method that adds a transaction, declare transactions can fail in more ways
+var (
+	addTransactionErrors = []*jsonrpc.Error{
+		ErrInsufficientAccountBalance, ErrInsufficientMaxFee, ErrInvalidTransactionNonce, ErrValidationFailure, ErrNonAccount,
+		ErrDuplicateTx, ErrUnsupportedTxVersion, ErrUnexpectedError,
+	}
+	declareTransactionErrors = append([]*jsonrpc.Error{
+		ErrClassAlreadyDeclared, ErrCompilationFailed, ErrCompiledClassHashMismatch, ErrContractClassSizeTooLarge,
+		ErrInvalidContractClass, ErrUnsupportedContractClassVersion,
+	}, addTransactionErrors...)
+)
+
 func (h *Handler) Methods() ([]jsonrpc.Method, string) { //nolint: funlen
 	return []jsonrpc.Method{
 		{
@@ -184,45 +223,54 @@ func (h *Handler) Methods() ([]jsonrpc.Method, string) { //nolint: funlen
 		{
 			Name:    "starknet_blockNumber",
 			Handler: h.BlockNumber,
+			Errors:  []*jsonrpc.Error{ErrNoBlock},
 		},
 		{
 			Name:    "starknet_blockHashAndNumber",
 			Handler: h.BlockHashAndNumber,
+			Errors:  []*jsonrpc.Error{ErrNoBlock},
 		},
 		{
 			Name:    "starknet_getBlockWithTxHashes",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.BlockWithTxHashes,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
 		},
 		{
 			Name:    "starknet_getBlockWithTxs",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.BlockWithTxs,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
 		},
 		{
 			Name:    "starknet_getTransactionByHash",
 			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
 			Handler: h.TransactionByHash,
+			Errors:  []*jsonrpc.Error{ErrTxnHashNotFound},
 		},
 		{
 			Name:    "starknet_getTransactionReceipt",
 			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
 			Handler: h.TransactionReceiptByHash,
+			Errors:  []*jsonrpc.Error{ErrTxnHashNotFound},
 		},
 		{
 			Name:    "starknet_getBlockTransactionCount",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.BlockTransactionCount,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
 		},
 		{
 			Name:    "starknet_getTransactionByBlockIdAndIndex",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "index"}},
 			Handler: h.TransactionByBlockIDAndIndex,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrInvalidTxIndex},
 		},
 		{
 			Name:    "starknet_getStateUpdate",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.StateUpdate,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
 		},
 		{
 			Name:    "starknet_syncing",
@@ -232,46 +280,55 @@ func (h *Handler) Methods() ([]jsonrpc.Method, string) { //nolint: funlen
 			Name:    "starknet_getNonce",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "contract_address"}},
 			Handler: h.Nonce,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound},
 		},
 		{
 			Name:    "starknet_getStorageAt",
 			Params:  []jsonrpc.Parameter{{Name: "contract_address"}, {Name: "key"}, {Name: "block_id"}},
 			Handler: h.StorageAt,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound},
 		},
 		{
 			Name:    "starknet_getClassHashAt",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "contract_address"}},
 			Handler: h.ClassHashAt,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound},
 		},
 		{
 			Name:    "starknet_getClass",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "class_hash"}},
 			Handler: h.Class,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrClassHashNotFound},
 		},
 		{
 			Name:    "starknet_getClassAt",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "contract_address"}},
 			Handler: h.ClassAt,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound, ErrClassHashNotFound},
 		},
 		{
 			Name:    "starknet_addInvokeTransaction",
 			Params:  []jsonrpc.Parameter{{Name: "invoke_transaction"}},
 			Handler: h.AddTransaction,
+			Errors:  addTransactionErrors,
 		},
 		{
 			Name:    "starknet_addDeployAccountTransaction",
 			Params:  []jsonrpc.Parameter{{Name: "deploy_account_transaction"}},
 			Handler: h.AddTransaction,
+			Errors:  addTransactionErrors,
 		},
 		{
 			Name:    "starknet_addDeclareTransaction",
 			Params:  []jsonrpc.Parameter{{Name: "declare_transaction"}},
 			Handler: h.AddTransaction,
+			Errors:  declareTransactionErrors,
 		},
 		{
 			Name:    "starknet_getEvents",
 			Params:  []jsonrpc.Parameter{{Name: "filter"}},
 			Handler: h.Events,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrTooManyKeysInFilter},
 		},
 		{
 			Name:    "juno_version",
@@ -281,36 +338,43 @@ func (h *Handler) Methods() ([]jsonrpc.Method, string) { //nolint: funlen
 			Name:    "starknet_getTransactionStatus",
 			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
 			Handler: h.TransactionStatus,
+			Errors:  []*jsonrpc.Error{ErrTxnHashNotFound},
 		},
 		{
 			Name:    "starknet_call",
 			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "block_id"}},
 			Handler: h.Call,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound, ErrContractError},
 		},
 		{
 			Name:    "starknet_estimateFee",
 			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "simulation_flags"}, {Name: "block_id"}},
 			Handler: h.EstimateFee,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrTransactionExecutionError},
 		},
 		{
 			Name:    "starknet_estimateMessageFee",
 			Params:  []jsonrpc.Parameter{{Name: "message"}, {Name: "block_id"}},
 			Handler: h.EstimateMessageFee,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound, ErrContractError},
 		},
 		{
 			Name:    "starknet_traceTransaction",
-			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
+			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}, {Name: "simulation_flags", Optional: true}},
 			Handler: h.TraceTransaction,
+			Errors:  []*jsonrpc.Error{ErrTxnHashNotFound, ErrUnexpectedError},
 		},
 		{
 			Name:    "starknet_simulateTransactions",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "transactions"}, {Name: "simulation_flags"}},
 			Handler: h.SimulateTransactions,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrTransactionExecutionError},
 		},
 		{
 			Name:    "starknet_traceBlockTransactions",
-			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
+			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "simulation_flags", Optional: true}},
 			Handler: h.TraceBlockTransactions,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrUnexpectedError},
 		},
 		{
 			Name:    "starknet_specVersion",
@@ -320,15 +384,90 @@ func (h *Handler) Methods() ([]jsonrpc.Method, string) { //nolint: funlen
 			Name:    "juno_subscribeNewHeads",
 			Handler: h.SubscribeNewHeads,
 		},
+		{
+			Name:    "juno_subscribeReorgs",
+			Handler: h.SubscribeReorgs,
+		},
 		{
 			Name:    "juno_unsubscribe",
 			Params:  []jsonrpc.Parameter{{Name: "id"}},
 			Handler: h.Unsubscribe,
+			Errors:  []*jsonrpc.Error{ErrSubscriptionNotFound},
+		},
+		{
+			Name:    "juno_feeHistory",
+			Params:  []jsonrpc.Parameter{{Name: "block_count"}, {Name: "newest_block"}, {Name: "reward_percentiles", Optional: true}},
+			Handler: h.FeeHistory,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
+		},
+		{
+			Name:    "juno_suggestResourceBounds",
+			Params:  []jsonrpc.Parameter{{Name: "block_count", Optional: true}},
+			Handler: h.SuggestResourceBounds,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
+		},
+		{
+			Name: "juno_getTransfers",
+			Params: []jsonrpc.Parameter{
+				{Name: "address"}, {Name: "token", Optional: true}, {Name: "continuation_token", Optional: true}, {Name: "chunk_size"},
+			},
+			Handler: h.Transfers,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrTransferIndexDisabled},
+		},
+		{
+			Name:    "juno_getTokenBalance",
+			Params:  []jsonrpc.Parameter{{Name: "token"}, {Name: "address"}, {Name: "block_id"}},
+			Handler: h.TokenBalance,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound, ErrContractError},
+		},
+		{
+			Name:    "juno_getContractsByClassHash",
+			Params:  []jsonrpc.Parameter{{Name: "class_hash"}, {Name: "continuation_token", Optional: true}, {Name: "chunk_size"}},
+			Handler: h.ContractsByClassHash,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken},
+		},
+		{
+			Name:    "juno_getAccountTransactions",
+			Params:  []jsonrpc.Parameter{{Name: "filter"}},
+			Handler: h.AccountTransactions,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken},
+		},
+		{
+			Name: "juno_getStorageHistory",
+			Params: []jsonrpc.Parameter{
+				{Name: "contract_address"}, {Name: "key"}, {Name: "from_block", Optional: true}, {Name: "to_block", Optional: true},
+				{Name: "continuation_token", Optional: true}, {Name: "chunk_size"},
+			},
+			Handler: h.StorageHistory,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrContractNotFound},
+		},
+		{
+			Name: "juno_getNonceHistory",
+			Params: []jsonrpc.Parameter{
+				{Name: "contract_address"}, {Name: "from_block", Optional: true}, {Name: "to_block", Optional: true},
+				{Name: "continuation_token", Optional: true}, {Name: "chunk_size"},
+			},
+			Handler: h.NonceHistory,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrContractNotFound},
+		},
+		{
+			Name: "juno_getClassHashHistory",
+			Params: []jsonrpc.Parameter{
+				{Name: "contract_address"}, {Name: "from_block", Optional: true}, {Name: "to_block", Optional: true},
+				{Name: "continuation_token", Optional: true}, {Name: "chunk_size"},
+			},
+			Handler: h.ClassHashHistory,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrContractNotFound},
 		},
 		{
 			Name:    "starknet_getBlockWithReceipts",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.BlockWithReceipts,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
+		},
+		{
+			Name:    jsonrpc.DiscoverMethod,
+			Handler: h.Discover,
 		},
 	}, "/v0_7"
 }
@@ -342,45 +481,54 @@ func (h *Handler) MethodsV0_6() ([]jsonrpc.Method, string) { //nolint: funlen
 		{
 			Name:    "starknet_blockNumber",
 			Handler: h.BlockNumber,
+			Errors:  []*jsonrpc.Error{ErrNoBlock},
 		},
 		{
 			Name:    "starknet_blockHashAndNumber",
 			Handler: h.BlockHashAndNumber,
+			Errors:  []*jsonrpc.Error{ErrNoBlock},
 		},
 		{
 			Name:    "starknet_getBlockWithTxHashes",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.BlockWithTxHashesV0_6,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
 		},
 		{
 			Name:    "starknet_getBlockWithTxs",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.BlockWithTxsV0_6,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
 		},
 		{
 			Name:    "starknet_getTransactionByHash",
 			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
 			Handler: h.TransactionByHash,
+			Errors:  []*jsonrpc.Error{ErrTxnHashNotFound},
 		},
 		{
 			Name:    "starknet_getTransactionReceipt",
 			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
 			Handler: h.TransactionReceiptByHashV0_6,
+			Errors:  []*jsonrpc.Error{ErrTxnHashNotFound},
 		},
 		{
 			Name:    "starknet_getBlockTransactionCount",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.BlockTransactionCount,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
 		},
 		{
 			Name:    "starknet_getTransactionByBlockIdAndIndex",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "index"}},
 			Handler: h.TransactionByBlockIDAndIndex,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrInvalidTxIndex},
 		},
 		{
 			Name:    "starknet_getStateUpdate",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.StateUpdate,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
 		},
 		{
 			Name:    "starknet_syncing",
@@ -390,46 +538,55 @@ func (h *Handler) MethodsV0_6() ([]jsonrpc.Method, string) { //nolint: funlen
 			Name:    "starknet_getNonce",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "contract_address"}},
 			Handler: h.Nonce,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound},
 		},
 		{
 			Name:    "starknet_getStorageAt",
 			Params:  []jsonrpc.Parameter{{Name: "contract_address"}, {Name: "key"}, {Name: "block_id"}},
 			Handler: h.StorageAt,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound},
 		},
 		{
 			Name:    "starknet_getClassHashAt",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "contract_address"}},
 			Handler: h.ClassHashAt,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound},
 		},
 		{
 			Name:    "starknet_getClass",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "class_hash"}},
 			Handler: h.Class,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrClassHashNotFound},
 		},
 		{
 			Name:    "starknet_getClassAt",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "contract_address"}},
 			Handler: h.ClassAt,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound, ErrClassHashNotFound},
 		},
 		{
 			Name:    "starknet_addInvokeTransaction",
 			Params:  []jsonrpc.Parameter{{Name: "invoke_transaction"}},
 			Handler: h.AddTransaction,
+			Errors:  addTransactionErrors,
 		},
 		{
 			Name:    "starknet_addDeployAccountTransaction",
 			Params:  []jsonrpc.Parameter{{Name: "deploy_account_transaction"}},
 			Handler: h.AddTransaction,
+			Errors:  addTransactionErrors,
 		},
 		{
 			Name:    "starknet_addDeclareTransaction",
 			Params:  []jsonrpc.Parameter{{Name: "declare_transaction"}},
 			Handler: h.AddTransaction,
+			Errors:  declareTransactionErrors,
 		},
 		{
 			Name:    "starknet_getEvents",
 			Params:  []jsonrpc.Parameter{{Name: "filter"}},
 			Handler: h.Events,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrTooManyKeysInFilter},
 		},
 		{
 			Name:    "juno_version",
@@ -439,36 +596,43 @@ func (h *Handler) MethodsV0_6() ([]jsonrpc.Method, string) { //nolint: funlen
 			Name:    "starknet_getTransactionStatus",
 			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
 			Handler: h.TransactionStatus,
+			Errors:  []*jsonrpc.Error{ErrTxnHashNotFound},
 		},
 		{
 			Name:    "starknet_call",
 			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "block_id"}},
 			Handler: h.CallV0_6,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound, ErrContractError},
 		},
 		{
 			Name:    "starknet_estimateFee",
 			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "simulation_flags"}, {Name: "block_id"}},
 			Handler: h.EstimateFeeV0_6,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrTransactionExecutionError},
 		},
 		{
 			Name:    "starknet_estimateMessageFee",
 			Params:  []jsonrpc.Parameter{{Name: "message"}, {Name: "block_id"}},
 			Handler: h.EstimateMessageFeeV0_6,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound, ErrContractError},
 		},
 		{
 			Name:    "starknet_traceTransaction",
 			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
 			Handler: h.TraceTransactionV0_6,
+			Errors:  []*jsonrpc.Error{ErrTxnHashNotFound, ErrUnexpectedError},
 		},
 		{
 			Name:    "starknet_simulateTransactions",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "transactions"}, {Name: "simulation_flags"}},
 			Handler: h.SimulateTransactionsV0_6,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrTransactionExecutionError},
 		},
 		{
 			Name:    "starknet_traceBlockTransactions",
 			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
 			Handler: h.TraceBlockTransactionsV0_6,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrUnexpectedError},
 		},
 		{
 			Name:    "starknet_specVersion",
@@ -478,10 +642,84 @@ func (h *Handler) MethodsV0_6() ([]jsonrpc.Method, string) { //nolint: funlen
 			Name:    "juno_subscribeNewHeads",
 			Handler: h.SubscribeNewHeads,
 		},
+		{
+			Name:    "juno_subscribeReorgs",
+			Handler: h.SubscribeReorgs,
+		},
 		{
 			Name:    "juno_unsubscribe",
 			Params:  []jsonrpc.Parameter{{Name: "id"}},
 			Handler: h.Unsubscribe,
+			Errors:  []*jsonrpc.Error{ErrSubscriptionNotFound},
+		},
+		{
+			Name:    "juno_feeHistory",
+			Params:  []jsonrpc.Parameter{{Name: "block_count"}, {Name: "newest_block"}, {Name: "reward_percentiles", Optional: true}},
+			Handler: h.FeeHistory,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
+		},
+		{
+			Name:    "juno_suggestResourceBounds",
+			Params:  []jsonrpc.Parameter{{Name: "block_count", Optional: true}},
+			Handler: h.SuggestResourceBounds,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound},
+		},
+		{
+			Name: "juno_getTransfers",
+			Params: []jsonrpc.Parameter{
+				{Name: "address"}, {Name: "token", Optional: true}, {Name: "continuation_token", Optional: true}, {Name: "chunk_size"},
+			},
+			Handler: h.Transfers,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrTransferIndexDisabled},
+		},
+		{
+			Name:    "juno_getTokenBalance",
+			Params:  []jsonrpc.Parameter{{Name: "token"}, {Name: "address"}, {Name: "block_id"}},
+			Handler: h.TokenBalance,
+			Errors:  []*jsonrpc.Error{ErrBlockNotFound, ErrContractNotFound, ErrContractError},
+		},
+		{
+			Name:    "juno_getContractsByClassHash",
+			Params:  []jsonrpc.Parameter{{Name: "class_hash"}, {Name: "continuation_token", Optional: true}, {Name: "chunk_size"}},
+			Handler: h.ContractsByClassHash,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken},
+		},
+		{
+			Name:    "juno_getAccountTransactions",
+			Params:  []jsonrpc.Parameter{{Name: "filter"}},
+			Handler: h.AccountTransactions,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken},
+		},
+		{
+			Name: "juno_getStorageHistory",
+			Params: []jsonrpc.Parameter{
+				{Name: "contract_address"}, {Name: "key"}, {Name: "from_block", Optional: true}, {Name: "to_block", Optional: true},
+				{Name: "continuation_token", Optional: true}, {Name: "chunk_size"},
+			},
+			Handler: h.StorageHistory,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrContractNotFound},
+		},
+		{
+			Name: "juno_getNonceHistory",
+			Params: []jsonrpc.Parameter{
+				{Name: "contract_address"}, {Name: "from_block", Optional: true}, {Name: "to_block", Optional: true},
+				{Name: "continuation_token", Optional: true}, {Name: "chunk_size"},
+			},
+			Handler: h.NonceHistory,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrContractNotFound},
+		},
+		{
+			Name: "juno_getClassHashHistory",
+			Params: []jsonrpc.Parameter{
+				{Name: "contract_address"}, {Name: "from_block", Optional: true}, {Name: "to_block", Optional: true},
+				{Name: "continuation_token", Optional: true}, {Name: "chunk_size"},
+			},
+			Handler: h.ClassHashHistory,
+			Errors:  []*jsonrpc.Error{ErrPageSizeTooBig, ErrInvalidContinuationToken, ErrContractNotFound},
+		},
+		{
+			Name:    jsonrpc.DiscoverMethod,
+			Handler: h.DiscoverV0_6,
 		},
 	}, "/v0_6"
 }
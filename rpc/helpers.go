@@ -168,3 +168,23 @@ func (h *Handler) stateByBlockID(id *BlockID) (core.StateReader, blockchain.Stat
 	}
 	return reader, closer, nil
 }
+
+// chunkStart checks the chunk size of a paginated request and parses its continuation token with parse. The zero value
+// of T is returned if there is no continuation token.
+func chunkStart[T any](chunkSize uint64, continuationToken string, parse func(string) (T, error)) (T, *jsonrpc.Error) {
+	var start T
+	if chunkSize == 0 {
+		return start, jsonrpc.Err(jsonrpc.InvalidParams, "chunk_size must be positive")
+	} else if chunkSize > maxEventChunkSize {
+		return start, ErrPageSizeTooBig
+	}
+
+	if continuationToken == "" {
+		return start, nil
+	}
+	parsed, err := parse(continuationToken)
+	if err != nil {
+		return start, ErrInvalidContinuationToken
+	}
+	return parsed, nil
+}
@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+// blockScopedMethods are the methods whose responses only depend on the block of their block_id param, which is
+// always their first param. The value is true if the response reports whether the block is accepted on L1, such
+// responses only stop changing once the block is.
+var blockScopedMethods = map[string]bool{
+	"starknet_getBlockWithTxHashes":   true,
+	"starknet_getBlockWithTxs":        true,
+	"starknet_getBlockWithReceipts":   true,
+	"starknet_getStateUpdate":         false,
+	"starknet_getClass":               false,
+	"starknet_traceBlockTransactions": false,
+}
+
+const receiptMethod = "starknet_getTransactionReceipt"
+
+// responseCachePolicy allows caching the responses of block scoped methods for blocks below the head of the chain
+type responseCachePolicy struct {
+	bcReader blockchain.Reader
+}
+
+// ResponseCachePolicy returns the policy of a jsonrpc.ResponseCache in front of the handler
+func (h *Handler) ResponseCachePolicy() jsonrpc.CachePolicy {
+	return &responseCachePolicy{bcReader: h.bcReader}
+}
+
+func (p *responseCachePolicy) Caches(method string) bool {
+	_, found := blockScopedMethods[method]
+	return found || method == receiptMethod
+}
+
+func (p *responseCachePolicy) Cacheable(method string, params, result any) (uint64, bool) {
+	if method == receiptMethod {
+		receipt, ok := result.(*TransactionReceipt)
+		if !ok || receipt.BlockNumber == nil || receipt.FinalityStatus != TxnAcceptedOnL1 {
+			return 0, false
+		}
+		return p.finalised(*receipt.BlockNumber, true)
+	}
+
+	reportsL1Status, found := blockScopedMethods[method]
+	if !found {
+		return 0, false
+	}
+	id, ok := blockIDParam(params)
+	if !ok || id.Latest || id.Pending {
+		return 0, false
+	}
+
+	number := id.Number
+	if id.Hash != nil {
+		header, err := p.bcReader.BlockHeaderByHash(id.Hash)
+		if err != nil {
+			return 0, false
+		}
+		number = header.Number
+	}
+	return p.finalised(number, reportsL1Status)
+}
+
+// finalised returns true if the block is below the head and, if l1 is set, accepted on L1
+func (p *responseCachePolicy) finalised(number uint64, l1 bool) (uint64, bool) {
+	height, err := p.bcReader.Height()
+	if err != nil || number >= height {
+		return 0, false
+	}
+
+	if l1 {
+		l1Head, err := p.bcReader.L1Head()
+		if err != nil || !isL1Verified(number, l1Head) {
+			return 0, false
+		}
+	}
+	return number, true
+}
+
+func blockIDParam(params any) (BlockID, bool) {
+	var param any
+	switch params := params.(type) {
+	case []any:
+		if len(params) > 0 {
+			param = params[0]
+		}
+	case map[string]any:
+		param = params["block_id"]
+	}
+	if param == nil {
+		return BlockID{}, false
+	}
+
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		return BlockID{}, false
+	}
+	var id BlockID
+	if err = json.Unmarshal(paramJSON, &id); err != nil {
+		return BlockID{}, false
+	}
+	return id, true
+}
@@ -0,0 +1,111 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResponseCachePolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	policy := rpc.New(mockReader, nil, nil, "", utils.Ptr(utils.Mainnet), nil).ResponseCachePolicy()
+
+	mockReader.EXPECT().Height().Return(uint64(10), nil).AnyTimes()
+	mockReader.EXPECT().L1Head().Return(&core.L1Head{BlockNumber: 5}, nil).AnyTimes()
+
+	hash := new(felt.Felt).SetUint64(0xabc)
+	mockReader.EXPECT().BlockHeaderByHash(hash).Return(&core.Header{Number: 7}, nil).AnyTimes()
+
+	t.Run("methods", func(t *testing.T) {
+		assert.True(t, policy.Caches("starknet_getStateUpdate"))
+		assert.True(t, policy.Caches("starknet_getTransactionReceipt"))
+		assert.False(t, policy.Caches("starknet_blockNumber"))
+		assert.False(t, policy.Caches("starknet_call"))
+	})
+
+	tests := map[string]struct {
+		method    string
+		params    any
+		cacheable bool
+		number    uint64
+	}{
+		"number below head": {
+			method:    "starknet_getStateUpdate",
+			params:    []any{map[string]any{"block_number": json.Number("9")}},
+			cacheable: true,
+			number:    9,
+		},
+		"named params": {
+			method:    "starknet_traceBlockTransactions",
+			params:    map[string]any{"block_id": map[string]any{"block_number": json.Number("3")}},
+			cacheable: true,
+			number:    3,
+		},
+		"hash below head": {
+			method:    "starknet_getClass",
+			params:    []any{map[string]any{"block_hash": "0xabc"}, "0x1"},
+			cacheable: true,
+			number:    7,
+		},
+		"head": {
+			method: "starknet_getStateUpdate",
+			params: []any{map[string]any{"block_number": json.Number("10")}},
+		},
+		"latest": {
+			method: "starknet_getStateUpdate",
+			params: []any{"latest"},
+		},
+		"pending": {
+			method: "starknet_getClass",
+			params: []any{"pending", "0x1"},
+		},
+		"block accepted on l1": {
+			method:    "starknet_getBlockWithTxs",
+			params:    []any{map[string]any{"block_number": json.Number("5")}},
+			cacheable: true,
+			number:    5,
+		},
+		"block not accepted on l1": {
+			method: "starknet_getBlockWithTxs",
+			params: []any{map[string]any{"block_hash": "0xabc"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			number, cacheable := policy.Cacheable(test.method, test.params, nil)
+			assert.Equal(t, test.cacheable, cacheable)
+			assert.Equal(t, test.number, number)
+		})
+	}
+
+	t.Run("receipts", func(t *testing.T) {
+		number, cacheable := policy.Cacheable("starknet_getTransactionReceipt", nil, &rpc.TransactionReceipt{
+			BlockNumber:    utils.Ptr[uint64](4),
+			FinalityStatus: rpc.TxnAcceptedOnL1,
+		})
+		assert.True(t, cacheable)
+		assert.Equal(t, uint64(4), number)
+
+		_, cacheable = policy.Cacheable("starknet_getTransactionReceipt", nil, &rpc.TransactionReceipt{
+			BlockNumber:    utils.Ptr[uint64](8),
+			FinalityStatus: rpc.TxnAcceptedOnL2,
+		})
+		assert.False(t, cacheable)
+
+		_, cacheable = policy.Cacheable("starknet_getTransactionReceipt", nil, &rpc.TransactionReceipt{
+			FinalityStatus: rpc.TxnAcceptedOnL2,
+		})
+		assert.False(t, cacheable)
+	})
+}
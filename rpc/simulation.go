@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
@@ -17,6 +18,8 @@ type SimulationFlag int
 const (
 	SkipValidateFlag SimulationFlag = iota + 1
 	SkipFeeChargeFlag
+	// ReturnAccessListFlag is a Juno-specific flag that includes the state read by each transaction in its trace
+	ReturnAccessListFlag
 )
 
 func (s *SimulationFlag) UnmarshalJSON(bytes []byte) (err error) {
@@ -25,6 +28,8 @@ func (s *SimulationFlag) UnmarshalJSON(bytes []byte) (err error) {
 		*s = SkipValidateFlag
 	case `"SKIP_FEE_CHARGE"`:
 		*s = SkipFeeChargeFlag
+	case `"RETURN_ACCESS_LIST"`:
+		*s = ReturnAccessListFlag
 	default:
 		err = fmt.Errorf("unknown simulation flag %q", flag)
 	}
@@ -46,25 +51,26 @@ type TracedBlockTransaction struct {
 		Simulate Handlers
 *****************************************************/
 
-func (h *Handler) SimulateTransactions(id BlockID, transactions []BroadcastedTransaction,
+func (h *Handler) SimulateTransactions(ctx context.Context, id BlockID, transactions []BroadcastedTransaction,
 	simulationFlags []SimulationFlag,
 ) ([]SimulatedTransaction, *jsonrpc.Error) {
-	return h.simulateTransactions(id, transactions, simulationFlags, false, false)
+	return h.simulateTransactions(ctx, id, transactions, simulationFlags, false, false)
 }
 
 // pre 13.1
-func (h *Handler) SimulateTransactionsV0_6(id BlockID, transactions []BroadcastedTransaction,
+func (h *Handler) SimulateTransactionsV0_6(ctx context.Context, id BlockID, transactions []BroadcastedTransaction,
 	simulationFlags []SimulationFlag,
 ) ([]SimulatedTransaction, *jsonrpc.Error) {
-	return h.simulateTransactions(id, transactions, simulationFlags, true, true)
+	return h.simulateTransactions(ctx, id, transactions, simulationFlags, true, true)
 }
 
 //nolint:funlen,gocyclo
-func (h *Handler) simulateTransactions(id BlockID, transactions []BroadcastedTransaction,
+func (h *Handler) simulateTransactions(ctx context.Context, id BlockID, transactions []BroadcastedTransaction,
 	simulationFlags []SimulationFlag, v0_6Response, errOnRevert bool,
 ) ([]SimulatedTransaction, *jsonrpc.Error) {
 	skipFeeCharge := slices.Contains(simulationFlags, SkipFeeChargeFlag)
 	skipValidate := slices.Contains(simulationFlags, SkipValidateFlag)
+	returnAccessList := slices.Contains(simulationFlags, ReturnAccessListFlag)
 
 	state, closer, rpcErr := h.stateByBlockID(&id)
 	if rpcErr != nil {
@@ -106,8 +112,8 @@ func (h *Handler) simulateTransactions(id BlockID, transactions []BroadcastedTra
 		BlockHashToBeRevealed: blockHashToBeRevealed,
 	}
 	useBlobData := !v0_6Response
-	overallFees, dataGasConsumed, traces, err := h.vm.Execute(txns, classes, paidFeesOnL1, &blockInfo,
-		state, h.bcReader.Network(), skipFeeCharge, skipValidate, errOnRevert, useBlobData)
+	overallFees, dataGasConsumed, traces, err := h.vm.Execute(ctx, txns, classes, paidFeesOnL1, &blockInfo,
+		state, h.bcReader.Network(), skipFeeCharge, skipValidate, errOnRevert, useBlobData, returnAccessList)
 	if err != nil {
 		if errors.Is(err, utils.ErrResourceBusy) {
 			return nil, ErrInternal.CloneWithData(throttledVMErr)
@@ -165,7 +171,6 @@ func (h *Handler) simulateTransactions(id BlockID, transactions []BroadcastedTra
 			executionResources.DataAvailability = vm.NewDataAvailability(gasConsumed, dataGasConsumed[i], header.L1DAMode)
 			traces[i].ExecutionResources = executionResources
 		}
-
 		result = append(result, SimulatedTransaction{
 			TransactionTrace: &traces[i],
 			FeeEstimation:    estimate,
@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+// StateChange is a value that a storage slot, a nonce or a class hash was set to at a block
+type StateChange struct {
+	BlockNumber uint64     `json:"block_number"`
+	Value       *felt.Felt `json:"value"`
+}
+
+type StateChangesChunk struct {
+	Changes           []*StateChange `json:"changes"`
+	ContinuationToken string         `json:"continuation_token,omitempty"`
+}
+
+// StorageHistory returns the changes to a storage slot of a contract in the order they happened
+func (h *Handler) StorageHistory(address, key felt.Felt, fromBlock uint64, toBlock *uint64, continuationToken string,
+	chunkSize uint64,
+) (*StateChangesChunk, *jsonrpc.Error) {
+	from, to, rpcErr := stateChangesRange(fromBlock, toBlock, continuationToken, chunkSize)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return adaptStateChanges(h.bcReader.ContractStorageChanges(&address, &key, from, to, chunkSize))
+}
+
+// NonceHistory returns the changes to the nonce of a contract in the order they happened
+func (h *Handler) NonceHistory(address felt.Felt, fromBlock uint64, toBlock *uint64, continuationToken string,
+	chunkSize uint64,
+) (*StateChangesChunk, *jsonrpc.Error) {
+	from, to, rpcErr := stateChangesRange(fromBlock, toBlock, continuationToken, chunkSize)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return adaptStateChanges(h.bcReader.ContractNonceChanges(&address, from, to, chunkSize))
+}
+
+// ClassHashHistory returns the class hashes that a contract instantiated, starting with the one it was deployed with
+func (h *Handler) ClassHashHistory(address felt.Felt, fromBlock uint64, toBlock *uint64, continuationToken string,
+	chunkSize uint64,
+) (*StateChangesChunk, *jsonrpc.Error) {
+	from, to, rpcErr := stateChangesRange(fromBlock, toBlock, continuationToken, chunkSize)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return adaptStateChanges(h.bcReader.ContractClassHashChanges(&address, from, to, chunkSize))
+}
+
+// stateChangesRange returns the inclusive block range to look for changes in. The continuation token is the block of
+// the next change.
+func stateChangesRange(fromBlock uint64, toBlock *uint64, continuationToken string, chunkSize uint64) (uint64, uint64,
+	*jsonrpc.Error,
+) {
+	next, rpcErr := chunkStart(chunkSize, continuationToken, func(token string) (*uint64, error) {
+		number, err := strconv.ParseUint(token, 10, 64)
+		if err == nil && number < fromBlock {
+			err = errors.New("continuation token before from_block")
+		}
+		return &number, err
+	})
+	if rpcErr != nil {
+		return 0, 0, rpcErr
+	}
+
+	to := uint64(math.MaxUint64)
+	if toBlock != nil {
+		to = *toBlock
+	}
+	if next != nil {
+		fromBlock = *next
+	}
+	return fromBlock, to, nil
+}
+
+func adaptStateChanges(changes []*core.ValueChange, next *uint64, err error) (*StateChangesChunk, *jsonrpc.Error) {
+	if err != nil {
+		if errors.Is(err, core.ErrContractNotDeployed) {
+			return nil, ErrContractNotFound
+		}
+		return nil, ErrInternal.CloneWithData(err)
+	}
+
+	chunk := &StateChangesChunk{Changes: make([]*StateChange, 0, len(changes))}
+	for _, change := range changes {
+		chunk.Changes = append(chunk.Changes, &StateChange{BlockNumber: change.BlockNumber, Value: change.Value})
+	}
+	if next != nil {
+		chunk.ContinuationToken = strconv.FormatUint(*next, 10)
+	}
+	return chunk, nil
+}
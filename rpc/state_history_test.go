@@ -0,0 +1,67 @@
+package rpc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStateHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	handler := rpc.New(mockReader, nil, nil, "", &utils.Mainnet, utils.NewNopZapLogger())
+	address, key := new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2)
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		_, rpcErr := handler.NonceHistory(*address, 0, nil, "", 0)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+		_, rpcErr = handler.NonceHistory(*address, 0, nil, "", 10241)
+		assert.Equal(t, rpc.ErrPageSizeTooBig, rpcErr)
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, rpcErr := handler.NonceHistory(*address, 0, nil, "x", 10)
+		assert.Equal(t, rpc.ErrInvalidContinuationToken, rpcErr)
+		_, rpcErr = handler.NonceHistory(*address, 5, nil, "4", 10)
+		assert.Equal(t, rpc.ErrInvalidContinuationToken, rpcErr)
+	})
+
+	t.Run("contract not found", func(t *testing.T) {
+		mockReader.EXPECT().ContractClassHashChanges(address, uint64(0), uint64(10), uint64(5)).
+			Return(nil, nil, core.ErrContractNotDeployed)
+		toBlock := uint64(10)
+		_, rpcErr := handler.ClassHashHistory(*address, 0, &toBlock, "", 5)
+		assert.Equal(t, rpc.ErrContractNotFound, rpcErr)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		next := uint64(9)
+		changes := []*core.ValueChange{
+			{BlockNumber: 3, Value: new(felt.Felt).SetUint64(4)},
+			{BlockNumber: 6, Value: new(felt.Felt).SetUint64(7)},
+		}
+		mockReader.EXPECT().ContractStorageChanges(address, key, uint64(3), uint64(math.MaxUint64), uint64(2)).
+			Return(changes, &next, nil)
+
+		chunk, rpcErr := handler.StorageHistory(*address, *key, 1, nil, "3", 2)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, &rpc.StateChangesChunk{
+			Changes: []*rpc.StateChange{
+				{BlockNumber: 3, Value: changes[0].Value},
+				{BlockNumber: 6, Value: changes[1].Value},
+			},
+			ContinuationToken: "9",
+		}, chunk)
+	})
+}
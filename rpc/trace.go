@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"slices"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/NethermindEth/juno/blockchain"
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/feed"
 	"github.com/NethermindEth/juno/jsonrpc"
 	"github.com/NethermindEth/juno/starknet"
 	"github.com/NethermindEth/juno/utils"
@@ -127,15 +129,19 @@ func adaptFeederExecutionResources(resources *starknet.ExecutionResources) *vm.E
 //
 // It follows the specification defined here:
 // https://github.com/starkware-libs/starknet-specs/blob/1ae810e0137cc5d175ace4554892a4f43052be56/api/starknet_trace_api_openrpc.json#L11
-func (h *Handler) TraceTransaction(ctx context.Context, hash felt.Felt) (*vm.TransactionTrace, *jsonrpc.Error) {
-	return h.traceTransaction(ctx, &hash, false)
+//
+// If the RETURN_ACCESS_LIST flag is set, the state read by the transaction is included in the trace.
+func (h *Handler) TraceTransaction(ctx context.Context, hash felt.Felt, flags []SimulationFlag) (*vm.TransactionTrace, *jsonrpc.Error) {
+	return h.traceTransaction(ctx, &hash, false, slices.Contains(flags, ReturnAccessListFlag))
 }
 
 func (h *Handler) TraceTransactionV0_6(ctx context.Context, hash felt.Felt) (*vm.TransactionTrace, *jsonrpc.Error) {
-	return h.traceTransaction(ctx, &hash, true)
+	return h.traceTransaction(ctx, &hash, true, false)
 }
 
-func (h *Handler) traceTransaction(ctx context.Context, hash *felt.Felt, v0_6Response bool) (*vm.TransactionTrace, *jsonrpc.Error) {
+func (h *Handler) traceTransaction(ctx context.Context, hash *felt.Felt, v0_6Response, accessList bool) (*vm.TransactionTrace,
+	*jsonrpc.Error,
+) {
 	_, blockHash, _, err := h.bcReader.Receipt(hash)
 	if err != nil {
 		return nil, ErrTxnHashNotFound
@@ -166,7 +172,7 @@ func (h *Handler) traceTransaction(ctx context.Context, hash *felt.Felt, v0_6Res
 		return nil, ErrTxnHashNotFound
 	}
 
-	traceResults, traceBlockErr := h.traceBlockTransactions(ctx, block, v0_6Response)
+	traceResults, traceBlockErr := h.traceBlockTransactions(ctx, block, v0_6Response, accessList)
 	if traceBlockErr != nil {
 		return nil, traceBlockErr
 	}
@@ -174,13 +180,16 @@ func (h *Handler) traceTransaction(ctx context.Context, hash *felt.Felt, v0_6Res
 	return traceResults[txIndex].TraceRoot, nil
 }
 
-func (h *Handler) TraceBlockTransactions(ctx context.Context, id BlockID) ([]TracedBlockTransaction, *jsonrpc.Error) {
+// TraceBlockTransactions returns the traces of all the transactions in a block.
+// If the RETURN_ACCESS_LIST flag is set, the state read by each transaction is included in its trace.
+func (h *Handler) TraceBlockTransactions(ctx context.Context, id BlockID, flags []SimulationFlag,
+) ([]TracedBlockTransaction, *jsonrpc.Error) {
 	block, rpcErr := h.blockByID(&id)
 	if rpcErr != nil {
 		return nil, rpcErr
 	}
 
-	return h.traceBlockTransactions(ctx, block, false)
+	return h.traceBlockTransactions(ctx, block, false, slices.Contains(flags, ReturnAccessListFlag))
 }
 
 func (h *Handler) TraceBlockTransactionsV0_6(ctx context.Context, id BlockID) ([]TracedBlockTransaction, *jsonrpc.Error) {
@@ -189,10 +198,14 @@ func (h *Handler) TraceBlockTransactionsV0_6(ctx context.Context, id BlockID) ([
 		return nil, rpcErr
 	}
 
-	return h.traceBlockTransactions(ctx, block, true)
+	return h.traceBlockTransactions(ctx, block, true, false)
 }
 
-func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block, v0_6Response bool, //nolint: gocyclo, funlen
+// traceBlockTransactions traces the transactions of a block, access lists are recorded only if accessList is set.
+// Traces with access lists are cached but not persisted in the trace store, and blocks that are traced by the feeder
+// gateway don't have them.
+func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block, v0_6Response, //nolint: gocyclo, funlen
+	accessList bool,
 ) ([]TracedBlockTransaction, *jsonrpc.Error) {
 	isPending := block.Hash == nil
 	if !isPending {
@@ -200,15 +213,27 @@ func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block,
 			return nil, ErrUnexpectedError.CloneWithData(err.Error())
 		} else if blockVer.Compare(traceFallbackVersion) != 1 || h.forceFeederTracesForBlocks.Contains(block.Number) {
 			// version <= 0.12.3 or forcing fetch some blocks from feeder gateway
+			if accessList {
+				return nil, jsonrpc.Err(jsonrpc.InvalidParams, "access lists are not available for blocks traced by the feeder gateway")
+			}
 			return h.fetchTraces(ctx, block.Hash)
 		}
 
-		if trace, hit := h.blockTraceCache.Get(traceCacheKey{
+		cacheKey := traceCacheKey{
 			blockHash:    *block.Hash,
 			v0_6Response: v0_6Response,
-		}); hit {
+			accessList:   accessList,
+		}
+		if trace, hit := h.blockTraceCache.Get(cacheKey); hit {
 			return trace, nil
 		}
+
+		if !v0_6Response && !accessList {
+			if trace, hit := h.storedBlockTraces(block); hit {
+				h.blockTraceCache.Add(cacheKey, trace)
+				return trace, nil
+			}
+		}
 	}
 
 	state, closer, err := h.bcReader.StateAtBlockHash(block.ParentHash)
@@ -260,8 +285,8 @@ func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block,
 	}
 
 	useBlobData := !v0_6Response
-	overallFees, dataGasConsumed, traces, err := h.vm.Execute(block.Transactions, classes, paidFeesOnL1, &blockInfo, state, network, false,
-		false, false, useBlobData)
+	overallFees, dataGasConsumed, traces, err := h.vm.Execute(ctx, block.Transactions, classes, paidFeesOnL1, &blockInfo, state, network, false,
+		false, false, useBlobData, accessList)
 	if err != nil {
 		if errors.Is(err, utils.ErrResourceBusy) {
 			return nil, ErrInternal.CloneWithData(throttledVMErr)
@@ -312,12 +337,90 @@ func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block,
 		h.blockTraceCache.Add(traceCacheKey{
 			blockHash:    *block.Hash,
 			v0_6Response: v0_6Response,
+			accessList:   accessList,
 		}, result)
+		if !v0_6Response && !accessList {
+			h.storeBlockTraces(block.Header, traces)
+		}
 	}
 
 	return result, nil
 }
 
+// storedBlockTraces looks up the traces of the given block in the persistent trace store.
+// Only the traces of the latest spec version are persisted.
+func (h *Handler) storedBlockTraces(block *core.Block) ([]TracedBlockTransaction, bool) {
+	if h.traceStore == nil {
+		return nil, false
+	}
+
+	storedTraces, err := h.traceStore.Traces(block.Number, block.Hash)
+	if err != nil {
+		if !errors.Is(err, blockchain.ErrTracesNotFound) {
+			h.log.Warnw("Failed to read stored traces", "number", block.Number, "err", err)
+		}
+		return nil, false
+	}
+
+	result := make([]TracedBlockTransaction, 0, len(storedTraces))
+	for index, traceJSON := range storedTraces {
+		trace := new(vm.TransactionTrace)
+		if err = json.Unmarshal(traceJSON, trace); err != nil {
+			h.log.Warnw("Failed to unmarshal stored trace", "number", block.Number, "index", index, "err", err)
+			return nil, false
+		}
+		result = append(result, TracedBlockTransaction{
+			TraceRoot:       trace,
+			TransactionHash: block.Transactions[index].Hash(),
+		})
+	}
+	return result, true
+}
+
+func (h *Handler) storeBlockTraces(header *core.Header, traces []vm.TransactionTrace) {
+	if h.traceStore == nil {
+		return
+	}
+
+	traceJSONs := make([][]byte, 0, len(traces))
+	for index := range traces {
+		traceJSON, err := json.Marshal(&traces[index])
+		if err != nil {
+			h.log.Warnw("Failed to marshal trace", "number", header.Number, "index", index, "err", err)
+			return
+		}
+		traceJSONs = append(traceJSONs, traceJSON)
+	}
+
+	if err := h.traceStore.StoreTraces(header, traceJSONs); err != nil {
+		h.log.Debugw("Failed to store traces", "number", header.Number, "err", err)
+	}
+}
+
+// traceNewHeads traces the blocks received from sub so that they are in the trace store before they are requested.
+// Heads that arrive while a block is being traced are skipped.
+func (h *Handler) traceNewHeads(ctx context.Context, sub *feed.Subscription[*core.Header]) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case header, ok := <-sub.Recv():
+			if !ok {
+				return
+			}
+
+			block, err := h.bcReader.BlockByHash(header.Hash)
+			if err != nil {
+				h.log.Debugw("Failed to get block for tracing", "number", header.Number, "err", err)
+				continue
+			}
+			if _, rpcErr := h.traceBlockTransactions(ctx, block, false, false); rpcErr != nil {
+				h.log.Debugw("Failed to trace block", "number", header.Number, "err", rpcErr.Message)
+			}
+		}
+	}
+}
+
 func (h *Handler) fetchTraces(ctx context.Context, blockHash *felt.Felt) ([]TracedBlockTransaction, *jsonrpc.Error) {
 	rpcBlock, err := h.BlockWithTxs(BlockID{
 		Hash: blockHash, // known non-nil
@@ -344,15 +447,15 @@ func (h *Handler) fetchTraces(ctx context.Context, blockHash *felt.Felt) ([]Trac
 }
 
 // https://github.com/starkware-libs/starknet-specs/blob/e0b76ed0d8d8eba405e182371f9edac8b2bcbc5a/api/starknet_api_openrpc.json#L401-L445
-func (h *Handler) Call(funcCall FunctionCall, id BlockID) ([]*felt.Felt, *jsonrpc.Error) { //nolint:gocritic
-	return h.call(funcCall, id, true)
+func (h *Handler) Call(ctx context.Context, funcCall FunctionCall, id BlockID) ([]*felt.Felt, *jsonrpc.Error) { //nolint:gocritic
+	return h.call(ctx, funcCall, id, true)
 }
 
-func (h *Handler) CallV0_6(call FunctionCall, id BlockID) ([]*felt.Felt, *jsonrpc.Error) { //nolint:gocritic
-	return h.call(call, id, false)
+func (h *Handler) CallV0_6(ctx context.Context, call FunctionCall, id BlockID) ([]*felt.Felt, *jsonrpc.Error) { //nolint:gocritic
+	return h.call(ctx, call, id, false)
 }
 
-func (h *Handler) call(funcCall FunctionCall, id BlockID, useBlobData bool) ([]*felt.Felt, *jsonrpc.Error) { //nolint:gocritic
+func (h *Handler) call(ctx context.Context, funcCall FunctionCall, id BlockID, useBlobData bool) ([]*felt.Felt, *jsonrpc.Error) { //nolint:gocritic
 	state, closer, rpcErr := h.stateByBlockID(&id)
 	if rpcErr != nil {
 		return nil, rpcErr
@@ -374,7 +477,7 @@ func (h *Handler) call(funcCall FunctionCall, id BlockID, useBlobData bool) ([]*
 		return nil, ErrInternal.CloneWithData(err)
 	}
 
-	res, err := h.vm.Call(&vm.CallInfo{
+	res, err := h.vm.Call(ctx, &vm.CallInfo{
 		ContractAddress: &funcCall.ContractAddress,
 		Selector:        &funcCall.EntryPointSelector,
 		Calldata:        funcCall.Calldata,
@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/feed"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceNewHeadsExits(t *testing.T) {
+	h := New(nil, nil, nil, "", &utils.Mainnet, utils.NewNopZapLogger())
+
+	run := func(ctx context.Context, sub *feed.Subscription[*core.Header]) <-chan struct{} {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			h.traceNewHeads(ctx, sub)
+		}()
+		return done
+	}
+	requireDone := func(t *testing.T, done <-chan struct{}) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "traceNewHeads did not return")
+		}
+	}
+
+	t.Run("context cancelled", func(t *testing.T) {
+		sub := feed.New[*core.Header]().Subscribe()
+		t.Cleanup(sub.Unsubscribe)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := run(ctx, sub)
+		cancel()
+		requireDone(t, done)
+	})
+
+	t.Run("subscription closed", func(t *testing.T) {
+		sub := feed.New[*core.Header]().Subscribe()
+		done := run(context.Background(), sub)
+		sub.Unsubscribe()
+		requireDone(t, done)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/NethermindEth/juno/blockchain"
 	"github.com/NethermindEth/juno/clients/feeder"
@@ -13,9 +14,12 @@ import (
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/feed"
+	"github.com/NethermindEth/juno/jsonrpc"
 	"github.com/NethermindEth/juno/mocks"
 	"github.com/NethermindEth/juno/rpc"
 	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/NethermindEth/juno/vm"
 	"github.com/stretchr/testify/assert"
@@ -59,15 +63,20 @@ func TestTraceFallback(t *testing.T) {
 				return mockReader.BlockByNumber(test.blockNumber)
 			}).Times(2)
 			handler := rpc.New(mockReader, nil, nil, "", n, nil)
-			_, jErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber})
+			_, jErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber}, nil)
 			require.Equal(t, rpc.ErrInternal.Code, jErr.Code)
 
 			handler = handler.WithFeeder(client)
-			trace, jErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber})
+			trace, jErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber}, nil)
 			require.Nil(t, jErr)
 			jsonStr, err := json.Marshal(trace)
 			require.NoError(t, err)
 			assert.JSONEq(t, test.want, string(jsonStr))
+
+			// the feeder gateway doesn't provide access lists
+			_, jErr = handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber},
+				[]rpc.SimulationFlag{rpc.ReturnAccessListFlag})
+			require.Equal(t, jsonrpc.InvalidParams, jErr.Code)
 		})
 	}
 }
@@ -86,7 +95,7 @@ func TestTraceTransaction(t *testing.T) {
 		// Receipt() returns error related to db
 		mockReader.EXPECT().Receipt(hash).Return(nil, nil, uint64(0), db.ErrKeyNotFound)
 
-		trace, err := handler.TraceTransaction(context.Background(), *hash)
+		trace, err := handler.TraceTransaction(context.Background(), *hash, nil)
 		assert.Nil(t, trace)
 		assert.Equal(t, rpc.ErrTxnHashNotFound, err)
 	})
@@ -157,10 +166,10 @@ func TestTraceTransaction(t *testing.T) {
 		require.NoError(t, json.Unmarshal(json.RawMessage(vmTraceJSON), vmTrace))
 		consumedGas := []*felt.Felt{new(felt.Felt).SetUint64(1)}
 		overallFee := []*felt.Felt{new(felt.Felt).SetUint64(1)}
-		mockVM.EXPECT().Execute([]core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{},
-			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false, true).Return(overallFee, consumedGas, []vm.TransactionTrace{*vmTrace}, nil)
+		mockVM.EXPECT().Execute(gomock.Any(), []core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{},
+			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false, true, false).Return(overallFee, consumedGas, []vm.TransactionTrace{*vmTrace}, nil)
 
-		trace, err := handler.TraceTransaction(context.Background(), *hash)
+		trace, err := handler.TraceTransaction(context.Background(), *hash, nil)
 		require.Nil(t, err)
 
 		vmTrace.ExecutionResources = &vm.ExecutionResources{
@@ -244,10 +253,11 @@ func TestTraceTransaction(t *testing.T) {
 		require.NoError(t, json.Unmarshal(json.RawMessage(vmTraceJSON), vmTrace))
 		consumedGas := []*felt.Felt{new(felt.Felt).SetUint64(1)}
 		overallFee := []*felt.Felt{new(felt.Felt).SetUint64(1)}
-		mockVM.EXPECT().Execute([]core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{},
-			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false, true).Return(overallFee, consumedGas, []vm.TransactionTrace{*vmTrace}, nil)
+		vmTrace.AccessList = &vm.AccessList{Nonces: []felt.Felt{*header.SequencerAddress}}
+		mockVM.EXPECT().Execute(gomock.Any(), []core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{},
+			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false, true, true).Return(overallFee, consumedGas, []vm.TransactionTrace{*vmTrace}, nil)
 
-		trace, err := handler.TraceTransaction(context.Background(), *hash)
+		trace, err := handler.TraceTransaction(context.Background(), *hash, []rpc.SimulationFlag{rpc.ReturnAccessListFlag})
 		require.Nil(t, err)
 
 		vmTrace.ExecutionResources = &vm.ExecutionResources{
@@ -323,8 +333,8 @@ func TestTraceTransactionV0_6(t *testing.T) {
 	}`)
 		vmTrace := new(vm.TransactionTrace)
 		require.NoError(t, json.Unmarshal(vmTraceJSON, vmTrace))
-		mockVM.EXPECT().Execute([]core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{},
-			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false, false).Return(nil, nil, []vm.TransactionTrace{*vmTrace}, nil)
+		mockVM.EXPECT().Execute(gomock.Any(), []core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{},
+			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false, false, false).Return(nil, nil, []vm.TransactionTrace{*vmTrace}, nil)
 
 		trace, err := handler.TraceTransactionV0_6(context.Background(), *hash)
 		require.Nil(t, err)
@@ -378,8 +388,8 @@ func TestTraceTransactionV0_6(t *testing.T) {
 	}`)
 		vmTrace := new(vm.TransactionTrace)
 		require.NoError(t, json.Unmarshal(vmTraceJSON, vmTrace))
-		mockVM.EXPECT().Execute([]core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{},
-			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false, false).Return(nil, nil, []vm.TransactionTrace{*vmTrace}, nil)
+		mockVM.EXPECT().Execute(gomock.Any(), []core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{},
+			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false, false, false).Return(nil, nil, []vm.TransactionTrace{*vmTrace}, nil)
 
 		trace, err := handler.TraceTransactionV0_6(context.Background(), *hash)
 		require.Nil(t, err)
@@ -404,7 +414,7 @@ func TestTraceBlockTransactions(t *testing.T) {
 			chain := blockchain.New(pebble.NewMemTest(t), n)
 			handler := rpc.New(chain, nil, nil, "", n, log)
 
-			update, rpcErr := handler.TraceBlockTransactions(context.Background(), id)
+			update, rpcErr := handler.TraceBlockTransactions(context.Background(), id, nil)
 			assert.Nil(t, update)
 			assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
 		})
@@ -469,10 +479,10 @@ func TestTraceBlockTransactions(t *testing.T) {
 		}`)
 		vmTrace := vm.TransactionTrace{}
 		require.NoError(t, json.Unmarshal(vmTraceJSON, &vmTrace))
-		mockVM.EXPECT().Execute(block.Transactions, []core.Class{declaredClass.Class}, paidL1Fees, &vm.BlockInfo{Header: header},
-			gomock.Any(), n, false, false, false, false).Return(nil, []vm.TransactionTrace{vmTrace, vmTrace}, nil)
+		mockVM.EXPECT().Execute(gomock.Any(), block.Transactions, []core.Class{declaredClass.Class}, paidL1Fees, &vm.BlockInfo{Header: header},
+			gomock.Any(), n, false, false, false, false, false).Return(nil, []vm.TransactionTrace{vmTrace, vmTrace}, nil)
 
-		result, err := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: blockHash})
+		result, err := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: blockHash}, nil)
 		require.Nil(t, err)
 		assert.Equal(t, &vm.TransactionTrace{
 			ValidateInvocation:    &vm.FunctionInvocation{},
@@ -535,8 +545,8 @@ func TestTraceBlockTransactions(t *testing.T) {
 		}`)
 		vmTrace := vm.TransactionTrace{}
 		require.NoError(t, json.Unmarshal(vmTraceJSON, &vmTrace))
-		mockVM.EXPECT().Execute([]core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{}, &vm.BlockInfo{Header: header},
-			gomock.Any(), n, false, false, false, false).Return(nil, []vm.TransactionTrace{vmTrace}, nil)
+		mockVM.EXPECT().Execute(gomock.Any(), []core.Transaction{tx}, []core.Class{declaredClass.Class}, []*felt.Felt{}, &vm.BlockInfo{Header: header},
+			gomock.Any(), n, false, false, false, false, false).Return(nil, []vm.TransactionTrace{vmTrace}, nil)
 
 		expectedResult := []rpc.TracedBlockTransaction{
 			{
@@ -544,12 +554,112 @@ func TestTraceBlockTransactions(t *testing.T) {
 				TraceRoot:       &vmTrace,
 			},
 		}
-		result, err := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: blockHash})
+		result, err := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: blockHash}, nil)
 		require.Nil(t, err)
 		assert.Equal(t, expectedResult, result)
 	})
 }
 
+func TestTraceStore(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	n := utils.Ptr(utils.Mainnet)
+	client := feeder.NewTestClient(t, n)
+	gw := adaptfeeder.New(client)
+	log := utils.NewNopZapLogger()
+
+	newChain := func(t *testing.T) (*blockchain.Blockchain, *blockchain.TraceStore, *core.Block) {
+		testDB := pebble.NewMemTest(t)
+		chain := blockchain.New(testDB, n)
+		block, err := gw.BlockByNumber(context.Background(), 0)
+		require.NoError(t, err)
+		stateUpdate, err := gw.StateUpdate(context.Background(), 0)
+		require.NoError(t, err)
+		// blocks after 0.12.3 are traced by the VM
+		block.ProtocolVersion = "0.13.1"
+		block.GasPrice = new(felt.Felt).SetUint64(1)
+		require.NoError(t, chain.Store(block, &core.BlockCommitments{}, stateUpdate, nil))
+
+		store, err := blockchain.NewTraceStore(testDB, utils.Megabyte)
+		require.NoError(t, err)
+		return chain, store, block
+	}
+
+	expectExecute := func(mockVM *mocks.MockVM, block *core.Block) {
+		fees := make([]*felt.Felt, 0, len(block.Transactions))
+		dataGas := make([]*felt.Felt, 0, len(block.Transactions))
+		traces := make([]vm.TransactionTrace, 0, len(block.Transactions))
+		for range block.Transactions {
+			fees = append(fees, new(felt.Felt).SetUint64(1))
+			dataGas = append(dataGas, &felt.Zero)
+			traces = append(traces, vm.TransactionTrace{Type: vm.TxnDeploy, StateDiff: &vm.StateDiff{}})
+		}
+		mockVM.EXPECT().Execute(gomock.Any(), block.Transactions, nil, []*felt.Felt{}, gomock.Any(), gomock.Any(), n,
+			false, false, false, true, false).Return(fees, dataGas, traces, nil).Times(1)
+	}
+
+	t.Run("store on miss and serve hit", func(t *testing.T) {
+		chain, store, block := newChain(t)
+		mockVM := mocks.NewMockVM(mockCtrl)
+		expectExecute(mockVM, block)
+
+		handler := rpc.New(chain, nil, mockVM, "", n, log).WithTraceStore(store, false)
+		_, err := store.Traces(block.Number, block.Hash)
+		require.ErrorIs(t, err, blockchain.ErrTracesNotFound)
+
+		traced, rpcErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: block.Number}, nil)
+		require.Nil(t, rpcErr)
+		stored, err := store.Traces(block.Number, block.Hash)
+		require.NoError(t, err)
+		require.Len(t, stored, len(block.Transactions))
+
+		// a new handler has an empty cache, so the traces can only come from the store
+		handler = rpc.New(chain, nil, mocks.NewMockVM(mockCtrl), "", n, log).WithTraceStore(store, false)
+		served, rpcErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: block.Number}, nil)
+		require.Nil(t, rpcErr)
+
+		tracedJSON, err := json.Marshal(traced)
+		require.NoError(t, err)
+		servedJSON, err := json.Marshal(served)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(tracedJSON), string(servedJSON))
+	})
+
+	t.Run("eager tracing", func(t *testing.T) {
+		chain, store, block := newChain(t)
+		mockVM := mocks.NewMockVM(mockCtrl)
+		expectExecute(mockVM, block)
+
+		heads := feed.New[*core.Header]()
+		mockSyncReader := mocks.NewMockSyncReader(mockCtrl)
+		mockSyncReader.EXPECT().SubscribeNewHeads().Return(sync.HeaderSubscription{Subscription: heads.Subscribe()})
+		mockSyncReader.EXPECT().SubscribeReorgs().Return(sync.ReorgSubscription{Subscription: feed.New[*sync.Reorg]().Subscribe()})
+
+		handler := rpc.New(chain, mockSyncReader, mockVM, "", n, log).WithTraceStore(store, true)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			assert.NoError(t, handler.Run(ctx))
+		}()
+
+		// the head is sent until the tracer has subscribed, repeated heads are served from the cache
+		require.Eventually(t, func() bool {
+			heads.Send(block.Header)
+			_, err := store.Traces(block.Number, block.Hash)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Run did not return after the context was cancelled")
+		}
+	})
+}
+
 func TestCall(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	t.Cleanup(mockCtrl.Finish)
@@ -562,7 +672,7 @@ func TestCall(t *testing.T) {
 	t.Run("empty blockchain", func(t *testing.T) {
 		mockReader.EXPECT().HeadState().Return(nil, nil, db.ErrKeyNotFound)
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Latest: true})
+		res, rpcErr := handler.Call(context.Background(), rpc.FunctionCall{}, rpc.BlockID{Latest: true})
 		require.Nil(t, res)
 		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
 	})
@@ -570,7 +680,7 @@ func TestCall(t *testing.T) {
 	t.Run("non-existent block hash", func(t *testing.T) {
 		mockReader.EXPECT().StateAtBlockHash(&felt.Zero).Return(nil, nil, db.ErrKeyNotFound)
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Hash: &felt.Zero})
+		res, rpcErr := handler.Call(context.Background(), rpc.FunctionCall{}, rpc.BlockID{Hash: &felt.Zero})
 		require.Nil(t, res)
 		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
 	})
@@ -578,7 +688,7 @@ func TestCall(t *testing.T) {
 	t.Run("non-existent block number", func(t *testing.T) {
 		mockReader.EXPECT().StateAtBlockNumber(uint64(0)).Return(nil, nil, db.ErrKeyNotFound)
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Number: 0})
+		res, rpcErr := handler.Call(context.Background(), rpc.FunctionCall{}, rpc.BlockID{Number: 0})
 		require.Nil(t, res)
 		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
 	})
@@ -590,7 +700,7 @@ func TestCall(t *testing.T) {
 		mockReader.EXPECT().HeadsHeader().Return(new(core.Header), nil)
 		mockState.EXPECT().ContractClassHash(&felt.Zero).Return(nil, errors.New("unknown contract"))
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Latest: true})
+		res, rpcErr := handler.Call(context.Background(), rpc.FunctionCall{}, rpc.BlockID{Latest: true})
 		require.Nil(t, res)
 		assert.Equal(t, rpc.ErrContractNotFound, rpcErr)
 	})
@@ -618,14 +728,14 @@ func TestCall(t *testing.T) {
 		mockReader.EXPECT().HeadsHeader().Return(headsHeader, nil)
 		mockState.EXPECT().ContractClassHash(contractAddr).Return(classHash, nil)
 		mockReader.EXPECT().Network().Return(n)
-		mockVM.EXPECT().Call(&vm.CallInfo{
+		mockVM.EXPECT().Call(gomock.Any(), &vm.CallInfo{
 			ContractAddress: contractAddr,
 			ClassHash:       classHash,
 			Selector:        selector,
 			Calldata:        calldata,
 		}, &vm.BlockInfo{Header: headsHeader}, gomock.Any(), &utils.Mainnet, uint64(1337), true).Return(expectedRes, nil)
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{
+		res, rpcErr := handler.Call(context.Background(), rpc.FunctionCall{
 			ContractAddress:    *contractAddr,
 			EntryPointSelector: *selector,
 			Calldata:           calldata,
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+// balanceOfSelector is the selector of the balanceOf entry point of ERC20 and ERC721 tokens
+var balanceOfSelector = func() *felt.Felt {
+	selector, err := crypto.StarknetKeccak([]byte("balanceOf"))
+	if err != nil {
+		panic(err)
+	}
+	return selector
+}()
+
+// Transfer is a token transfer. Value is the amount of ERC20 transfers and the token id of ERC721 ones.
+type Transfer struct {
+	BlockNumber      uint64     `json:"block_number"`
+	TransactionHash  *felt.Felt `json:"transaction_hash"`
+	TransactionIndex uint64     `json:"transaction_index"`
+	EventIndex       uint64     `json:"event_index"`
+	Token            *felt.Felt `json:"token"`
+	From             *felt.Felt `json:"from"`
+	To               *felt.Felt `json:"to"`
+	Value            string     `json:"value"`
+}
+
+// BlockRange is an inclusive range of block numbers
+type BlockRange struct {
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+}
+
+// TransfersChunk is a page of transfers. IndexedBlocks are the ranges of blocks whose transfers are indexed, the
+// transfers of blocks outside of them are missing since they were synced while the index was disabled.
+type TransfersChunk struct {
+	Transfers         []*Transfer  `json:"transfers"`
+	IndexedBlocks     []BlockRange `json:"indexed_blocks"`
+	ContinuationToken string       `json:"continuation_token,omitempty"`
+}
+
+// Transfers returns the ERC20 and ERC721 transfers from or to an address in the order they happened, optionally only
+// the transfers of a single token. It requires the token transfer index.
+func (h *Handler) Transfers(address felt.Felt, token *felt.Felt, continuationToken string, chunkSize uint64) (
+	*TransfersChunk, *jsonrpc.Error,
+) {
+	start, rpcErr := chunkStart(chunkSize, continuationToken, func(token string) (*blockchain.TransferID, error) {
+		id := new(blockchain.TransferID)
+		return id, id.FromString(token)
+	})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	transfers, next, err := h.bcReader.Transfers(&address, token, start, chunkSize)
+	if err != nil {
+		if errors.Is(err, blockchain.ErrTransferIndexDisabled) {
+			return nil, ErrTransferIndexDisabled
+		}
+		return nil, ErrInternal.CloneWithData(err)
+	}
+
+	ranges, err := h.bcReader.IndexedTransferBlocks()
+	if err != nil {
+		return nil, ErrInternal.CloneWithData(err)
+	}
+
+	chunk := &TransfersChunk{
+		Transfers:     make([]*Transfer, 0, len(transfers)),
+		IndexedBlocks: make([]BlockRange, 0, len(ranges)),
+	}
+	for _, r := range ranges {
+		chunk.IndexedBlocks = append(chunk.IndexedBlocks, BlockRange{FromBlock: r.From, ToBlock: r.To})
+	}
+	for _, transfer := range transfers {
+		chunk.Transfers = append(chunk.Transfers, &Transfer{
+			BlockNumber:      transfer.BlockNumber,
+			TransactionHash:  transfer.TransactionHash,
+			TransactionIndex: transfer.TransactionIndex,
+			EventIndex:       transfer.EventIndex,
+			Token:            transfer.Token,
+			From:             transfer.From,
+			To:               transfer.To,
+			Value:            u256String(transfer.Value()),
+		})
+	}
+	if next != nil {
+		chunk.ContinuationToken = next.String()
+	}
+	return chunk, nil
+}
+
+// TokenBalance returns the balance of an address at a block, as reported by the balanceOf entry point of the token
+func (h *Handler) TokenBalance(ctx context.Context, token, address felt.Felt, id BlockID) (string, *jsonrpc.Error) {
+	res, rpcErr := h.Call(ctx, FunctionCall{
+		ContractAddress:    token,
+		EntryPointSelector: *balanceOfSelector,
+		Calldata:           []felt.Felt{address},
+	}, id)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+
+	// balanceOf returns a u256, tokens that predate it return a felt
+	switch len(res) {
+	case 1:
+		return u256String(res[0].BigInt(new(big.Int))), nil
+	case 2: //nolint:gomnd
+		balance := res[1].BigInt(new(big.Int))
+		balance.Lsh(balance, 128) //nolint:gomnd
+		return u256String(balance.Or(balance, res[0].BigInt(new(big.Int)))), nil
+	default:
+		return "", makeContractError(errors.New("balanceOf didn't return a u256"))
+	}
+}
+
+func u256String(value *big.Int) string {
+	return "0x" + value.Text(16) //nolint:gomnd
+}
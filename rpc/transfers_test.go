@@ -0,0 +1,130 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransfers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	handler := rpc.New(mockReader, nil, nil, "", &utils.Mainnet, utils.NewNopZapLogger())
+	address, token := new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2)
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		_, rpcErr := handler.Transfers(*address, nil, "", 0)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+		_, rpcErr = handler.Transfers(*address, nil, "", 10241)
+		assert.Equal(t, rpc.ErrPageSizeTooBig, rpcErr)
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, rpcErr := handler.Transfers(*address, nil, "x", 10)
+		assert.Equal(t, rpc.ErrInvalidContinuationToken, rpcErr)
+	})
+
+	t.Run("disabled index", func(t *testing.T) {
+		mockReader.EXPECT().Transfers(address, nil, nil, uint64(10)).Return(nil, nil, blockchain.ErrTransferIndexDisabled)
+		_, rpcErr := handler.Transfers(*address, nil, "", 10)
+		assert.Equal(t, rpc.ErrTransferIndexDisabled, rpcErr)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		start := &blockchain.TransferID{BlockNumber: 1, TransactionIndex: 2, EventIndex: 3}
+		next := &blockchain.TransferID{BlockNumber: 4}
+		transfer := &blockchain.Transfer{
+			BlockNumber:      1,
+			TransactionIndex: 2,
+			EventIndex:       3,
+			TransactionHash:  new(felt.Felt).SetUint64(3),
+			Token:            token,
+			From:             address,
+			To:               new(felt.Felt).SetUint64(4),
+			ValueLow:         new(felt.Felt).SetUint64(0xff),
+			ValueHigh:        new(felt.Felt).SetUint64(1),
+		}
+		mockReader.EXPECT().Transfers(address, token, start, uint64(1)).Return([]*blockchain.Transfer{transfer}, next, nil)
+		mockReader.EXPECT().IndexedTransferBlocks().Return([]blockchain.BlockRange{{From: 0, To: 3}, {From: 6, To: 9}}, nil)
+
+		chunk, rpcErr := handler.Transfers(*address, token, "1-2-3", 1)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, &rpc.TransfersChunk{
+			Transfers: []*rpc.Transfer{{
+				BlockNumber:      1,
+				TransactionHash:  transfer.TransactionHash,
+				TransactionIndex: 2,
+				EventIndex:       3,
+				Token:            token,
+				From:             address,
+				To:               transfer.To,
+				Value:            "0x1000000000000000000000000000000ff",
+			}},
+			IndexedBlocks:     []rpc.BlockRange{{FromBlock: 0, ToBlock: 3}, {FromBlock: 6, ToBlock: 9}},
+			ContinuationToken: "4-0-0",
+		}, chunk)
+	})
+}
+
+func TestTokenBalance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockState := mocks.NewMockStateHistoryReader(mockCtrl)
+	mockVM := mocks.NewMockVM(mockCtrl)
+	handler := rpc.New(mockReader, nil, mockVM, "", &utils.Mainnet, utils.NewNopZapLogger())
+
+	token, address, classHash := new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2), new(felt.Felt).SetUint64(3)
+	selector, err := crypto.StarknetKeccak([]byte("balanceOf"))
+	require.NoError(t, err)
+
+	expectCall := func(res []*felt.Felt, err error) {
+		mockReader.EXPECT().HeadState().Return(mockState, nopCloser, nil)
+		mockReader.EXPECT().HeadsHeader().Return(new(core.Header), nil)
+		mockState.EXPECT().ContractClassHash(token).Return(classHash, nil)
+		mockReader.EXPECT().Network().Return(&utils.Mainnet)
+		mockVM.EXPECT().Call(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, callInfo *vm.CallInfo, _, _, _, _, _ any) ([]*felt.Felt, error) {
+				assert.Equal(t, selector, callInfo.Selector)
+				assert.Equal(t, []felt.Felt{*address}, callInfo.Calldata)
+				return res, err
+			})
+	}
+
+	for name, test := range map[string]struct {
+		res  []*felt.Felt
+		want string
+	}{
+		"u256": {res: []*felt.Felt{new(felt.Felt).SetUint64(2), new(felt.Felt).SetUint64(1)}, want: "0x100000000000000000000000000000002"},
+		"felt": {res: []*felt.Felt{new(felt.Felt).SetUint64(10)}, want: "0xa"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectCall(test.res, nil)
+			balance, rpcErr := handler.TokenBalance(context.Background(), *token, *address, rpc.BlockID{Latest: true})
+			require.Nil(t, rpcErr)
+			assert.Equal(t, test.want, balance)
+		})
+	}
+
+	t.Run("call fails", func(t *testing.T) {
+		expectCall(nil, errors.New("not a token"))
+		_, rpcErr := handler.TokenBalance(context.Background(), *token, *address, rpc.BlockID{Latest: true})
+		assert.Equal(t, rpc.ErrContractError.Code, rpcErr.Code)
+	})
+}
@@ -16,8 +16,27 @@ const (
 	Rejected
 )
 
+// MarshalJSON encodes the zero value, which stands for an unknown status, as null
+func (es ExecutionStatus) MarshalJSON() ([]byte, error) {
+	switch es {
+	case 0:
+		return []byte("null"), nil
+	case Succeeded:
+		return []byte(`"SUCCEEDED"`), nil
+	case Reverted:
+		return []byte(`"REVERTED"`), nil
+	case Rejected:
+		return []byte(`"REJECTED"`), nil
+	default:
+		return nil, errors.New("unknown ExecutionStatus")
+	}
+}
+
+// UnmarshalJSON decodes null as the zero value, so that encoded unknown statuses can be decoded again
 func (es *ExecutionStatus) UnmarshalJSON(data []byte) error {
 	switch string(data) {
+	case "null":
+		*es = 0
 	case `"SUCCEEDED"`:
 		*es = Succeeded
 	case `"REVERTED"`:
@@ -39,8 +58,11 @@ const (
 	Received
 )
 
+// UnmarshalJSON decodes null as the zero value, which stands for an unknown status
 func (fs *FinalityStatus) UnmarshalJSON(data []byte) error {
 	switch string(data) {
+	case "null":
+		*fs = 0
 	case `"ACCEPTED_ON_L2"`:
 		*fs = AcceptedOnL2
 	case `"ACCEPTED_ON_L1"`:
@@ -186,7 +208,7 @@ type Transaction struct {
 type TransactionStatus struct {
 	Status           string          `json:"status"`
 	FinalityStatus   FinalityStatus  `json:"finality_status"`
-	ExecutionStatus  ExecutionStatus `json:"execution_status"`
+	ExecutionStatus  ExecutionStatus `json:"execution_status,omitempty"`
 	BlockHash        *felt.Felt      `json:"block_hash"`
 	BlockNumber      uint64          `json:"block_number"`
 	TransactionIndex uint64          `json:"transaction_index"`
@@ -1,6 +1,7 @@
 package starknet_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/NethermindEth/juno/starknet"
@@ -16,9 +17,38 @@ func TestUnmarshalExecutionStatus(t *testing.T) {
 	require.NoError(t, es.UnmarshalJSON([]byte(`"REVERTED"`)))
 	assert.Equal(t, starknet.Reverted, *es)
 
+	require.NoError(t, es.UnmarshalJSON([]byte("null")))
+	assert.Equal(t, starknet.ExecutionStatus(0), *es)
+
 	require.ErrorContains(t, es.UnmarshalJSON([]byte("ABC")), "unknown ExecutionStatus")
 }
 
+func TestMarshalExecutionStatus(t *testing.T) {
+	for status, want := range map[starknet.ExecutionStatus]string{
+		0:                  "null",
+		starknet.Succeeded: `"SUCCEEDED"`,
+		starknet.Reverted:  `"REVERTED"`,
+		starknet.Rejected:  `"REJECTED"`,
+	} {
+		got, err := status.MarshalJSON()
+		require.NoError(t, err)
+		assert.Equal(t, want, string(got))
+	}
+
+	got, err := json.Marshal(starknet.TransactionStatus{Status: "NOT_RECEIVED"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(got), "execution_status")
+
+	// statuses survive a round trip, including unknown ones
+	for _, status := range []starknet.ExecutionStatus{0, starknet.Succeeded, starknet.Reverted, starknet.Rejected} {
+		data, err := json.Marshal(status)
+		require.NoError(t, err)
+		var decoded starknet.ExecutionStatus
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, status, decoded)
+	}
+}
+
 func TestUnmarshalFinalityStatus(t *testing.T) {
 	fs := new(starknet.FinalityStatus)
 	require.NoError(t, fs.UnmarshalJSON([]byte(`"ACCEPTED_ON_L1"`)))
@@ -27,5 +57,8 @@ func TestUnmarshalFinalityStatus(t *testing.T) {
 	require.NoError(t, fs.UnmarshalJSON([]byte(`"ACCEPTED_ON_L2"`)))
 	assert.Equal(t, starknet.AcceptedOnL2, *fs)
 
+	require.NoError(t, fs.UnmarshalJSON([]byte("null")))
+	assert.Equal(t, starknet.FinalityStatus(0), *fs)
+
 	require.ErrorContains(t, fs.UnmarshalJSON([]byte("ABC")), "unknown FinalityStatus")
 }
@@ -0,0 +1,335 @@
+// Package archive reads the chain from files that hold the responses of the feeder gateway, so that a node can sync
+// without access to the feeder. The files are laid out like the fixtures of the feeder client, e.g.
+// block/<number>.json, state_update_with_block/<number>.json and class/<class hash>.json, either in a directory or
+// in a zip file.
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NethermindEth/juno/adapters/sn2core"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/starknet"
+	"github.com/NethermindEth/juno/starknetdata"
+)
+
+var _ starknetdata.StarknetData = (*Archive)(nil)
+
+var ErrNotFound = errors.New("not found in the archive")
+
+const (
+	blockDir                = "block"
+	stateUpdateDir          = "state_update"
+	stateUpdateWithBlockDir = "state_update_with_block"
+	signatureDir            = "signature"
+	transactionDir          = "transaction"
+	classDir                = "class"
+	compiledClassDir        = "compiled_class"
+
+	pendingID = "pending"
+	extension = ".json"
+)
+
+type Archive struct {
+	files  fs.FS
+	closer io.Closer
+
+	// the highest block number in the archive, archives don't change once opened
+	latestNumber uint64
+	latestErr    error
+}
+
+// New returns an archive that reads the files from fsys
+func New(fsys fs.FS) *Archive {
+	a := &Archive{
+		files: fsys,
+	}
+	a.latestNumber, a.latestErr = latest(fsys)
+	return a
+}
+
+// Open opens the archive at path, which is either a directory or a zip file
+func Open(archivePath string) (*Archive, error) {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return New(os.DirFS(archivePath)), nil
+	}
+
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("open zip archive: %w", err)
+	}
+	a := New(reader)
+	a.closer = reader
+	return a, nil
+}
+
+// Close closes the zip file the archive was opened from
+func (a *Archive) Close() error {
+	if a.closer == nil {
+		return nil
+	}
+	return a.closer.Close()
+}
+
+// read decodes the response stored under dir with the given ID into v
+func (a *Archive) read(dir, id string, v any) error {
+	file, err := a.files.Open(path.Join(dir, id+extension))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%s %s: %w", dir, id, ErrNotFound)
+		}
+		return err
+	}
+	defer file.Close()
+
+	if err = json.NewDecoder(file).Decode(v); err != nil {
+		return fmt.Errorf("decode %s %s: %w", dir, id, err)
+	}
+	return nil
+}
+
+// latest returns the highest block number in fsys
+func latest(fsys fs.FS) (uint64, error) {
+	var highest uint64
+	found := false
+	for _, dir := range []string{stateUpdateWithBlockDir, blockDir} {
+		entries, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return 0, err
+		}
+
+		for _, entry := range entries {
+			number, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), extension), 10, 64)
+			if err != nil {
+				// latest.json, pending.json and the like
+				continue
+			}
+			if !found || number > highest {
+				highest = number
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return 0, fmt.Errorf("blocks: %w", ErrNotFound)
+	}
+	return highest, nil
+}
+
+// BlockByNumber reads the block with the given number from the archive,
+// then adapts it to the core.Block type.
+func (a *Archive) BlockByNumber(_ context.Context, blockNumber uint64) (*core.Block, error) {
+	return a.block(strconv.FormatUint(blockNumber, 10))
+}
+
+// BlockLatest reads the block with the highest number in the archive,
+// then adapts it to the core.Block type.
+func (a *Archive) BlockLatest(_ context.Context) (*core.Block, error) {
+	if a.latestErr != nil {
+		return nil, a.latestErr
+	}
+	return a.block(strconv.FormatUint(a.latestNumber, 10))
+}
+
+// BlockPending reads the pending block from the archive, if it holds one,
+// then adapts it to the core.Block type.
+func (a *Archive) BlockPending(_ context.Context) (*core.Block, error) {
+	return a.block(pendingID)
+}
+
+func (a *Archive) block(blockID string) (*core.Block, error) {
+	response := new(starknet.Block)
+	err := a.read(blockDir, blockID, response)
+	if errors.Is(err, ErrNotFound) {
+		withBlock := new(starknet.StateUpdateWithBlock)
+		if a.read(stateUpdateWithBlockDir, blockID, withBlock) == nil && withBlock.Block != nil {
+			response, err = withBlock.Block, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if blockID == pendingID && response.Status != "PENDING" {
+		return nil, errors.New("no pending block")
+	}
+
+	sig, err := a.signature(blockID)
+	if err != nil {
+		return nil, err
+	}
+	return sn2core.AdaptBlock(response, sig)
+}
+
+func (a *Archive) signature(blockID string) (*starknet.Signature, error) {
+	if blockID == pendingID {
+		return nil, nil
+	}
+
+	sig := new(starknet.Signature)
+	if err := a.read(signatureDir, blockID, sig); err != nil {
+		return nil, fmt.Errorf("get signature for block %q: %w", blockID, err)
+	}
+	return sig, nil
+}
+
+// Transaction reads the transaction with the given hash from the archive,
+// then adapts it to the appropriate core.Transaction types.
+func (a *Archive) Transaction(_ context.Context, transactionHash *felt.Felt) (core.Transaction, error) {
+	response := new(starknet.TransactionStatus)
+	if err := a.read(transactionDir, transactionHash.String(), response); err != nil {
+		return nil, err
+	}
+	if response.Transaction == nil {
+		return nil, fmt.Errorf("%s %s: %w", transactionDir, transactionHash, ErrNotFound)
+	}
+	return sn2core.AdaptTransaction(response.Transaction)
+}
+
+// Class reads the class with the given hash from the archive,
+// then adapts it to the core.Class type.
+func (a *Archive) Class(_ context.Context, classHash *felt.Felt) (core.Class, error) {
+	response := new(starknet.ClassDefinition)
+	if err := a.read(classDir, classHash.String(), response); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case response.V1 != nil:
+		compiledClass, err := a.compiledClass(classHash)
+		if err != nil {
+			return nil, err
+		}
+		return sn2core.AdaptCairo1Class(response.V1, compiledClass)
+	case response.V0 != nil:
+		return sn2core.AdaptCairo0Class(response.V0)
+	default:
+		return nil, errors.New("empty class")
+	}
+}
+
+// compiledClass returns nil if the archive doesn't hold the compiled class or if it is deprecated, the feeder adapter
+// treats the latter the same way
+func (a *Archive) compiledClass(classHash *felt.Felt) (*starknet.CompiledClass, error) {
+	var definition json.RawMessage
+	if err := a.read(compiledClassDir, classHash.String(), &definition); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if deprecated, _ := starknet.IsDeprecatedCompiledClassDefinition(definition); deprecated {
+		return nil, nil
+	}
+
+	compiledClass := new(starknet.CompiledClass)
+	if err := json.Unmarshal(definition, compiledClass); err != nil {
+		return nil, err
+	}
+	return compiledClass, nil
+}
+
+func (a *Archive) stateUpdate(blockID string) (*core.StateUpdate, error) {
+	response := new(starknet.StateUpdate)
+	err := a.read(stateUpdateDir, blockID, response)
+	if errors.Is(err, ErrNotFound) {
+		withBlock := new(starknet.StateUpdateWithBlock)
+		if a.read(stateUpdateWithBlockDir, blockID, withBlock) == nil && withBlock.StateUpdate != nil {
+			response, err = withBlock.StateUpdate, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return sn2core.AdaptStateUpdate(response)
+}
+
+// StateUpdate reads the state update for a given block number from the archive,
+// then adapts it to the core.StateUpdate type.
+func (a *Archive) StateUpdate(_ context.Context, blockNumber uint64) (*core.StateUpdate, error) {
+	return a.stateUpdate(strconv.FormatUint(blockNumber, 10))
+}
+
+// StateUpdatePending reads the state update for the pending block from the archive,
+// then adapts it to the core.StateUpdate type.
+func (a *Archive) StateUpdatePending(_ context.Context) (*core.StateUpdate, error) {
+	return a.stateUpdate(pendingID)
+}
+
+func (a *Archive) stateUpdateWithBlock(blockID string) (*core.StateUpdate, *core.Block, error) {
+	response := new(starknet.StateUpdateWithBlock)
+	if err := a.read(stateUpdateWithBlockDir, blockID, response); err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			return nil, nil, err
+		}
+
+		// archives can hold the block and the state update in files of their own, like the feeder fixtures
+		stateUpdate, suErr := a.stateUpdate(blockID)
+		if suErr != nil {
+			return nil, nil, suErr
+		}
+		block, bErr := a.block(blockID)
+		if bErr != nil {
+			return nil, nil, bErr
+		}
+		return stateUpdate, block, nil
+	}
+	if response.Block == nil || response.StateUpdate == nil {
+		return nil, nil, fmt.Errorf("%s %s is incomplete", stateUpdateWithBlockDir, blockID)
+	}
+
+	if blockID == pendingID && response.Block.Status != "PENDING" {
+		return nil, nil, errors.New("no pending block")
+	}
+
+	sig, err := a.signature(blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var adaptedState *core.StateUpdate
+	var adaptedBlock *core.Block
+
+	if adaptedState, err = sn2core.AdaptStateUpdate(response.StateUpdate); err != nil {
+		return nil, nil, err
+	}
+
+	if adaptedBlock, err = sn2core.AdaptBlock(response.Block, sig); err != nil {
+		return nil, nil, err
+	}
+
+	return adaptedState, adaptedBlock, nil
+}
+
+// StateUpdatePendingWithBlock reads both pending state update and pending block from the archive,
+// then adapts them to the core.StateUpdate and core.Block types respectively
+func (a *Archive) StateUpdatePendingWithBlock(_ context.Context) (*core.StateUpdate, *core.Block, error) {
+	return a.stateUpdateWithBlock(pendingID)
+}
+
+// StateUpdateWithBlock reads both state update and block for a given block number from the archive,
+// then adapts them to the core.StateUpdate and core.Block types respectively
+func (a *Archive) StateUpdateWithBlock(_ context.Context, blockNumber uint64) (*core.StateUpdate, *core.Block, error) {
+	return a.stateUpdateWithBlock(strconv.FormatUint(blockNumber, 10))
+}
@@ -0,0 +1,220 @@
+package archive_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	gosync "sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/starknetdata/archive"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtures(network *utils.Network) *archive.Archive {
+	return archive.New(os.DirFS(filepath.Join("..", "..", "clients", "feeder", "testdata", network.String())))
+}
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	mainnet := fixtures(&utils.Mainnet)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Mainnet))
+
+	t.Run("block", func(t *testing.T) {
+		for _, number := range []uint64{0, 147, 11817} {
+			expected, err := gw.BlockByNumber(ctx, number)
+			require.NoError(t, err)
+			block, err := mainnet.BlockByNumber(ctx, number)
+			require.NoError(t, err)
+			assert.Equal(t, expected, block)
+		}
+	})
+
+	t.Run("latest block is the highest block in the archive", func(t *testing.T) {
+		block, err := mainnet.BlockLatest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(19199), block.Number)
+	})
+
+	t.Run("latest block is found when the archive is opened", func(t *testing.T) {
+		fixture := func(name string) *fstest.MapFile {
+			data, err := os.ReadFile(filepath.Join("..", "..", "clients", "feeder", "testdata", "mainnet", name))
+			require.NoError(t, err)
+			return &fstest.MapFile{Data: data}
+		}
+		files := fstest.MapFS{
+			"block/0.json":     fixture("block/0.json"),
+			"signature/0.json": fixture("signature/0.json"),
+		}
+		a := archive.New(files)
+		files["block/147.json"] = fixture("block/147.json")
+		files["signature/147.json"] = fixture("signature/147.json")
+
+		block, err := a.BlockLatest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), block.Number)
+	})
+
+	t.Run("state update with block", func(t *testing.T) {
+		expectedUpdate, expectedBlock, err := gw.StateUpdateWithBlock(ctx, 2)
+		require.NoError(t, err)
+		update, block, err := mainnet.StateUpdateWithBlock(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, expectedUpdate, update)
+		assert.Equal(t, expectedBlock, block)
+	})
+
+	t.Run("state update with block from separate files", func(t *testing.T) {
+		expected, err := gw.StateUpdate(ctx, 21656)
+		require.NoError(t, err)
+		update, err := mainnet.StateUpdate(ctx, 21656)
+		require.NoError(t, err)
+		assert.Equal(t, expected, update)
+
+		_, _, err = mainnet.StateUpdateWithBlock(ctx, 21656)
+		assert.ErrorIs(t, err, archive.ErrNotFound)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		expectedUpdate, expectedBlock, err := gw.StateUpdatePendingWithBlock(ctx)
+		require.NoError(t, err)
+		update, block, err := mainnet.StateUpdatePendingWithBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, expectedUpdate, update)
+		assert.Equal(t, expectedBlock, block)
+	})
+
+	t.Run("missing block", func(t *testing.T) {
+		_, err := mainnet.BlockByNumber(ctx, 10000000000)
+		assert.ErrorIs(t, err, archive.ErrNotFound)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		hash := utils.HexToFelt(t, "0x6d3e06989ee2245139cd677f59b4da7f360a27b2b614a4eb088fdf5862d23ee")
+		expected, err := gw.Transaction(ctx, hash)
+		require.NoError(t, err)
+		tx, err := mainnet.Transaction(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, expected, tx)
+	})
+
+	t.Run("cairo 0 class", func(t *testing.T) {
+		classHash := utils.HexToFelt(t, "0x3297a93c52357144b7da71296d7e8231c3e0959f0a1d37222204f2f7712010e")
+		expected, err := gw.Class(ctx, classHash)
+		require.NoError(t, err)
+		class, err := mainnet.Class(ctx, classHash)
+		require.NoError(t, err)
+		assert.Equal(t, expected, class)
+	})
+
+	t.Run("cairo 1 class", func(t *testing.T) {
+		classHash := utils.HexToFelt(t, "0x1cd2edfb485241c4403254d550de0a097fa76743cd30696f714a491a454bad5")
+		expected, err := adaptfeeder.New(feeder.NewTestClient(t, &utils.Integration)).Class(ctx, classHash)
+		require.NoError(t, err)
+		class, err := fixtures(&utils.Integration).Class(ctx, classHash)
+		require.NoError(t, err)
+		assert.Equal(t, expected, class)
+	})
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	client := feeder.NewTestClient(t, &utils.Mainnet)
+	log := utils.NewNopZapLogger()
+
+	for _, name := range []string{"archive.zip", "archive"} {
+		t.Run(name, func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), name)
+			writer, err := archive.Create(archivePath)
+			require.NoError(t, err)
+			require.NoError(t, archive.Download(ctx, client, 0, 2, writer, log))
+			require.NoError(t, writer.Close())
+
+			data, err := archive.Open(archivePath)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, data.Close())
+			})
+
+			latest, err := data.BlockLatest(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(2), latest.Number)
+
+			// the archive holds everything the synchronizer needs
+			chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+			syncCtx, cancel := context.WithTimeout(ctx, time.Second)
+			require.NoError(t, sync.New(chain, data, log, 0, false).Run(syncCtx))
+			cancel()
+
+			head, err := chain.Head()
+			require.NoError(t, err)
+			assert.Equal(t, latest, head)
+		})
+	}
+
+	t.Run("first block after the last block", func(t *testing.T) {
+		writer, err := archive.Create(filepath.Join(t.TempDir(), "archive"))
+		require.NoError(t, err)
+		assert.Error(t, archive.Download(ctx, client, 3, 2, writer, log))
+	})
+}
+
+// countingArchive counts the requests for the blocks after the last block of the archive
+type countingArchive struct {
+	*archive.Archive
+	last uint64
+
+	mu       gosync.Mutex
+	requests map[uint64]int
+}
+
+func (a *countingArchive) StateUpdateWithBlock(ctx context.Context, number uint64) (*core.StateUpdate, *core.Block, error) {
+	if number > a.last {
+		a.mu.Lock()
+		a.requests[number]++
+		a.mu.Unlock()
+	}
+	return a.Archive.StateUpdateWithBlock(ctx, number)
+}
+
+func TestSyncPastArchive(t *testing.T) {
+	ctx := context.Background()
+	client := feeder.NewTestClient(t, &utils.Mainnet)
+	log := utils.NewNopZapLogger()
+
+	archivePath := filepath.Join(t.TempDir(), "archive")
+	writer, err := archive.Create(archivePath)
+	require.NoError(t, err)
+	require.NoError(t, archive.Download(ctx, client, 0, 2, writer, log))
+	require.NoError(t, writer.Close())
+	data, err := archive.Open(archivePath)
+	require.NoError(t, err)
+
+	counting := &countingArchive{Archive: data, last: 2, requests: make(map[uint64]int)}
+	chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+	syncCtx, cancel := context.WithTimeout(ctx, time.Second)
+	require.NoError(t, sync.New(chain, counting, log, 0, false).Run(syncCtx))
+	cancel()
+
+	head, err := chain.Head()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), head.Number)
+
+	// the fetchers of the missing blocks back off instead of spinning
+	counting.mu.Lock()
+	defer counting.mu.Unlock()
+	require.NotEmpty(t, counting.requests)
+	for number, requests := range counting.requests {
+		assert.LessOrEqual(t, requests, 10, "block %d", number)
+	}
+}
@@ -0,0 +1,180 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/starknet"
+	"github.com/NethermindEth/juno/utils"
+)
+
+const downloadLogInterval = 1000
+
+// Writer stores the files of an archive
+type Writer interface {
+	Write(name string, data []byte) error
+	Close() error
+}
+
+// Create returns a writer of a new archive at path, a zip file if the path ends with .zip and a directory otherwise
+func Create(archivePath string) (Writer, error) {
+	if !strings.EqualFold(filepath.Ext(archivePath), ".zip") {
+		if err := os.MkdirAll(archivePath, 0o750); err != nil {
+			return nil, err
+		}
+		return dirWriter(archivePath), nil
+	}
+
+	file, err := os.OpenFile(archivePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &zipWriter{
+		file:   file,
+		writer: zip.NewWriter(file),
+	}, nil
+}
+
+type dirWriter string
+
+func (d dirWriter) Write(name string, data []byte) error {
+	filePath := filepath.Join(string(d), filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0o600)
+}
+
+func (d dirWriter) Close() error {
+	return nil
+}
+
+type zipWriter struct {
+	file   *os.File
+	writer *zip.Writer
+}
+
+func (z *zipWriter) Write(name string, data []byte) error {
+	w, err := z.writer.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (z *zipWriter) Close() error {
+	return errors.Join(z.writer.Close(), z.file.Close())
+}
+
+// Download writes the blocks from..to, their signatures and state updates, and the classes they refer to into an
+// archive. The responses are stored as the feeder returns them.
+func Download(ctx context.Context, client *feeder.Client, from, to uint64, archive Writer, log utils.SimpleLogger) error {
+	if from > to {
+		return fmt.Errorf("first block %d is after the last block %d", from, to)
+	}
+
+	classes := make(map[felt.Felt]struct{})
+	for number := from; number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := downloadBlock(ctx, client, number, classes, archive); err != nil {
+			return fmt.Errorf("download block %d: %w", number, err)
+		}
+		if (number-from+1)%downloadLogInterval == 0 {
+			log.Infow("Downloaded blocks", "number", number, "classes", len(classes))
+		}
+	}
+	log.Infow("Downloaded archive", "from", from, "to", to, "classes", len(classes))
+	return nil
+}
+
+func downloadBlock(ctx context.Context, client *feeder.Client, number uint64, classes map[felt.Felt]struct{}, archive Writer) error {
+	blockID := strconv.FormatUint(number, 10)
+	raw, err := client.Raw(ctx, "get_state_update", map[string]string{
+		"blockNumber":  blockID,
+		"includeBlock": "true",
+	})
+	if err != nil {
+		return err
+	}
+	if err = archive.Write(path.Join(stateUpdateWithBlockDir, blockID+extension), raw); err != nil {
+		return err
+	}
+
+	response := new(starknet.StateUpdateWithBlock)
+	if err = json.Unmarshal(raw, response); err != nil {
+		return err
+	}
+	if response.StateUpdate == nil {
+		return errors.New("no state update in the response")
+	}
+
+	if raw, err = client.Raw(ctx, "get_signature", map[string]string{"blockNumber": blockID}); err != nil {
+		return err
+	}
+	if err = archive.Write(path.Join(signatureDir, blockID+extension), raw); err != nil {
+		return err
+	}
+
+	stateDiff := response.StateUpdate.StateDiff
+	classHashes := make([]*felt.Felt, 0, len(stateDiff.DeployedContracts)+len(stateDiff.OldDeclaredContracts)+
+		len(stateDiff.DeclaredClasses))
+	for _, deployed := range stateDiff.DeployedContracts {
+		classHashes = append(classHashes, deployed.ClassHash)
+	}
+	classHashes = append(classHashes, stateDiff.OldDeclaredContracts...)
+	for _, declared := range stateDiff.DeclaredClasses {
+		classHashes = append(classHashes, declared.ClassHash)
+	}
+
+	for _, classHash := range classHashes {
+		if _, found := classes[*classHash]; found {
+			continue
+		}
+		if err = downloadClass(ctx, client, classHash, archive); err != nil {
+			return fmt.Errorf("download class %s: %w", classHash, err)
+		}
+		classes[*classHash] = struct{}{}
+	}
+	return nil
+}
+
+func downloadClass(ctx context.Context, client *feeder.Client, classHash *felt.Felt, archive Writer) error {
+	args := map[string]string{
+		"classHash":   classHash.String(),
+		"blockNumber": "pending",
+	}
+	raw, err := client.Raw(ctx, "get_class_by_hash", args)
+	if err != nil {
+		return err
+	}
+	if err = archive.Write(path.Join(classDir, classHash.String()+extension), raw); err != nil {
+		return err
+	}
+
+	class := new(starknet.ClassDefinition)
+	if err = json.Unmarshal(raw, class); err != nil {
+		return err
+	}
+	if class.V1 == nil {
+		return nil
+	}
+
+	if raw, err = client.Raw(ctx, "get_compiled_class_by_class_hash", args); err != nil {
+		return err
+	}
+	return archive.Write(path.Join(compiledClassDir, classHash.String()+extension), raw)
+}
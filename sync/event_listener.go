@@ -4,12 +4,12 @@ import "time"
 
 type EventListener interface {
 	OnSyncStepDone(op string, blockNum uint64, took time.Duration)
-	OnReorg(blockNum uint64)
+	OnReorg(blockNum, depth uint64)
 }
 
 type SelectiveListener struct {
 	OnSyncStepDoneCb func(op string, blockNum uint64, took time.Duration)
-	OnReorgCb        func(blockNum uint64)
+	OnReorgCb        func(blockNum, depth uint64)
 }
 
 func (l *SelectiveListener) OnSyncStepDone(op string, blockNum uint64, took time.Duration) {
@@ -18,8 +18,8 @@ func (l *SelectiveListener) OnSyncStepDone(op string, blockNum uint64, took time
 	}
 }
 
-func (l *SelectiveListener) OnReorg(blockNum uint64) {
+func (l *SelectiveListener) OnReorg(blockNum, depth uint64) {
 	if l.OnReorgCb != nil {
-		l.OnReorgCb(blockNum)
+		l.OnReorgCb(blockNum, depth)
 	}
 }
@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"errors"
+	"fmt"
 	"runtime"
 	"sync/atomic"
 	"time"
@@ -16,6 +17,10 @@ import (
 	"github.com/NethermindEth/juno/starknetdata"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/sourcegraph/conc/stream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -23,6 +28,20 @@ var (
 	_ Reader          = (*Synchronizer)(nil)
 )
 
+var tracer = otel.Tracer("github.com/NethermindEth/juno/sync")
+
+// ErrReorgTooDeep stops the sync when the data source doesn't agree with any of the last blocks of the local chain,
+// reverting them all automatically could wipe the database because of a misconfigured or faulty data source
+var ErrReorgTooDeep = errors.New("reorg is deeper than the maximum reorg depth")
+
+// DefaultMaxReorgDepth is the number of blocks the sync reverts at most to handle a reorg
+const DefaultMaxReorgDepth = 1000
+
+const (
+	minFetchRetryWait = 50 * time.Millisecond
+	maxFetchRetryWait = 4 * time.Second
+)
+
 const (
 	OpVerify = "verify"
 	OpStore  = "store"
@@ -34,6 +53,20 @@ type HeaderSubscription struct {
 	*feed.Subscription[*core.Header]
 }
 
+type ReorgSubscription struct {
+	*feed.Subscription[*Reorg]
+}
+
+// Reorg is a reorg handled by the Synchronizer: the Depth blocks up to OldHead were reverted in a single transaction.
+// CommonAncestor is the new local head, it is nil if the whole chain was reverted. NewHead is the block of the new
+// chain that didn't extend OldHead.
+type Reorg struct {
+	OldHead        *core.Header
+	NewHead        *core.Header
+	CommonAncestor *core.Header
+	Depth          uint64
+}
+
 // Todo: Since this is also going to be implemented by p2p package we should move this interface to node package
 //
 //go:generate mockgen -destination=../mocks/mock_synchronizer.go -package=mocks -mock_names Reader=MockSyncReader github.com/NethermindEth/juno/sync Reader
@@ -41,6 +74,7 @@ type Reader interface {
 	StartingBlockNumber() (uint64, error)
 	HighestBlockHeader() *core.Header
 	SubscribeNewHeads() HeaderSubscription
+	SubscribeReorgs() ReorgSubscription
 }
 
 // This is temporary and will be removed once the p2p synchronizer implements this interface.
@@ -58,6 +92,10 @@ func (n *NoopSynchronizer) SubscribeNewHeads() HeaderSubscription {
 	return HeaderSubscription{feed.New[*core.Header]().Subscribe()}
 }
 
+func (n *NoopSynchronizer) SubscribeReorgs() ReorgSubscription {
+	return ReorgSubscription{feed.New[*Reorg]().Subscribe()}
+}
+
 // Synchronizer manages a list of StarknetData to fetch the latest blockchain updates
 type Synchronizer struct {
 	blockchain          *blockchain.Blockchain
@@ -66,12 +104,18 @@ type Synchronizer struct {
 	startingBlockNumber *uint64
 	highestBlockHeader  atomic.Pointer[core.Header]
 	newHeads            *feed.Feed[*core.Header]
+	reorgs              *feed.Feed[*Reorg]
 
 	log      utils.SimpleLogger
 	listener EventListener
 
 	pendingPollInterval time.Duration
 	catchUpMode         bool
+	maxReorgDepth       uint64
+	stopSync            context.CancelCauseFunc
+
+	paused  atomic.Bool
+	resumed chan struct{}
 }
 
 func New(bc *blockchain.Blockchain, starkNetData starknetdata.StarknetData,
@@ -82,9 +126,12 @@ func New(bc *blockchain.Blockchain, starkNetData starknetdata.StarknetData,
 		starknetData:        starkNetData,
 		log:                 log,
 		newHeads:            feed.New[*core.Header](),
+		reorgs:              feed.New[*Reorg](),
 		pendingPollInterval: pendingPollInterval,
 		listener:            &SelectiveListener{},
 		readOnlyBlockchain:  readOnlyBlockchain,
+		maxReorgDepth:       DefaultMaxReorgDepth,
+		resumed:             make(chan struct{}, 1),
 	}
 	return s
 }
@@ -95,37 +142,97 @@ func (s *Synchronizer) WithListener(listener EventListener) *Synchronizer {
 	return s
 }
 
-// Run starts the Synchronizer, returns an error if the loop is already running
+// WithMaxReorgDepth sets the number of blocks the sync reverts at most to handle a reorg, deeper reorgs stop the
+// sync with ErrReorgTooDeep
+func (s *Synchronizer) WithMaxReorgDepth(depth uint64) *Synchronizer {
+	s.maxReorgDepth = depth
+	return s
+}
+
+// Run starts the Synchronizer, it returns ErrReorgTooDeep if the sync had to stop because of a reorg
 func (s *Synchronizer) Run(ctx context.Context) error {
-	s.syncBlocks(ctx)
-	return nil
+	return s.syncBlocks(ctx)
+}
+
+// Pause stops fetching and storing new blocks, including the pending block, until Resume is called. The blocks that
+// are being fetched are discarded.
+func (s *Synchronizer) Pause() {
+	if s.paused.CompareAndSwap(false, true) {
+		s.log.Infow("Pausing sync")
+	}
+}
+
+// Resume restarts the sync from the current head after a Pause
+func (s *Synchronizer) Resume() {
+	if s.paused.CompareAndSwap(true, false) {
+		s.log.Infow("Resuming sync")
+		select {
+		case s.resumed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Paused returns true if the sync is paused
+func (s *Synchronizer) Paused() bool {
+	return s.paused.Load()
+}
+
+// waitWhilePaused returns once the sync is resumed or ctx is done
+func (s *Synchronizer) waitWhilePaused(ctx context.Context) {
+	for s.paused.Load() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.resumed:
+		}
+	}
 }
 
 func (s *Synchronizer) fetcherTask(ctx context.Context, height uint64, verifiers *stream.Stream,
 	resetStreams context.CancelFunc,
 ) stream.Callback {
-	for {
-		select {
-		case <-ctx.Done():
-			return func() {}
-		default:
-			stateUpdate, block, err := s.starknetData.StateUpdateWithBlock(ctx, height)
-			if err != nil {
-				continue
-			}
+	// the verify and store steps of the block are traced as children of its fetch
+	ctx, span := tracer.Start(ctx, "sync.Fetch", trace.WithAttributes(attribute.Int64("block.number", int64(height))))
+	defer span.End()
+
+	// data sources such as archives fail immediately for blocks they don't have, so failed fetches are retried with
+	// a backoff and only the last error is recorded
+	var (
+		lastErr error
+		retries int
+	)
+	defer func() {
+		if lastErr != nil {
+			span.RecordError(lastErr)
+			span.SetAttributes(attribute.Int("fetch.retries", retries))
+		}
+	}()
 
-			newClasses, err := s.fetchUnknownClasses(ctx, stateUpdate)
-			if err != nil {
-				continue
+	wait := minFetchRetryWait
+	for ctx.Err() == nil {
+		stateUpdate, block, err := s.starknetData.StateUpdateWithBlock(ctx, height)
+		if err == nil {
+			var newClasses map[felt.Felt]core.Class
+			if newClasses, err = s.fetchUnknownClasses(ctx, stateUpdate); err == nil {
+				return func() {
+					verifiers.Go(func() stream.Callback {
+						return s.verifierTask(ctx, block, stateUpdate, newClasses, resetStreams)
+					})
+				}
 			}
+		}
+		lastErr = err
 
-			return func() {
-				verifiers.Go(func() stream.Callback {
-					return s.verifierTask(ctx, block, stateUpdate, newClasses, resetStreams)
-				})
-			}
+		select {
+		case <-ctx.Done():
+		case <-time.After(wait):
+			retries++
+			wait *= 2
+			wait = min(wait, maxFetchRetryWait)
 		}
 	}
+	return func() {}
 }
 
 func (s *Synchronizer) fetchUnknownClasses(ctx context.Context, stateUpdate *core.StateUpdate) (map[felt.Felt]core.Class, error) {
@@ -184,10 +291,14 @@ func (s *Synchronizer) verifierTask(ctx context.Context, block *core.Block, stat
 	newClasses map[felt.Felt]core.Class, resetStreams context.CancelFunc,
 ) stream.Callback {
 	verifyTimer := time.Now()
+	_, verifySpan := tracer.Start(ctx, "sync.Verify")
 	commitments, err := s.blockchain.SanityCheckNewHeight(block, stateUpdate, newClasses)
 	if err == nil {
 		s.listener.OnSyncStepDone(OpVerify, block.Number, time.Since(verifyTimer))
+	} else {
+		verifySpan.SetStatus(codes.Error, err.Error())
 	}
+	verifySpan.End()
 	return func() {
 		select {
 		case <-ctx.Done():
@@ -199,13 +310,18 @@ func (s *Synchronizer) verifierTask(ctx context.Context, block *core.Block, stat
 				return
 			}
 			storeTimer := time.Now()
+			_, storeSpan := tracer.Start(ctx, "sync.Store")
 			err = s.blockchain.Store(block, commitments, stateUpdate, newClasses)
+			if err != nil {
+				storeSpan.SetStatus(codes.Error, err.Error())
+			}
+			storeSpan.End()
 			if err != nil {
 				if errors.Is(err, blockchain.ErrParentDoesNotMatchHead) {
-					// revert the head and restart the sync process, hoping that the reorg is not deep
-					// if the reorg is deeper, we will end up here again and again until we fully revert reorged
-					// blocks
-					s.revertHead(block)
+					// revert to the last block the data source agrees with and restart the sync process from there
+					if reorgErr := s.handleReorg(ctx, block); reorgErr != nil {
+						s.stopSync(reorgErr)
+					}
 				} else {
 					s.log.Warnw("Failed storing Block", "number", block.Number,
 						"hash", block.Hash.ShortString(), "err", err)
@@ -243,7 +359,11 @@ func (s *Synchronizer) nextHeight() uint64 {
 	return nextHeight
 }
 
-func (s *Synchronizer) syncBlocks(syncCtx context.Context) {
+func (s *Synchronizer) syncBlocks(ctx context.Context) error {
+	syncCtx, stopSync := context.WithCancelCause(ctx)
+	defer stopSync(nil)
+	s.stopSync = stopSync
+
 	defer func() {
 		s.startingBlockNumber = nil
 		s.highestBlockHeader.Store(nil)
@@ -256,7 +376,7 @@ func (s *Synchronizer) syncBlocks(syncCtx context.Context) {
 	latestSem := make(chan struct{}, 1)
 	if s.readOnlyBlockchain {
 		s.pollLatest(syncCtx, latestSem)
-		return
+		return nil
 	}
 
 	fetchers, verifiers := s.setupWorkers()
@@ -273,11 +393,15 @@ func (s *Synchronizer) syncBlocks(syncCtx context.Context) {
 			fetchers.Wait()
 			verifiers.Wait()
 
+			s.waitWhilePaused(syncCtx)
 			select {
 			case <-syncCtx.Done():
 				pendingSem <- struct{}{}
 				latestSem <- struct{}{}
-				return
+				if ctx.Err() != nil {
+					return nil
+				}
+				return context.Cause(syncCtx)
 			default:
 				streamCtx, streamCancel = context.WithCancel(syncCtx)
 				nextHeight = s.nextHeight()
@@ -285,6 +409,11 @@ func (s *Synchronizer) syncBlocks(syncCtx context.Context) {
 				s.log.Warnw("Restarting sync process", "height", nextHeight, "catchUpMode", s.catchUpMode)
 			}
 		default:
+			if s.paused.Load() {
+				// restart the streams once resumed, the blocks that were being fetched may be outdated by then
+				streamCancel()
+				continue
+			}
 			curHeight, curStreamCtx, curCancel := nextHeight, streamCtx, streamCancel
 			fetchers.Go(func() stream.Callback {
 				fetchTimer := time.Now()
@@ -313,22 +442,74 @@ func (s *Synchronizer) setupWorkers() (*stream.Stream, *stream.Stream) {
 	return stream.New().WithMaxGoroutines(numWorkers), stream.New().WithMaxGoroutines(runtime.GOMAXPROCS(0))
 }
 
-func (s *Synchronizer) revertHead(forkBlock *core.Block) {
-	var localHead *felt.Felt
-	head, err := s.blockchain.HeadsHeader()
-	if err == nil {
-		localHead = head.Hash
+// handleReorg reverts the local chain to its last block the data source agrees with. It only returns an error if
+// the sync has to stop, other failures are logged and the reorg is handled again once the sync restarts.
+func (s *Synchronizer) handleReorg(ctx context.Context, forkBlock *core.Block) error {
+	oldHead, err := s.blockchain.HeadsHeader()
+	if err != nil {
+		s.log.Warnw("Failed reading HEAD", "err", err)
+		return nil
+	}
+	s.log.Infow("Reorg detected", "localHead", oldHead.Hash, "forkHead", forkBlock.Hash)
+
+	ancestor, err := s.commonAncestor(ctx, oldHead, forkBlock)
+	if errors.Is(err, ErrReorgTooDeep) {
+		s.log.Errorw("Stopping sync, the reorg needs to be handled manually", "localHead", oldHead.Hash, "err", err)
+		return err
+	} else if err != nil {
+		if ctx.Err() == nil {
+			s.log.Warnw("Failed finding the common ancestor", "localHead", oldHead.Hash, "err", err)
+		}
+		return nil
 	}
 
-	s.log.Infow("Reorg detected", "localHead", localHead, "forkHead", forkBlock.Hash)
+	depth := oldHead.Number + 1
+	if ancestor != nil {
+		depth = oldHead.Number - ancestor.Number
+	}
+	if err = s.blockchain.RevertHeads(depth); err != nil {
+		s.log.Warnw("Failed reverting to the common ancestor", "localHead", oldHead.Hash, "depth", depth, "err", err)
+		return nil
+	}
 
-	err = s.blockchain.RevertHead()
-	if err != nil {
-		s.log.Warnw("Failed reverting HEAD", "reverted", localHead, "err", err)
+	reorg := &Reorg{OldHead: oldHead, NewHead: forkBlock.Header, CommonAncestor: ancestor, Depth: depth}
+	if ancestor != nil {
+		s.log.Infow("Reverted to the common ancestor", "number", ancestor.Number, "hash", ancestor.Hash.ShortString(),
+			"depth", depth)
 	} else {
-		s.log.Infow("Reverted HEAD", "reverted", localHead)
+		s.log.Infow("Reverted the whole chain, no block is common with the data source", "depth", depth)
+	}
+	s.reorgs.Send(reorg)
+	s.listener.OnReorg(oldHead.Number, depth)
+	return nil
+}
+
+// commonAncestor walks the local chain back from head until a block has the same hash at the data source, it
+// returns nil if even the genesis blocks differ. forkBlock is the block of the new chain that follows head.
+// It returns ErrReorgTooDeep if more than maxReorgDepth blocks would have to be reverted.
+func (s *Synchronizer) commonAncestor(ctx context.Context, head *core.Header, forkBlock *core.Block) (*core.Header, error) {
+	local, remoteHash := head, forkBlock.ParentHash
+	for {
+		if local.Hash.Equal(remoteHash) {
+			return local, nil
+		} else if head.Number-local.Number+1 > s.maxReorgDepth {
+			return nil, fmt.Errorf("%w: no block in common with the data source among the last %d blocks", ErrReorgTooDeep,
+				s.maxReorgDepth)
+		} else if local.Number == 0 {
+			return nil, nil
+		}
+
+		number := local.Number - 1
+		var err error
+		if local, err = s.blockchain.BlockHeaderByNumber(number); err != nil {
+			return nil, err
+		}
+		remote, err := s.starknetData.BlockByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		remoteHash = remote.Hash
 	}
-	s.listener.OnReorg(head.Number)
 }
 
 func (s *Synchronizer) pollPending(ctx context.Context, sem chan struct{}) {
@@ -343,6 +524,9 @@ func (s *Synchronizer) pollPending(ctx context.Context, sem chan struct{}) {
 			pendingPollTicker.Stop()
 			return
 		case <-pendingPollTicker.C:
+			if s.paused.Load() {
+				continue
+			}
 			select {
 			case sem <- struct{}{}:
 				go func() {
@@ -443,3 +627,10 @@ func (s *Synchronizer) SubscribeNewHeads() HeaderSubscription {
 		Subscription: s.newHeads.Subscribe(),
 	}
 }
+
+// SubscribeReorgs sends every reorg once its blocks are reverted
+func (s *Synchronizer) SubscribeReorgs() ReorgSubscription {
+	return ReorgSubscription{
+		Subscription: s.reorgs.Subscribe(),
+	}
+}
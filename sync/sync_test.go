@@ -11,6 +11,7 @@ import (
 	"github.com/NethermindEth/juno/clients/feeder"
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
 	"github.com/NethermindEth/juno/mocks"
 	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
@@ -169,10 +170,23 @@ func TestReorg(t *testing.T) {
 		require.Equal(t, utils.HexToFelt(t, "0x34e815552e42c5eb5233b99de2d3d7fd396e575df2719bf98e7ed2794494f86"), head.Hash)
 
 		synchronizer = sync.New(bc, mainGw, utils.NewNopZapLogger(), time.Duration(0), false)
+		reorgs := synchronizer.SubscribeReorgs()
+		defer reorgs.Unsubscribe()
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
 		require.NoError(t, synchronizer.Run(ctx))
 		cancel()
 
+		// The genesis blocks differ, the whole integration chain is reverted at once
+		select {
+		case reorg := <-reorgs.Recv():
+			assert.Equal(t, head.Hash, reorg.OldHead.Hash)
+			assert.Equal(t, head.Number+1, reorg.NewHead.Number)
+			assert.Nil(t, reorg.CommonAncestor)
+			assert.Equal(t, head.Number+1, reorg.Depth)
+		default:
+			require.Fail(t, "no reorg notification")
+		}
+
 		// After syncing (and reorging) the current head should be at mainnet
 		head, err = bc.HeadsHeader()
 		require.NoError(t, err)
@@ -180,6 +194,32 @@ func TestReorg(t *testing.T) {
 	})
 }
 
+func TestReorgTooDeep(t *testing.T) {
+	t.Parallel()
+	integGw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Integration))
+	mainGw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Mainnet))
+	testDB := pebble.NewMemTest(t)
+
+	bc := blockchain.New(testDB, &utils.Integration)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	require.NoError(t, sync.New(bc, integGw, utils.NewNopZapLogger(), time.Duration(0), false).Run(ctx))
+	cancel()
+	head, err := bc.HeadsHeader()
+	require.NoError(t, err)
+
+	// none of the integration blocks are on mainnet, reverting them all takes more than a single block
+	bc = blockchain.New(testDB, &utils.Mainnet)
+	synchronizer := sync.New(bc, mainGw, utils.NewNopZapLogger(), time.Duration(0), false).WithMaxReorgDepth(1)
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	require.ErrorIs(t, synchronizer.Run(ctx), sync.ErrReorgTooDeep)
+
+	// nothing was reverted
+	newHead, err := bc.HeadsHeader()
+	require.NoError(t, err)
+	assert.Equal(t, head, newHead)
+}
+
 func TestPending(t *testing.T) {
 	t.Parallel()
 
@@ -225,3 +265,37 @@ func TestSubscribeNewHeads(t *testing.T) {
 	require.Equal(t, want.Header, got)
 	sub.Unsubscribe()
 }
+
+func TestPauseAndResume(t *testing.T) {
+	t.Parallel()
+
+	client := feeder.NewTestClient(t, &utils.Mainnet)
+	gw := adaptfeeder.New(client)
+
+	testDB := pebble.NewMemTest(t)
+	bc := blockchain.New(testDB, &utils.Mainnet)
+	synchronizer := sync.New(bc, gw, utils.NewNopZapLogger(), 0, false)
+	synchronizer.Pause()
+	require.True(t, synchronizer.Paused())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		done <- synchronizer.Run(ctx)
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+	_, err := bc.Height()
+	require.ErrorIs(t, err, db.ErrKeyNotFound, "no blocks are stored while paused")
+
+	synchronizer.Resume()
+	require.False(t, synchronizer.Paused())
+	require.Eventually(t, func() bool {
+		height, heightErr := bc.Height()
+		return heightErr == nil && height == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
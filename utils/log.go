@@ -94,12 +94,13 @@ type SimpleLogger interface {
 
 type ZapLogger struct {
 	*zap.SugaredLogger
+	level zap.AtomicLevel
 }
 
 var _ Logger = (*ZapLogger)(nil)
 
 func NewNopZapLogger() *ZapLogger {
-	return &ZapLogger{zap.NewNop().Sugar()}
+	return &ZapLogger{zap.NewNop().Sugar(), zap.NewAtomicLevel()}
 }
 
 func NewZapLogger(logLevel LogLevel, colour bool) (*ZapLogger, error) {
@@ -123,7 +124,27 @@ func NewZapLogger(logLevel LogLevel, colour bool) (*ZapLogger, error) {
 		return nil, err
 	}
 
-	return &ZapLogger{log.Sugar()}, nil
+	return &ZapLogger{log.Sugar(), config.Level}, nil
+}
+
+// Level returns the minimum level of the messages that are logged
+func (l *ZapLogger) Level() LogLevel {
+	var level LogLevel
+	if err := level.Set(l.level.Level().String()); err != nil {
+		// Should not happen, the level can only be set from a LogLevel.
+		panic(err)
+	}
+	return level
+}
+
+// SetLevel changes the minimum level of the messages that are logged, it is safe to call while logging
+func (l *ZapLogger) SetLevel(logLevel LogLevel) error {
+	level, err := zapcore.ParseLevel(logLevel.String())
+	if err != nil {
+		return err
+	}
+	l.level.SetLevel(level)
+	return nil
 }
 
 func (l *ZapLogger) Warningf(msg string, args ...any) {
@@ -8,6 +8,7 @@ import (
 	"github.com/NethermindEth/juno/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 )
 
 var levelStrings = map[utils.LogLevel]string{
@@ -106,3 +107,18 @@ func TestZapWithoutColour(t *testing.T) {
 		})
 	}
 }
+
+func TestZapSetLevel(t *testing.T) {
+	log, err := utils.NewZapLogger(utils.INFO, false)
+	require.NoError(t, err)
+	assert.Equal(t, utils.INFO, log.Level())
+	assert.False(t, log.Desugar().Core().Enabled(zapcore.DebugLevel))
+
+	for level := range levelStrings {
+		require.NoError(t, log.SetLevel(level))
+		assert.Equal(t, level, log.Level())
+	}
+
+	require.NoError(t, log.SetLevel(utils.DEBUG))
+	assert.True(t, log.Desugar().Core().Enabled(zapcore.DebugLevel))
+}
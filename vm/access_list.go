@@ -0,0 +1,20 @@
+package vm
+
+import "github.com/NethermindEth/juno/core/felt"
+
+// AccessList is the set of state entries that were read while executing a transaction.
+// Writes are part of the StateDiff of the trace.
+//
+// The VM records the reads of each transaction below its transactional state, so reads served from
+// the writes or the cache of an earlier transaction of the same Execute call are included as well.
+type AccessList struct {
+	Storage     []StorageAccess `json:"storage"`
+	Nonces      []felt.Felt     `json:"nonces"`
+	ClassHashes []felt.Felt     `json:"class_hashes"`
+	Classes     []felt.Felt     `json:"classes"`
+}
+
+type StorageAccess struct {
+	Address felt.Felt   `json:"address"`
+	Keys    []felt.Felt `json:"keys"`
+}
@@ -0,0 +1,159 @@
+use std::collections::{BTreeMap, BTreeSet, HashMap};
+
+use blockifier::execution::contract_class::ContractClass;
+use blockifier::state::cached_state::CommitmentStateDiff;
+use blockifier::state::state_api::{StateReader, StateResult};
+use serde::Serialize;
+use starknet_api::core::{ClassHash, CompiledClassHash, ContractAddress, Nonce};
+use starknet_api::hash::StarkFelt;
+use starknet_api::state::StorageKey;
+
+/// The state entries read by a transaction, serialised the same way as vm.AccessList
+#[derive(Serialize, Default)]
+pub struct AccessList {
+    storage: Vec<StorageAccess>,
+    nonces: Vec<StarkFelt>,
+    class_hashes: Vec<StarkFelt>,
+    classes: Vec<StarkFelt>,
+}
+
+#[derive(Serialize)]
+struct StorageAccess {
+    address: StarkFelt,
+    keys: Vec<StarkFelt>,
+}
+
+/// AccessRecorder is the reader of the block state. If recording is enabled, the block state is
+/// recreated for every transaction so that none of its reads are served from the cache of an
+/// earlier transaction, and the recorder serves the writes of the earlier transactions instead.
+pub struct AccessRecorder<S: StateReader> {
+    state: S,
+    record: bool,
+
+    storage_writes: HashMap<(ContractAddress, StorageKey), StarkFelt>,
+    nonce_writes: HashMap<ContractAddress, Nonce>,
+    class_hash_writes: HashMap<ContractAddress, ClassHash>,
+    compiled_class_hash_writes: HashMap<ClassHash, CompiledClassHash>,
+    declared_classes: HashMap<ClassHash, ContractClass>,
+
+    storage_reads: BTreeMap<StarkFelt, BTreeSet<StarkFelt>>,
+    nonce_reads: BTreeSet<StarkFelt>,
+    class_hash_reads: BTreeSet<StarkFelt>,
+    class_reads: BTreeSet<StarkFelt>,
+}
+
+impl<S: StateReader> AccessRecorder<S> {
+    pub fn new(state: S, record: bool) -> Self {
+        Self {
+            state,
+            record,
+            storage_writes: HashMap::new(),
+            nonce_writes: HashMap::new(),
+            class_hash_writes: HashMap::new(),
+            compiled_class_hash_writes: HashMap::new(),
+            declared_classes: HashMap::new(),
+            storage_reads: BTreeMap::new(),
+            nonce_reads: BTreeSet::new(),
+            class_hash_reads: BTreeSet::new(),
+            class_reads: BTreeSet::new(),
+        }
+    }
+
+    pub fn is_recording(&self) -> bool {
+        self.record
+    }
+
+    /// Makes the writes of a transaction visible to the transactions that follow it
+    pub fn apply_writes(
+        &mut self,
+        diff: CommitmentStateDiff,
+        declared_class: Option<(ClassHash, ContractClass)>,
+    ) {
+        for (address, updates) in diff.storage_updates {
+            for (key, value) in updates {
+                self.storage_writes.insert((address, key), value);
+            }
+        }
+        self.nonce_writes.extend(diff.address_to_nonce);
+        self.class_hash_writes.extend(diff.address_to_class_hash);
+        self.compiled_class_hash_writes
+            .extend(diff.class_hash_to_compiled_class_hash);
+        if let Some((class_hash, class)) = declared_class {
+            self.declared_classes.insert(class_hash, class);
+        }
+    }
+
+    /// Returns the reads recorded since the last call, sorted so that the result is deterministic
+    pub fn take_access_list(&mut self) -> AccessList {
+        AccessList {
+            storage: std::mem::take(&mut self.storage_reads)
+                .into_iter()
+                .map(|(address, keys)| StorageAccess {
+                    address,
+                    keys: keys.into_iter().collect(),
+                })
+                .collect(),
+            nonces: std::mem::take(&mut self.nonce_reads).into_iter().collect(),
+            class_hashes: std::mem::take(&mut self.class_hash_reads)
+                .into_iter()
+                .collect(),
+            classes: std::mem::take(&mut self.class_reads).into_iter().collect(),
+        }
+    }
+}
+
+impl<S: StateReader> StateReader for AccessRecorder<S> {
+    fn get_storage_at(
+        &mut self,
+        contract_address: ContractAddress,
+        key: StorageKey,
+    ) -> StateResult<StarkFelt> {
+        if self.record {
+            self.storage_reads
+                .entry(*contract_address.0.key())
+                .or_default()
+                .insert(*key.0.key());
+        }
+        match self.storage_writes.get(&(contract_address, key)) {
+            Some(value) => Ok(*value),
+            None => self.state.get_storage_at(contract_address, key),
+        }
+    }
+
+    fn get_nonce_at(&mut self, contract_address: ContractAddress) -> StateResult<Nonce> {
+        if self.record {
+            self.nonce_reads.insert(*contract_address.0.key());
+        }
+        match self.nonce_writes.get(&contract_address) {
+            Some(nonce) => Ok(*nonce),
+            None => self.state.get_nonce_at(contract_address),
+        }
+    }
+
+    fn get_class_hash_at(&mut self, contract_address: ContractAddress) -> StateResult<ClassHash> {
+        if self.record {
+            self.class_hash_reads.insert(*contract_address.0.key());
+        }
+        match self.class_hash_writes.get(&contract_address) {
+            Some(class_hash) => Ok(*class_hash),
+            None => self.state.get_class_hash_at(contract_address),
+        }
+    }
+
+    fn get_compiled_contract_class(&mut self, class_hash: ClassHash) -> StateResult<ContractClass> {
+        if self.record {
+            self.class_reads.insert(class_hash.0);
+        }
+        match self.declared_classes.get(&class_hash) {
+            Some(class) => Ok(class.clone()),
+            None => self.state.get_compiled_contract_class(class_hash),
+        }
+    }
+
+    fn get_compiled_class_hash(&mut self, class_hash: ClassHash) -> StateResult<CompiledClassHash> {
+        match self.compiled_class_hash_writes.get(&class_hash) {
+            Some(compiled_class_hash) => Ok(*compiled_class_hash),
+            None => self.state.get_compiled_class_hash(class_hash),
+        }
+    }
+}
@@ -1,9 +1,11 @@
+mod access_list;
 pub mod jsonrpc;
 mod juno_state_reader;
 
 #[macro_use]
 extern crate lazy_static;
 
+use crate::access_list::AccessRecorder;
 use crate::juno_state_reader::{ptr_to_felt, JunoStateReader};
 use std::{
     collections::HashMap, ffi::{c_char, c_longlong, c_uchar, c_ulonglong, c_void, CStr, CString}, num::NonZeroU128, slice, sync::Arc
@@ -11,7 +13,7 @@ use std::{
 
 use blockifier::{
     block::{pre_process_block, BlockInfo as BlockifierBlockInfo, BlockNumberHashPair, GasPrices}, context::{BlockContext, ChainInfo, FeeTokenAddresses, TransactionContext}, execution::{
-        contract_class::ClassInfo,
+        contract_class::{ClassInfo, ContractClass},
         entry_point::{CallEntryPoint, CallType, EntryPointExecutionContext},
     }, fee::fee_utils::calculate_tx_fee, state::{cached_state::{CachedState, GlobalContractCache}, state_api::State}, transaction::{
         errors::TransactionExecutionError::{
@@ -42,6 +44,7 @@ extern "C" {
     fn JunoAppendResponse(reader_handle: usize, ptr: *const c_uchar);
     fn JunoAppendActualFee(reader_handle: usize, ptr: *const c_uchar);
     fn JunoAppendDataGasConsumed(reader_handle: usize, ptr: *const c_uchar);
+    fn JunoAppendAccessList(reader_handle: usize, json_access_list: *const c_void, len: usize);
 }
 
 #[repr(C)]
@@ -153,10 +156,14 @@ pub extern "C" fn cairoVMExecute(
     chain_id: *const c_char,
     skip_charge_fee: c_uchar,
     skip_validate: c_uchar,
-    err_on_revert: c_uchar
+    err_on_revert: c_uchar,
+    record_access: c_uchar
 ) {
     let block_info = unsafe { *block_info_ptr };
-    let reader = JunoStateReader::new(reader_handle, block_info.block_number);
+    let reader = AccessRecorder::new(
+        JunoStateReader::new(reader_handle, block_info.block_number),
+        record_access != 0,
+    );
     let chain_id_str = unsafe { CStr::from_ptr(chain_id) }.to_str().unwrap();
     let txn_json_str = unsafe { CStr::from_ptr(txns_json) }.to_str().unwrap();
     let txns_and_query_bits: Result<Vec<TxnAndQueryBit>, serde_json::Error> =
@@ -195,6 +202,11 @@ pub extern "C" fn cairoVMExecute(
     let validate = skip_validate == 0;
 
     let mut trace_buffer = Vec::with_capacity(10_000);
+    if state.state.is_recording() {
+        // the block pre-processing writes to the block state as well, reads of those writes have to reach the reader
+        state = renew_block_state(state, None);
+        state.state.take_access_list();
+    }
 
     for (txn_index, txn_and_query_bit) in txns_and_query_bits.iter().enumerate() {
         let class_info = match txn_and_query_bit.txn.clone() {
@@ -214,6 +226,12 @@ pub extern "C" fn cairoVMExecute(
             }
             _ => None,
         };
+        let declared_class = match (&txn_and_query_bit.txn, &class_info) {
+            (StarknetApiTransaction::Declare(declare_txn), Some(info)) if state.state.is_recording() => {
+                Some((declare_txn.class_hash(), info.contract_class()))
+            }
+            _ => None,
+        };
 
         let paid_fee_on_l1: Option<Fee> = match txn_and_query_bit.txn.clone() {
             StarknetApiTransaction::L1Handler(_) => {
@@ -239,6 +257,7 @@ pub extern "C" fn cairoVMExecute(
         }
 
         let mut txn_state = CachedState::create_transactional(&mut state);
+        let mut reverted = false;
         let fee_type;
         let res = match txn.unwrap() {
             Transaction::AccountTransaction(t) => {
@@ -281,6 +300,7 @@ pub extern "C" fn cairoVMExecute(
                     );
                     return;
                 }
+                reverted = t.is_reverted();
 
                 // we are estimating fee, override actual fee calculation
                 if  t.actual_fee.0 == 0 {
@@ -313,9 +333,29 @@ pub extern "C" fn cairoVMExecute(
             }
         }
         txn_state.commit();
+
+        if state.state.is_recording() {
+            state = renew_block_state(state, if reverted { None } else { declared_class });
+            append_access_list(reader_handle, &state.state.take_access_list(), &mut trace_buffer);
+        }
     }
 }
 
+type BlockState = CachedState<AccessRecorder<JunoStateReader>>;
+
+/// renew_block_state moves the writes of the last transaction to the reader of the block state and
+/// returns a block state with an empty cache, so that every read of the next transaction reaches the
+/// reader and is recorded.
+fn renew_block_state(
+    mut state: BlockState,
+    declared_class: Option<(ClassHash, ContractClass)>,
+) -> BlockState {
+    let diff = state.to_state_diff();
+    let mut reader = state.state;
+    reader.apply_writes(diff, declared_class);
+    CachedState::new(reader, GlobalContractCache::new(1))
+}
+
 fn felt_to_u128(felt: StarkFelt) -> u128 {
     let bytes = felt.bytes();
     let mut arr = [0u8; 16];
@@ -368,6 +408,19 @@ fn append_trace(
     };
 }
 
+fn append_access_list(
+    reader_handle: usize,
+    access_list: &access_list::AccessList,
+    buffer: &mut Vec<u8>,
+) {
+    buffer.clear();
+    serde_json::to_writer(&mut *buffer, access_list).unwrap();
+
+    unsafe {
+        JunoAppendAccessList(reader_handle, buffer.as_ptr() as *const c_void, buffer.len());
+    };
+}
+
 fn report_error(reader_handle: usize, msg: &str, txn_index: i64) {
     let err_msg = CString::new(msg).unwrap();
     unsafe {
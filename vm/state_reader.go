@@ -6,6 +6,7 @@ import "C"
 
 import (
 	"errors"
+	"time"
 	"unsafe"
 
 	"github.com/NethermindEth/juno/core/felt"
@@ -19,14 +20,15 @@ func JunoFree(ptr unsafe.Pointer) {
 
 //export JunoStateGetStorageAt
 func JunoStateGetStorageAt(readerHandle C.uintptr_t, contractAddress, storageLocation unsafe.Pointer) unsafe.Pointer {
-	context := unwrapContext(readerHandle)
+	callCtx := unwrapContext(readerHandle)
+	defer callCtx.countStateRead(time.Now())
 
 	contractAddressFelt := makeFeltFromPtr(contractAddress)
 	storageLocationFelt := makeFeltFromPtr(storageLocation)
-	val, err := context.state.ContractStorage(contractAddressFelt, storageLocationFelt)
+	val, err := callCtx.state.ContractStorage(contractAddressFelt, storageLocationFelt)
 	if err != nil {
 		if !errors.Is(err, db.ErrKeyNotFound) {
-			context.log.Errorw("JunoStateGetStorageAt failed to read contract storage", "err", err)
+			callCtx.log.Errorw("JunoStateGetStorageAt failed to read contract storage", "err", err)
 			return nil
 		}
 		val = &felt.Zero
@@ -37,13 +39,14 @@ func JunoStateGetStorageAt(readerHandle C.uintptr_t, contractAddress, storageLoc
 
 //export JunoStateGetNonceAt
 func JunoStateGetNonceAt(readerHandle C.uintptr_t, contractAddress unsafe.Pointer) unsafe.Pointer {
-	context := unwrapContext(readerHandle)
+	callCtx := unwrapContext(readerHandle)
+	defer callCtx.countStateRead(time.Now())
 
 	contractAddressFelt := makeFeltFromPtr(contractAddress)
-	val, err := context.state.ContractNonce(contractAddressFelt)
+	val, err := callCtx.state.ContractNonce(contractAddressFelt)
 	if err != nil {
 		if !errors.Is(err, db.ErrKeyNotFound) {
-			context.log.Errorw("JunoStateGetNonceAt failed to read contract nonce", "err", err)
+			callCtx.log.Errorw("JunoStateGetNonceAt failed to read contract nonce", "err", err)
 			return nil
 		}
 		val = &felt.Zero
@@ -54,13 +57,14 @@ func JunoStateGetNonceAt(readerHandle C.uintptr_t, contractAddress unsafe.Pointe
 
 //export JunoStateGetClassHashAt
 func JunoStateGetClassHashAt(readerHandle C.uintptr_t, contractAddress unsafe.Pointer) unsafe.Pointer {
-	context := unwrapContext(readerHandle)
+	callCtx := unwrapContext(readerHandle)
+	defer callCtx.countStateRead(time.Now())
 
 	contractAddressFelt := makeFeltFromPtr(contractAddress)
-	val, err := context.state.ContractClassHash(contractAddressFelt)
+	val, err := callCtx.state.ContractClassHash(contractAddressFelt)
 	if err != nil {
 		if !errors.Is(err, db.ErrKeyNotFound) {
-			context.log.Errorw("JunoStateGetClassHashAt failed to read contract class", "err", err)
+			callCtx.log.Errorw("JunoStateGetClassHashAt failed to read contract class", "err", err)
 			return nil
 		}
 		val = &felt.Zero
@@ -71,20 +75,21 @@ func JunoStateGetClassHashAt(readerHandle C.uintptr_t, contractAddress unsafe.Po
 
 //export JunoStateGetCompiledClass
 func JunoStateGetCompiledClass(readerHandle C.uintptr_t, classHash unsafe.Pointer) unsafe.Pointer {
-	context := unwrapContext(readerHandle)
+	callCtx := unwrapContext(readerHandle)
+	defer callCtx.countStateRead(time.Now())
 
 	classHashFelt := makeFeltFromPtr(classHash)
-	val, err := context.state.Class(classHashFelt)
+	val, err := callCtx.state.Class(classHashFelt)
 	if err != nil {
 		if !errors.Is(err, db.ErrKeyNotFound) {
-			context.log.Errorw("JunoStateGetCompiledClass failed to read class", "err", err)
+			callCtx.log.Errorw("JunoStateGetCompiledClass failed to read class", "err", err)
 		}
 		return nil
 	}
 
 	compiledClass, err := marshalClassInfo(val.Class)
 	if err != nil {
-		context.log.Errorw("JunoStateGetCompiledClass failed to marshal compiled class", "err", err)
+		callCtx.log.Errorw("JunoStateGetCompiledClass failed to marshal compiled class", "err", err)
 		return nil
 	}
 
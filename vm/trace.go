@@ -106,6 +106,7 @@ type TransactionTrace struct {
 	FunctionInvocation    *FunctionInvocation `json:"function_invocation,omitempty"`
 	StateDiff             *StateDiff          `json:"state_diff,omitempty"`
 	ExecutionResources    *ExecutionResources `json:"execution_resources,omitempty"`
+	AccessList            *AccessList         `json:"access_list,omitempty"`
 }
 
 func (t *TransactionTrace) allInvocations() []*FunctionInvocation {
@@ -0,0 +1,32 @@
+package vm
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/NethermindEth/juno/vm")
+
+// countStateRead records a state read done through a callback, meant to be deferred with the start time of the read
+func (c *callContext) countStateRead(start time.Time) {
+	c.stateReads++
+	c.stateReadDuration += time.Since(start)
+}
+
+func (c *callContext) traceStateReads(span trace.Span) {
+	span.SetAttributes(
+		attribute.Int("vm.state_reads", c.stateReads),
+		attribute.Int64("vm.state_read_time_us", c.stateReadDuration.Microseconds()),
+	)
+}
+
+// traceError marks the span as failed and returns err
+func traceError(span trace.Span, err error) error {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return err
+}
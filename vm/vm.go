@@ -33,7 +33,8 @@ extern void cairoVMCall(CallInfo* call_info_ptr, BlockInfo* block_info_ptr, uint
 
 extern void cairoVMExecute(char* txns_json, char* classes_json, char* paid_fees_on_l1_json,
 					BlockInfo* block_info_ptr, uintptr_t readerHandle,  char* chain_id,
-					unsigned char skip_charge_fee, unsigned char skip_validate, unsigned char err_on_revert);
+					unsigned char skip_charge_fee, unsigned char skip_validate, unsigned char err_on_revert,
+					unsigned char record_access);
 
 #cgo vm_debug  LDFLAGS: -L./rust/target/debug   -ljuno_starknet_rs -ldl -lm
 #cgo !vm_debug LDFLAGS: -L./rust/target/release -ljuno_starknet_rs -ldl -lm
@@ -41,23 +42,28 @@ extern void cairoVMExecute(char* txns_json, char* classes_json, char* paid_fees_
 import "C"
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"runtime"
 	"runtime/cgo"
+	"time"
 	"unsafe"
 
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate mockgen -destination=../mocks/mock_vm.go -package=mocks github.com/NethermindEth/juno/vm VM
 type VM interface {
-	Call(callInfo *CallInfo, blockInfo *BlockInfo, state core.StateReader, network *utils.Network, maxSteps uint64, useBlobData bool) ([]*felt.Felt, error) //nolint:lll
-	Execute(txns []core.Transaction, declaredClasses []core.Class, paidFeesOnL1 []*felt.Felt, blockInfo *BlockInfo,
-		state core.StateReader, network *utils.Network, skipChargeFee, skipValidate, errOnRevert, useBlobData bool,
+	Call(ctx context.Context, callInfo *CallInfo, blockInfo *BlockInfo, state core.StateReader, network *utils.Network, maxSteps uint64,
+		useBlobData bool) ([]*felt.Felt, error)
+	Execute(ctx context.Context, txns []core.Transaction, declaredClasses []core.Class, paidFeesOnL1 []*felt.Felt, blockInfo *BlockInfo,
+		state core.StateReader, network *utils.Network, skipChargeFee, skipValidate, errOnRevert, useBlobData, recordAccess bool,
 	) ([]*felt.Felt, []*felt.Felt, []TransactionTrace, error)
 }
 
@@ -86,47 +92,59 @@ type callContext struct {
 	actualFees      []*felt.Felt
 	traces          []json.RawMessage
 	dataGasConsumed []*felt.Felt
+	// state reads per executed transaction, recorded by the VM if requested
+	accessLists []json.RawMessage
+	// number of state reads done through the callbacks and the time spent on them
+	stateReads        int
+	stateReadDuration time.Duration
 }
 
 func unwrapContext(readerHandle C.uintptr_t) *callContext {
-	context, ok := cgo.Handle(readerHandle).Value().(*callContext)
+	callCtx, ok := cgo.Handle(readerHandle).Value().(*callContext)
 	if !ok {
 		panic("cannot cast reader")
 	}
 
-	return context
+	return callCtx
 }
 
 //export JunoReportError
 func JunoReportError(readerHandle C.uintptr_t, txnIndex C.long, str *C.char) {
-	context := unwrapContext(readerHandle)
-	context.errTxnIndex = int64(txnIndex)
-	context.err = C.GoString(str)
+	callCtx := unwrapContext(readerHandle)
+	callCtx.errTxnIndex = int64(txnIndex)
+	callCtx.err = C.GoString(str)
 }
 
 //export JunoAppendTrace
 func JunoAppendTrace(readerHandle C.uintptr_t, jsonBytes *C.void, bytesLen C.size_t) {
-	context := unwrapContext(readerHandle)
+	callCtx := unwrapContext(readerHandle)
 	byteSlice := C.GoBytes(unsafe.Pointer(jsonBytes), C.int(bytesLen))
-	context.traces = append(context.traces, json.RawMessage(byteSlice))
+	callCtx.traces = append(callCtx.traces, json.RawMessage(byteSlice))
+}
+
+//export JunoAppendAccessList
+func JunoAppendAccessList(readerHandle C.uintptr_t, jsonBytes *C.void, bytesLen C.size_t) {
+	callCtx := unwrapContext(readerHandle)
+	byteSlice := C.GoBytes(unsafe.Pointer(jsonBytes), C.int(bytesLen))
+	callCtx.accessLists = append(callCtx.accessLists, json.RawMessage(byteSlice))
 }
 
 //export JunoAppendResponse
 func JunoAppendResponse(readerHandle C.uintptr_t, ptr unsafe.Pointer) {
-	context := unwrapContext(readerHandle)
-	context.response = append(context.response, makeFeltFromPtr(ptr))
+	callCtx := unwrapContext(readerHandle)
+	callCtx.response = append(callCtx.response, makeFeltFromPtr(ptr))
 }
 
 //export JunoAppendActualFee
 func JunoAppendActualFee(readerHandle C.uintptr_t, ptr unsafe.Pointer) {
-	context := unwrapContext(readerHandle)
-	context.actualFees = append(context.actualFees, makeFeltFromPtr(ptr))
+	callCtx := unwrapContext(readerHandle)
+	callCtx.actualFees = append(callCtx.actualFees, makeFeltFromPtr(ptr))
 }
 
 //export JunoAppendDataGasConsumed
 func JunoAppendDataGasConsumed(readerHandle C.uintptr_t, ptr unsafe.Pointer) {
-	context := unwrapContext(readerHandle)
-	context.dataGasConsumed = append(context.dataGasConsumed, makeFeltFromPtr(ptr))
+	callCtx := unwrapContext(readerHandle)
+	callCtx.dataGasConsumed = append(callCtx.dataGasConsumed, makeFeltFromPtr(ptr))
 }
 
 func makeFeltFromPtr(ptr unsafe.Pointer) *felt.Felt {
@@ -209,15 +227,22 @@ func makeCBlockInfo(blockInfo *BlockInfo, useBlobData bool) C.BlockInfo {
 	return cBlockInfo
 }
 
-func (v *vm) Call(callInfo *CallInfo, blockInfo *BlockInfo, state core.StateReader,
+func (v *vm) Call(ctx context.Context, callInfo *CallInfo, blockInfo *BlockInfo, state core.StateReader,
 	network *utils.Network, maxSteps uint64, useBlobData bool,
 ) ([]*felt.Felt, error) {
-	context := &callContext{
+	_, span := tracer.Start(ctx, "vm.Call", trace.WithAttributes(
+		attribute.Stringer("vm.contract_address", callInfo.ContractAddress),
+		attribute.Stringer("vm.entry_point_selector", callInfo.Selector),
+		attribute.Int64("vm.block_number", int64(blockInfo.Header.Number)),
+	))
+	defer span.End()
+
+	callCtx := &callContext{
 		state:    state,
 		response: []*felt.Felt{},
 		log:      v.log,
 	}
-	handle := cgo.NewHandle(context)
+	handle := cgo.NewHandle(callCtx)
 	defer handle.Delete()
 
 	cCallInfo, callInfoPinner := makeCCallInfo(callInfo)
@@ -233,33 +258,44 @@ func (v *vm) Call(callInfo *CallInfo, blockInfo *BlockInfo, state core.StateRead
 	callInfoPinner.Unpin()
 	C.free(unsafe.Pointer(chainID))
 	C.free(unsafe.Pointer(cBlockInfo.version))
+	callCtx.traceStateReads(span)
 
-	if context.err != "" {
-		return nil, errors.New(context.err)
+	if callCtx.err != "" {
+		return nil, traceError(span, errors.New(callCtx.err))
 	}
-	return context.response, nil
+	return callCtx.response, nil
 }
 
-// Execute executes a given transaction set and returns the gas spent per transaction
-func (v *vm) Execute(txns []core.Transaction, declaredClasses []core.Class, paidFeesOnL1 []*felt.Felt,
+// Execute executes a given transaction set and returns the gas spent per transaction. If recordAccess is set, the
+// traces include the state read by each transaction.
+func (v *vm) Execute(ctx context.Context, txns []core.Transaction, declaredClasses []core.Class, paidFeesOnL1 []*felt.Felt,
 	blockInfo *BlockInfo, state core.StateReader, network *utils.Network,
-	skipChargeFee, skipValidate, errOnRevert, useBlobData bool,
+	skipChargeFee, skipValidate, errOnRevert, useBlobData, recordAccess bool,
 ) ([]*felt.Felt, []*felt.Felt, []TransactionTrace, error) {
-	context := &callContext{
+	_, span := tracer.Start(ctx, "vm.Execute", trace.WithAttributes(
+		attribute.Int("vm.transactions", len(txns)),
+		attribute.Int("vm.declared_classes", len(declaredClasses)),
+		attribute.Int64("vm.block_number", int64(blockInfo.Header.Number)),
+		attribute.Bool("vm.skip_charge_fee", skipChargeFee),
+		attribute.Bool("vm.skip_validate", skipValidate),
+	))
+	defer span.End()
+
+	callCtx := &callContext{
 		state: state,
 		log:   v.log,
 	}
-	handle := cgo.NewHandle(context)
+	handle := cgo.NewHandle(callCtx)
 	defer handle.Delete()
 
 	txnsJSON, classesJSON, err := marshalTxnsAndDeclaredClasses(txns, declaredClasses)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, traceError(span, err)
 	}
 
 	paidFeesOnL1Bytes, err := json.Marshal(paidFeesOnL1)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, traceError(span, err)
 	}
 
 	paidFeesOnL1CStr := cstring(paidFeesOnL1Bytes)
@@ -281,6 +317,11 @@ func (v *vm) Execute(txns []core.Transaction, declaredClasses []core.Class, paid
 		errOnRevertByte = 1
 	}
 
+	var recordAccessByte byte
+	if recordAccess {
+		recordAccessByte = 1
+	}
+
 	cBlockInfo := makeCBlockInfo(blockInfo, useBlobData)
 	chainID := C.CString(network.L2ChainID)
 	C.cairoVMExecute(txnsJSONCstr,
@@ -291,7 +332,8 @@ func (v *vm) Execute(txns []core.Transaction, declaredClasses []core.Class, paid
 		chainID,
 		C.uchar(skipChargeFeeByte),
 		C.uchar(skipValidateByte),
-		C.uchar(errOnRevertByte), //nolint:gocritic
+		C.uchar(errOnRevertByte),
+		C.uchar(recordAccessByte), //nolint:gocritic
 	)
 
 	C.free(unsafe.Pointer(classesJSONCStr))
@@ -299,26 +341,32 @@ func (v *vm) Execute(txns []core.Transaction, declaredClasses []core.Class, paid
 	C.free(unsafe.Pointer(txnsJSONCstr))
 	C.free(unsafe.Pointer(chainID))
 	C.free(unsafe.Pointer(cBlockInfo.version))
-
-	if context.err != "" {
-		if context.errTxnIndex >= 0 {
-			return nil, nil, nil, TransactionExecutionError{
-				Index: uint64(context.errTxnIndex),
-				Cause: errors.New(context.err),
-			}
+	callCtx.traceStateReads(span)
+
+	if callCtx.err != "" {
+		if callCtx.errTxnIndex >= 0 {
+			return nil, nil, nil, traceError(span, TransactionExecutionError{
+				Index: uint64(callCtx.errTxnIndex),
+				Cause: errors.New(callCtx.err),
+			})
 		}
-		return nil, nil, nil, errors.New(context.err)
+		return nil, nil, nil, traceError(span, errors.New(callCtx.err))
 	}
 
-	traces := make([]TransactionTrace, len(context.traces))
-	for index, traceJSON := range context.traces {
+	traces := make([]TransactionTrace, len(callCtx.traces))
+	for index, traceJSON := range callCtx.traces {
 		if err := json.Unmarshal(traceJSON, &traces[index]); err != nil {
-			return nil, nil, nil, fmt.Errorf("unmarshal trace: %v", err)
+			return nil, nil, nil, traceError(span, fmt.Errorf("unmarshal trace: %v", err))
+		}
+		if index < len(callCtx.accessLists) {
+			traces[index].AccessList = new(AccessList)
+			if err := json.Unmarshal(callCtx.accessLists[index], traces[index].AccessList); err != nil {
+				return nil, nil, nil, traceError(span, fmt.Errorf("unmarshal access list: %v", err))
+			}
 		}
-		//
 	}
 
-	return context.actualFees, context.dataGasConsumed, traces, nil
+	return callCtx.actualFees, callCtx.dataGasConsumed, traces, nil
 }
 
 func marshalTxnsAndDeclaredClasses(txns []core.Transaction, declaredClasses []core.Class) (json.RawMessage, json.RawMessage, error) { //nolint:lll
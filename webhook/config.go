@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"gopkg.in/yaml.v3"
+)
+
+// Notifications a sink can subscribe to
+const (
+	NotifyBlocks = "blocks"
+	NotifyEvents = "events"
+	NotifyReorgs = "reorgs"
+)
+
+// Config describes a sink. Notify lists the notifications sent to URL, all of them if empty. Address and Keys
+// filter the events the same way as starknet_getEvents does. Payloads are signed with Secret if it is set.
+// A new sink starts after the current head, or at StartBlock if it is set. StartBlock is ignored once the sink has
+// a cursor.
+type Config struct {
+	Name       string     `mapstructure:"name" yaml:"name"`
+	URL        string     `mapstructure:"url" yaml:"url"`
+	Secret     string     `mapstructure:"secret" yaml:"secret"`
+	Notify     []string   `mapstructure:"notify" yaml:"notify"`
+	Address    string     `mapstructure:"address" yaml:"address"`
+	Keys       [][]string `mapstructure:"keys" yaml:"keys"`
+	StartBlock *uint64    `mapstructure:"start_block" yaml:"start_block"`
+}
+
+// LoadConfig reads a YAML or JSON list of sinks
+func LoadConfig(path string) ([]Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read webhooks: %w", err)
+	}
+
+	var configs []Config
+	if err = yaml.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("parse webhooks: %w", err)
+	}
+	return configs, nil
+}
+
+func (c *Config) notifies(notification string) bool {
+	return len(c.Notify) == 0 || slices.Contains(c.Notify, notification)
+}
+
+func (c *Config) validate() error {
+	if c.Name == "" {
+		return errors.New("webhooks need a name")
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook %s: invalid URL %q", c.Name, c.URL)
+	}
+	for _, notification := range c.Notify {
+		if notification != NotifyBlocks && notification != NotifyEvents && notification != NotifyReorgs {
+			return fmt.Errorf("webhook %s: unknown notification %q (known: blocks, events, reorgs)", c.Name, notification)
+		}
+	}
+	return nil
+}
+
+type eventFilter struct {
+	address *felt.Felt
+	keys    []map[felt.Felt]struct{}
+}
+
+func newEventFilter(c *Config) (*eventFilter, error) {
+	filter := new(eventFilter)
+	if c.Address != "" {
+		address, err := new(felt.Felt).SetString(c.Address)
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s: invalid address: %w", c.Name, err)
+		}
+		filter.address = address
+	}
+
+	filter.keys = make([]map[felt.Felt]struct{}, len(c.Keys))
+	for index, keys := range c.Keys {
+		filter.keys[index] = make(map[felt.Felt]struct{}, len(keys))
+		for _, key := range keys {
+			value, err := new(felt.Felt).SetString(key)
+			if err != nil {
+				return nil, fmt.Errorf("webhook %s: invalid key: %w", c.Name, err)
+			}
+			filter.keys[index][*value] = struct{}{}
+		}
+	}
+	return filter, nil
+}
+
+// matches follows the semantics of starknet_getEvents: every position of the keys filter lists the accepted
+// values at that position of the event keys, an empty list accepts any value.
+func (f *eventFilter) matches(event *core.Event) bool {
+	if f.address != nil && !f.address.Equal(event.From) {
+		return false
+	}
+	for index, accepted := range f.keys {
+		if len(accepted) == 0 {
+			continue
+		}
+		if index >= len(event.Keys) {
+			return false
+		}
+		if _, found := accepted[*event.Keys[index]]; !found {
+			return false
+		}
+	}
+	return true
+}
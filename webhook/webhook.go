@@ -0,0 +1,405 @@
+// Package webhook POSTs JSON notifications about new blocks, events and reorgs to the configured sinks.
+//
+// Every sink has a cursor in the database that records the blocks delivered to it, so delivery resumes where it
+// stopped after a restart and the delivered blocks that were reverted in the meantime are reported as reorgs.
+// The cursor only keeps the last 64 delivered blocks: if a reorg reverts all of them, delivery resumes at the oldest
+// of them and the older blocks that were reverted as well are neither reported nor delivered again.
+// Delivery is at-least-once: a notification is retried until the sink acknowledges it with a 2xx status, and it
+// may be sent again if the node stops before the cursor is updated. The X-Juno-Delivery header identifies the
+// notification so sinks can drop duplicates.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+	"github.com/NethermindEth/juno/service"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/sourcegraph/conc"
+)
+
+const (
+	// cursorDepth is the number of delivered blocks that are checked for reorgs, keep the package doc in sync
+	cursorDepth = 64
+
+	defaultMinWait = time.Second
+	defaultMaxWait = time.Minute
+	defaultTimeout = 30 * time.Second
+)
+
+// Synchronizer notifies the service of new blocks and of reverted ones
+type Synchronizer interface {
+	SubscribeNewHeads() sync.HeaderSubscription
+	SubscribeReorgs() sync.ReorgSubscription
+}
+
+type BlockPayload struct {
+	Type             string     `json:"type"`
+	BlockNumber      uint64     `json:"block_number"`
+	BlockHash        *felt.Felt `json:"block_hash"`
+	ParentHash       *felt.Felt `json:"parent_hash"`
+	NewRoot          *felt.Felt `json:"new_root"`
+	Timestamp        uint64     `json:"timestamp"`
+	SequencerAddress *felt.Felt `json:"sequencer_address"`
+	TransactionCount uint64     `json:"transaction_count"`
+	EventCount       uint64     `json:"event_count"`
+}
+
+type Event struct {
+	TransactionHash *felt.Felt   `json:"transaction_hash"`
+	FromAddress     *felt.Felt   `json:"from_address"`
+	Keys            []*felt.Felt `json:"keys"`
+	Data            []*felt.Felt `json:"data"`
+}
+
+// EventsPayload holds the events of a block that match the filter of the sink
+type EventsPayload struct {
+	Type        string     `json:"type"`
+	BlockNumber uint64     `json:"block_number"`
+	BlockHash   *felt.Felt `json:"block_hash"`
+	Events      []Event    `json:"events"`
+}
+
+// ReorgPayload is sent for every delivered block that was reverted, newest first
+type ReorgPayload struct {
+	Type        string     `json:"type"`
+	BlockNumber uint64     `json:"block_number"`
+	BlockHash   *felt.Felt `json:"block_hash"`
+}
+
+type deliveredBlock struct {
+	Number uint64
+	Hash   *felt.Felt
+}
+
+// cursor is the state of a sink: the next block to deliver and the most recently delivered blocks, oldest first
+type cursor struct {
+	Next   uint64
+	Blocks []deliveredBlock
+}
+
+type sink struct {
+	config Config
+	filter *eventFilter
+}
+
+var _ service.Service = (*Service)(nil)
+
+type Service struct {
+	sinks        []*sink
+	bc           blockchain.Reader
+	database     db.DB
+	synchronizer Synchronizer
+	client       *http.Client
+	minWait      time.Duration
+	maxWait      time.Duration
+	log          utils.SimpleLogger
+}
+
+func New(configs []Config, bc blockchain.Reader, database db.DB, synchronizer Synchronizer,
+	log utils.SimpleLogger,
+) (*Service, error) {
+	names := make(map[string]struct{}, len(configs))
+	sinks := make([]*sink, 0, len(configs))
+	for _, config := range configs {
+		if err := config.validate(); err != nil {
+			return nil, err
+		}
+		if _, found := names[config.Name]; found {
+			return nil, fmt.Errorf("duplicate webhook %s", config.Name)
+		}
+		names[config.Name] = struct{}{}
+
+		filter, err := newEventFilter(&config)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, &sink{config: config, filter: filter})
+	}
+
+	return &Service{
+		sinks:        sinks,
+		bc:           bc,
+		database:     database,
+		synchronizer: synchronizer,
+		client:       &http.Client{Timeout: defaultTimeout},
+		minWait:      defaultMinWait,
+		maxWait:      defaultMaxWait,
+		log:          log,
+	}, nil
+}
+
+// WithRetryWait sets the bounds of the exponential backoff between delivery attempts
+func (s *Service) WithRetryWait(minWait, maxWait time.Duration) *Service {
+	s.minWait = minWait
+	s.maxWait = maxWait
+	return s
+}
+
+// WithTimeout sets the timeout of a delivery attempt
+func (s *Service) WithTimeout(timeout time.Duration) *Service {
+	s.client.Timeout = timeout
+	return s
+}
+
+// Run delivers the notifications to every sink independently, a sink that is down doesn't hold back the others
+func (s *Service) Run(ctx context.Context) error {
+	wg := conc.NewWaitGroup()
+	for _, sk := range s.sinks {
+		wg.Go(func() {
+			s.runSink(ctx, sk)
+		})
+	}
+	wg.Wait()
+	return nil
+}
+
+func (s *Service) runSink(ctx context.Context, sk *sink) {
+	// the subscriptions only wake the sink up, the blocks to deliver are read from the database
+	newHeads := s.synchronizer.SubscribeNewHeads()
+	defer newHeads.Unsubscribe()
+	reorgs := s.synchronizer.SubscribeReorgs()
+	defer reorgs.Unsubscribe()
+
+	for {
+		var retry <-chan time.Time
+		if err := s.deliver(ctx, sk); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.log.Errorw("Webhook delivery failed", "name", sk.config.Name, "retryAfter", s.maxWait.String(), "err", err)
+			retry = time.After(s.maxWait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-newHeads.Recv():
+		case <-reorgs.Recv():
+		case <-retry:
+		}
+	}
+}
+
+// deliver reports the delivered blocks that were reverted and then delivers the blocks up to the head
+func (s *Service) deliver(ctx context.Context, sk *sink) error {
+	cur, err := s.loadCursor(sk)
+	if err != nil {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		if err = s.revert(ctx, sk, cur); err != nil {
+			return err
+		}
+
+		var block *core.Block
+		block, err = s.bc.BlockByNumber(cur.Next)
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(cur.Blocks) > 0 && !block.ParentHash.Equal(cur.Blocks[len(cur.Blocks)-1].Hash) {
+			// the chain was reorged after the check, check again
+			continue
+		}
+
+		if err = s.deliverBlock(ctx, sk, block); err != nil {
+			return err
+		}
+		cur.Blocks = append(cur.Blocks, deliveredBlock{Number: block.Number, Hash: block.Hash})
+		if len(cur.Blocks) > cursorDepth {
+			cur.Blocks = cur.Blocks[len(cur.Blocks)-cursorDepth:]
+		}
+		cur.Next = block.Number + 1
+		if err = s.storeCursor(sk, cur); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// revert pops the delivered blocks that are no longer part of the chain, newest first
+func (s *Service) revert(ctx context.Context, sk *sink, cur *cursor) error {
+	for len(cur.Blocks) > 0 {
+		last := cur.Blocks[len(cur.Blocks)-1]
+		header, err := s.bc.BlockHeaderByNumber(last.Number)
+		if err == nil && header.Hash.Equal(last.Hash) {
+			return nil
+		} else if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+			return err
+		}
+
+		if sk.config.notifies(NotifyReorgs) {
+			payload := &ReorgPayload{Type: NotifyReorgs, BlockNumber: last.Number, BlockHash: last.Hash}
+			if err = s.post(ctx, sk, NotifyReorgs, last.Number, last.Hash, payload); err != nil {
+				return err
+			}
+		}
+		cur.Blocks = cur.Blocks[:len(cur.Blocks)-1]
+		cur.Next = last.Number
+		if err = s.storeCursor(sk, cur); err != nil {
+			return err
+		}
+		if len(cur.Blocks) == 0 {
+			s.log.Warnw("Webhook reorg is deeper than the delivered blocks the cursor keeps, older reverted blocks are "+
+				"not reported", "name", sk.config.Name, "number", last.Number)
+		}
+	}
+	return nil
+}
+
+func (s *Service) deliverBlock(ctx context.Context, sk *sink, block *core.Block) error {
+	if sk.config.notifies(NotifyBlocks) {
+		payload := &BlockPayload{
+			Type:             NotifyBlocks,
+			BlockNumber:      block.Number,
+			BlockHash:        block.Hash,
+			ParentHash:       block.ParentHash,
+			NewRoot:          block.GlobalStateRoot,
+			Timestamp:        block.Timestamp,
+			SequencerAddress: block.SequencerAddress,
+			TransactionCount: block.TransactionCount,
+			EventCount:       block.EventCount,
+		}
+		if err := s.post(ctx, sk, NotifyBlocks, block.Number, block.Hash, payload); err != nil {
+			return err
+		}
+	}
+
+	if sk.config.notifies(NotifyEvents) {
+		var events []Event
+		for _, receipt := range block.Receipts {
+			for _, event := range receipt.Events {
+				if sk.filter.matches(event) {
+					events = append(events, Event{
+						TransactionHash: receipt.TransactionHash,
+						FromAddress:     event.From,
+						Keys:            event.Keys,
+						Data:            event.Data,
+					})
+				}
+			}
+		}
+		if len(events) > 0 {
+			payload := &EventsPayload{Type: NotifyEvents, BlockNumber: block.Number, BlockHash: block.Hash, Events: events}
+			if err := s.post(ctx, sk, NotifyEvents, block.Number, block.Hash, payload); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// post retries until the sink acknowledges the notification or ctx is cancelled
+func (s *Service) post(ctx context.Context, sk *sink, notification string, number uint64, hash *felt.Felt,
+	payload any,
+) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	delivery := fmt.Sprintf("%s-%d-%s", notification, number, hash)
+
+	var wait time.Duration
+	for {
+		if err = s.send(ctx, sk, notification, delivery, body); err == nil {
+			return nil
+		}
+
+		wait = min(max(wait*2, s.minWait), s.maxWait)
+		s.log.Warnw("Failed to deliver webhook, retrying...", "name", sk.config.Name, "delivery", delivery,
+			"retryAfter", wait.String(), "err", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (s *Service) send(ctx context.Context, sk *sink, notification, delivery string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sk.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Juno-Event", notification)
+	req.Header.Set("X-Juno-Delivery", delivery)
+	if sk.config.Secret != "" {
+		req.Header.Set("X-Juno-Signature", Signature(sk.config.Secret, body))
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.New(res.Status)
+	}
+	return nil
+}
+
+// Signature returns the value of the X-Juno-Signature header of a payload: "sha256=" followed by the hex encoded
+// HMAC-SHA256 of the body keyed with the secret of the sink
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// loadCursor returns the state of the sink. A new sink starts after the current head unless a start block is
+// configured.
+func (s *Service) loadCursor(sk *sink) (*cursor, error) {
+	cur := new(cursor)
+	err := s.database.View(func(txn db.Transaction) error {
+		return txn.Get(db.WebhookCursors.Key([]byte(sk.config.Name)), func(val []byte) error {
+			return encoder.Unmarshal(val, cur)
+		})
+	})
+	if err == nil || !errors.Is(err, db.ErrKeyNotFound) {
+		return cur, err
+	}
+
+	if sk.config.StartBlock != nil {
+		cur.Next = *sk.config.StartBlock
+		return cur, s.storeCursor(sk, cur)
+	}
+
+	head, err := s.bc.HeadsHeader()
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return cur, nil
+	} else if err != nil {
+		return nil, err
+	}
+	cur.Next = head.Number + 1
+	cur.Blocks = []deliveredBlock{{Number: head.Number, Hash: head.Hash}}
+	return cur, s.storeCursor(sk, cur)
+}
+
+func (s *Service) storeCursor(sk *sink, cur *cursor) error {
+	val, err := encoder.Marshal(cur)
+	if err != nil {
+		return err
+	}
+	return s.database.Update(func(txn db.Transaction) error {
+		return txn.Set(db.WebhookCursors.Key([]byte(sk.config.Name)), val)
+	})
+}
@@ -0,0 +1,341 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	gosync "sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/feed"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+// fakeChain is a chain whose blocks can be replaced to simulate reorgs
+type fakeChain struct {
+	blockchain.Reader
+	mu     gosync.Mutex
+	blocks []*core.Block
+}
+
+func (c *fakeChain) set(blocks ...*core.Block) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocks = blocks
+}
+
+func (c *fakeChain) BlockByNumber(number uint64) (*core.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number >= uint64(len(c.blocks)) {
+		return nil, db.ErrKeyNotFound
+	}
+	return c.blocks[number], nil
+}
+
+func (c *fakeChain) BlockHeaderByNumber(number uint64) (*core.Header, error) {
+	block, err := c.BlockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header, nil
+}
+
+func (c *fakeChain) HeadsHeader() (*core.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.blocks) == 0 {
+		return nil, db.ErrKeyNotFound
+	}
+	return c.blocks[len(c.blocks)-1].Header, nil
+}
+
+type fakeSynchronizer struct {
+	newHeads *feed.Feed[*core.Header]
+	reorgs   *feed.Feed[*sync.Reorg]
+}
+
+func (s *fakeSynchronizer) SubscribeNewHeads() sync.HeaderSubscription {
+	return sync.HeaderSubscription{Subscription: s.newHeads.Subscribe()}
+}
+
+func (s *fakeSynchronizer) SubscribeReorgs() sync.ReorgSubscription {
+	return sync.ReorgSubscription{Subscription: s.reorgs.Subscribe()}
+}
+
+// makeChain returns blocks with a single event each, salt makes the hashes of the fork differ
+func makeChain(parent *core.Block, count int, salt uint64) []*core.Block {
+	var blocks []*core.Block
+	for range count {
+		header := &core.Header{ParentHash: &felt.Zero}
+		if parent != nil {
+			header.Number = parent.Number + 1
+			header.ParentHash = parent.Hash
+		}
+		header.Hash = new(felt.Felt).SetUint64(header.Number*1000 + salt)
+		block := &core.Block{
+			Header: header,
+			Receipts: []*core.TransactionReceipt{{
+				TransactionHash: new(felt.Felt).SetUint64(header.Number),
+				Events: []*core.Event{{
+					From: new(felt.Felt).SetUint64(header.Number % 2),
+					Keys: []*felt.Felt{new(felt.Felt).SetUint64(42)},
+					Data: []*felt.Felt{new(felt.Felt).SetUint64(header.Number)},
+				}},
+			}},
+		}
+		blocks = append(blocks, block)
+		parent = block
+	}
+	return blocks
+}
+
+type delivery struct {
+	event string
+	id    string
+	body  map[string]any
+}
+
+func newSink(t *testing.T, failures int32) (*httptest.Server, <-chan delivery) {
+	t.Helper()
+
+	deliveries := make(chan delivery, 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.Signature(secret, body), r.Header.Get("X-Juno-Signature"))
+
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, r.Header.Get("X-Juno-Event"), payload["type"])
+		deliveries <- delivery{event: r.Header.Get("X-Juno-Event"), id: r.Header.Get("X-Juno-Delivery"), body: payload}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, deliveries
+}
+
+func next(t *testing.T, deliveries <-chan delivery) delivery {
+	t.Helper()
+
+	select {
+	case d := <-deliveries:
+		return d
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no delivery")
+		return delivery{}
+	}
+}
+
+func assertNoDelivery(t *testing.T, deliveries <-chan delivery) {
+	t.Helper()
+
+	select {
+	case d := <-deliveries:
+		assert.Fail(t, "unexpected delivery", d.id)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func run(t *testing.T, service *webhook.Service) func() {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, service.Run(ctx))
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	testDB := pebble.NewMemTest(t)
+	chain := &fakeChain{}
+	synchronizer := &fakeSynchronizer{newHeads: feed.New[*core.Header](), reorgs: feed.New[*sync.Reorg]()}
+	blocks := makeChain(nil, 2, 0)
+	chain.set(blocks...)
+
+	srv, deliveries := newSink(t, 2)
+	configs := []webhook.Config{{
+		Name:   "sink",
+		URL:    srv.URL,
+		Secret: secret,
+		Keys:   [][]string{{"0x2a"}},
+		// only the events of odd blocks
+		Address: "0x1",
+	}}
+	newService := func() *webhook.Service {
+		service, err := webhook.New(configs, chain, testDB, synchronizer, utils.NewNopZapLogger())
+		require.NoError(t, err)
+		return service.WithRetryWait(time.Millisecond, 10*time.Millisecond)
+	}
+	stop := run(t, newService())
+	require.Eventually(t, func() bool {
+		return testDB.View(func(txn db.Transaction) error {
+			return txn.Get(db.WebhookCursors.Key([]byte("sink")), func([]byte) error { return nil })
+		}) == nil
+	}, 5*time.Second, time.Millisecond)
+
+	t.Run("starts after the head", func(t *testing.T) {
+		blocks = append(blocks, makeChain(blocks[1], 2, 0)...)
+		chain.set(blocks...)
+		synchronizer.newHeads.Send(blocks[3].Header)
+
+		// the failed deliveries are retried
+		d := next(t, deliveries)
+		assert.Equal(t, webhook.NotifyBlocks, d.event)
+		assert.Equal(t, "blocks-2-0x7d0", d.id)
+		assert.Equal(t, float64(2), d.body["block_number"])
+		assert.Equal(t, blocks[1].Hash.String(), d.body["parent_hash"])
+
+		d = next(t, deliveries)
+		assert.Equal(t, webhook.NotifyBlocks, d.event)
+		assert.Equal(t, float64(3), d.body["block_number"])
+
+		d = next(t, deliveries)
+		assert.Equal(t, webhook.NotifyEvents, d.event)
+		assert.Equal(t, float64(3), d.body["block_number"])
+		events := d.body["events"].([]any)
+		require.Len(t, events, 1)
+		assert.Equal(t, "0x1", events[0].(map[string]any)["from_address"])
+	})
+
+	t.Run("reorg", func(t *testing.T) {
+		blocks = append(blocks[:3:3], makeChain(blocks[2], 2, 1)...)
+		chain.set(blocks...)
+		synchronizer.reorgs.Send(&sync.Reorg{NewHead: blocks[3].Header, CommonAncestor: blocks[2].Header})
+
+		d := next(t, deliveries)
+		assert.Equal(t, webhook.NotifyReorgs, d.event)
+		assert.Equal(t, "reorgs-3-0xbb8", d.id)
+
+		for _, block := range blocks[3:] {
+			d = next(t, deliveries)
+			assert.Equal(t, webhook.NotifyBlocks, d.event)
+			assert.Equal(t, block.Hash.String(), d.body["block_hash"])
+			if block.Number%2 == 1 {
+				assert.Equal(t, webhook.NotifyEvents, next(t, deliveries).event)
+			}
+		}
+		// let the service record the last delivery before it is stopped
+		assertNoDelivery(t, deliveries)
+	})
+
+	t.Run("resumes after a restart", func(t *testing.T) {
+		stop()
+		// blocks 3 and 4 are reverted and 3 to 5 are added while the service is stopped
+		blocks = append(blocks[:3:3], makeChain(blocks[2], 3, 2)...)
+		chain.set(blocks...)
+		stop = run(t, newService())
+		defer stop()
+
+		for _, number := range []float64{4, 3} {
+			d := next(t, deliveries)
+			assert.Equal(t, webhook.NotifyReorgs, d.event)
+			assert.Equal(t, number, d.body["block_number"])
+		}
+		for _, block := range blocks[3:] {
+			d := next(t, deliveries)
+			assert.Equal(t, webhook.NotifyBlocks, d.event)
+			assert.Equal(t, block.Hash.String(), d.body["block_hash"])
+			if block.Number%2 == 1 {
+				assert.Equal(t, webhook.NotifyEvents, next(t, deliveries).event)
+			}
+		}
+		assertNoDelivery(t, deliveries)
+	})
+}
+
+func TestWebhookStartBlock(t *testing.T) {
+	chain := &fakeChain{}
+	synchronizer := &fakeSynchronizer{newHeads: feed.New[*core.Header](), reorgs: feed.New[*sync.Reorg]()}
+	blocks := makeChain(nil, 3, 0)
+	chain.set(blocks...)
+
+	srv, deliveries := newSink(t, 0)
+	configs := []webhook.Config{{
+		Name:       "sink",
+		URL:        srv.URL,
+		Secret:     secret,
+		Notify:     []string{webhook.NotifyBlocks},
+		StartBlock: utils.Ptr(uint64(1)),
+	}}
+	service, err := webhook.New(configs, chain, pebble.NewMemTest(t), synchronizer, utils.NewNopZapLogger())
+	require.NoError(t, err)
+	stop := run(t, service.WithRetryWait(time.Millisecond, 10*time.Millisecond))
+	defer stop()
+
+	// the blocks from the start block on are delivered although they are below the head
+	for _, block := range blocks[1:] {
+		d := next(t, deliveries)
+		assert.Equal(t, webhook.NotifyBlocks, d.event)
+		assert.Equal(t, block.Hash.String(), d.body["block_hash"])
+	}
+	assertNoDelivery(t, deliveries)
+}
+
+func TestNew(t *testing.T) {
+	log := utils.NewNopZapLogger()
+	for name, configs := range map[string][]webhook.Config{
+		"no name":              {{URL: "http://localhost"}},
+		"invalid URL":          {{Name: "sink", URL: "localhost"}},
+		"unknown notification": {{Name: "sink", URL: "http://localhost", Notify: []string{"transactions"}}},
+		"invalid address":      {{Name: "sink", URL: "http://localhost", Address: "address"}},
+		"invalid key":          {{Name: "sink", URL: "http://localhost", Keys: [][]string{{"key"}}}},
+		"duplicate name":       {{Name: "sink", URL: "http://localhost"}, {Name: "sink", URL: "http://localhost"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := webhook.New(configs, nil, nil, nil, log)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+- name: transfers
+  url: https://example.com/hook
+  secret: secret
+  notify: [events, reorgs]
+  address: "0x1"
+  keys: [["0x2a"], []]
+  start_block: 100
+`), 0o600))
+
+	configs, err := webhook.LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []webhook.Config{{
+		Name:       "transfers",
+		URL:        "https://example.com/hook",
+		Secret:     secret,
+		Notify:     []string{webhook.NotifyEvents, webhook.NotifyReorgs},
+		Address:    "0x1",
+		Keys:       [][]string{{"0x2a"}, {}},
+		StartBlock: utils.Ptr(uint64(100)),
+	}}, configs)
+}